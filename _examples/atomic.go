@@ -0,0 +1,56 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	cache := cachego.NewCache()
+	defer cache.Close()
+
+	// Use GetOrSet to get a key, or set it if it's absent, in one lock.
+	actual, found := cache.GetOrSet("key", 666, time.Minute)
+	fmt.Println(actual, found) // 666 false
+
+	// Use SetIfAbsent to set a key only if it's absent, which is useful for distributing tasks.
+	actual, stored := cache.SetIfAbsent("key", 888, time.Minute)
+	fmt.Println(actual, stored) // 666 false
+
+	// Use CompareAndSwap to set a key only if its value isn't changed by others.
+	// A nil equal func uses == to compare, so pass your own func if values aren't comparable.
+	swapped := cache.CompareAndSwap("key", 666, 888, time.Minute, nil)
+	fmt.Println(swapped) // true
+
+	// Use Update to read and modify a key in one lock, so no updates will be lost in concurrency.
+	// Return false as keep to remove the key.
+	value, kept := cache.Update("counter", func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		if !found {
+			return 1, cachego.NoTTL, true
+		}
+
+		return old.(int) + 1, cachego.NoTTL, true
+	})
+
+	fmt.Println(value, kept) // 1 true
+
+	// Use GetAndRemove to take a key away from cache.
+	value, found = cache.GetAndRemove("key")
+	fmt.Println(value, found) // 888 true
+}
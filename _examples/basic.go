@@ -28,6 +28,9 @@ func main() {
 	// Use WithGC to clean expired entries every 10 minutes.
 	cache := cachego.NewCache(cachego.WithGC(10*time.Minute), cachego.WithShardings(64))
 
+	// Close the cache to stop its gc task and release its entries if you don't need it anymore.
+	defer cache.Close()
+
 	// Set an entry to cache with ttl.
 	cache.Set("key", 123, time.Second)
 
@@ -0,0 +1,46 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use NewBytesCache to store []byte values in pre-allocated buffers, which is friendly to gc.
+	// Max cost is the total bytes of buffers and must be specified.
+	cache := cachego.NewBytesCache(cachego.WithMaxCost(64*1024*1024), cachego.WithShardings(16), cachego.WithMaxEntries(0))
+
+	if err := cache.Set("key", []byte("value"), time.Minute); err != nil {
+		panic(err)
+	}
+
+	// Get returns a copy of value.
+	value, found := cache.Get("key")
+	fmt.Println(string(value), found) // value true
+
+	// View borrows the value from buffer without copying, and it's only valid in fn.
+	cache.View("key", func(value []byte) {
+		fmt.Println(string(value)) // value
+	})
+
+	// Entries are evicted in FIFO order when buffers are full.
+	// Entries which can't fit in a buffer will return ErrEntryTooLarge.
+	err := cache.Set("large", make([]byte, 64*1024*1024), time.Minute)
+	fmt.Println(err == cachego.ErrEntryTooLarge) // true
+}
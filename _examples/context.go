@@ -0,0 +1,64 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use WithLoadTimeout to set a timeout of all loads called by LoadContext.
+	cache := cachego.NewCache(cachego.WithLoadTimeout(time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The caller returns right away if ctx is done, but the load function keeps going for others.
+	// The loaded value will be set to cache even if all callers leave.
+	value, err := cache.LoadContext(ctx, "key", time.Minute, func(ctx context.Context) (value interface{}, err error) {
+		time.Sleep(200 * time.Millisecond)
+		return 666, nil
+	})
+
+	fmt.Println(value, err) // <nil> context deadline exceeded
+
+	time.Sleep(200 * time.Millisecond)
+
+	value, ok := cache.Get("key")
+	fmt.Println(value, ok) // 666 true
+
+	// Use WithCancelAbandonedLoad if you want to cancel the load function after all callers leave.
+	cache = cachego.NewCache(cachego.WithLoadTimeout(time.Second), cachego.WithCancelAbandonedLoad())
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	value, err = cache.LoadContext(ctx, "key", time.Minute, func(ctx context.Context) (value interface{}, err error) {
+		select {
+		case <-time.After(200 * time.Millisecond):
+			return 666, nil
+		case <-ctx.Done():
+			fmt.Println("load is cancelled:", ctx.Err())
+			return nil, ctx.Err()
+		}
+	})
+
+	fmt.Println(value, err) // <nil> context deadline exceeded
+	time.Sleep(10 * time.Millisecond)
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use WithMaxCost and WithWeigher to bound the memory used by cache.
+	// The weigher computes the cost of each entry, and entries will be evicted until the total cost fits.
+	// Notice that only standard, lru and lfu caches support WithMaxCost.
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(key) + len(value.([]byte)))
+	}
+
+	cache, reporter := cachego.NewCacheWithReport(cachego.WithLRU(0), cachego.WithMaxCost(1024), cachego.WithWeigher(weigher))
+
+	cache.Set("key1", make([]byte, 500), cachego.NoTTL)
+	cache.Set("key2", make([]byte, 500), cachego.NoTTL)
+	fmt.Println(cache.Cost(), reporter.CacheCost()) // 1008 1008
+
+	// key1 will be evicted because there is no room for key3.
+	cache.Set("key3", make([]byte, 500), cachego.NoTTL)
+	fmt.Println(cache.Cost(), cache.Size()) // 1008 2
+
+	_, ok := cache.Get("key1")
+	fmt.Println(ok) // false
+
+	// Sharding caches give each sharding a proportional share of max cost.
+	cache = cachego.NewCache(cachego.WithShardings(4), cachego.WithMaxCost(4096), cachego.WithWeigher(weigher))
+}
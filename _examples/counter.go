@@ -0,0 +1,45 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+	"github.com/FishGoddess/cachego/pkg/limiter"
+)
+
+func main() {
+	cache := cachego.NewCache(cachego.WithShardings(64))
+	defer cache.Close()
+
+	// Use Incr and Decr to count something in cache.
+	// The key will be created with ttl at first, and its ttl won't be changed by later calls.
+	count := cache.Incr("requests", 1, time.Minute)
+	fmt.Println(count) // 1
+
+	count = cache.Decr("requests", 1, time.Minute)
+	fmt.Println(count) // 0
+
+	// Use limiter to limit the requests of each key in a window.
+	// A fixed window is cheap, and a sliding window smooths the burst around the boundary of two windows.
+	fixedWindow := limiter.NewFixedWindow(cache, 2, time.Second)
+	slidingWindow := limiter.NewSlidingWindow(cache, 2, time.Second)
+
+	for i := 0; i < 3; i++ {
+		fmt.Println(fixedWindow.Allow("api-key"), slidingWindow.Allow("api-key")) // true true, true true, false false
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	cache := cachego.NewCache(cachego.WithLRU(100))
+	cache.Set("key", 666, time.Minute)
+	cache.Set("expired", 666, time.Millisecond)
+
+	time.Sleep(2 * time.Millisecond)
+
+	// Use Dump to write all unexpired entries to a writer, like a file.
+	// Remaining ttl of entries will be dumped, and lru/lfu caches keep their orders/frequencies.
+	buffer := bytes.NewBuffer(nil)
+	if err := cachego.Dump(cache, buffer, cachego.GobCodec{}); err != nil {
+		panic(err)
+	}
+
+	// Use Restore to read entries from a reader, so your service won't start with an empty cache.
+	cache = cachego.NewCache(cachego.WithLRU(100))
+	if err := cachego.Restore(cache, buffer, cachego.GobCodec{}); err != nil {
+		panic(err)
+	}
+
+	value, ok := cache.Get("key")
+	fmt.Println(value, ok) // 666 true
+
+	value, ok = cache.Get("expired")
+	fmt.Println(value, ok) // <nil> false
+
+	// Remember to register your own value types if you are using gob codec:
+	// gob.Register(YourType{})
+	// Also, try JSONCodec if you want a readable format, and use UnmarshalValue to customize values.
+	codec := cachego.JSONCodec{
+		UnmarshalValue: func(key string, data []byte) (value interface{}, err error) {
+			return string(data), nil
+		},
+	}
+
+	buffer.Reset()
+	cachego.Dump(cache, buffer, codec)
+	fmt.Println(buffer.String()) // {"key":"key","value":666,"ttl":...}
+}
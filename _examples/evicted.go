@@ -0,0 +1,45 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use WithOnEvicted to know which entries are removed from cache and why.
+	// It's useful if you store some resources in cache, like files and connections.
+	// Notice that it's called with cache locked, so don't call methods of the same cache in it.
+	onEvicted := func(key string, value interface{}, cause cachego.RemovalCause) {
+		fmt.Printf("key %s with value %v is removed because of %s\n", key, value, cause)
+	}
+
+	cache := cachego.NewCache(cachego.WithLRU(1), cachego.WithOnEvicted(onEvicted))
+
+	cache.Set("key", 1, cachego.NoTTL)
+	cache.Set("key", 2, cachego.NoTTL)     // key with value 1 is removed because of replaced
+	cache.Set("another", 3, cachego.NoTTL) // key with value 2 is removed because of capacity
+	cache.Remove("another")                // another with value 3 is removed because of explicit
+	cache.Set("key", 4, time.Millisecond)
+
+	time.Sleep(2 * time.Millisecond)
+	cache.GC() // key with value 4 is removed because of expired
+
+	cache.Set("key", 5, cachego.NoTTL)
+	cache.Reset() // key with value 5 is removed because of reset
+}
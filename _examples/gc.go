@@ -86,4 +86,9 @@ func main() {
 	// This can be a serious problem in some situations.
 	// Use WithMaxScans to set this value, remember, a value <= 0 means no scan limit.
 	cache = cachego.NewCache(cachego.WithGC(10*time.Minute), cachego.WithMaxScans(0))
+
+	// If there are millions of entries in cache, scanning may miss expired entries for many rounds.
+	// Use WithExpirationIndex to index entries by their expirations, so gc removes exactly the expired entries.
+	// Notice that only standard, lru and lfu caches support it, and max scans will be ignored.
+	cache = cachego.NewCache(cachego.WithGC(10*time.Minute), cachego.WithExpirationIndex())
 }
@@ -0,0 +1,55 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/FishGoddess/cachego"
+	cachegohttp "github.com/FishGoddess/cachego/http"
+)
+
+func main() {
+	cache, reporter := cachego.NewCacheWithReport(cachego.WithCacheName("users"), cachego.WithShardings(16))
+	defer cache.Close()
+
+	cache.Set("user:1", "FishGoddess", cachego.NoTTL)
+
+	// Use an auth hook to check requests, and use WithReadOnly if nobody should modify caches.
+	auth := func(r *http.Request) error {
+		if _, password, ok := r.BasicAuth(); !ok || password != "secret" {
+			return errors.New("wrong password")
+		}
+
+		return nil
+	}
+
+	handler := cachegohttp.NewHandler(cachegohttp.WithAuth(auth))
+	handler.Register("users", cache, reporter)
+
+	// Try these requests:
+	//
+	//	curl -u admin:secret localhost:8080/debug/cachego/
+	//	curl -u admin:secret localhost:8080/debug/cachego/users/keys?prefix=user
+	//	curl -u admin:secret localhost:8080/debug/cachego/users/keys/user:1
+	//	curl -u admin:secret -X PUT -d FishGoddess localhost:8080/debug/cachego/users/keys/user:2?ttl=1m
+	//	curl -u admin:secret -X POST localhost:8080/debug/cachego/users/gc
+	http.Handle("/debug/cachego/", http.StripPrefix("/debug/cachego", handler))
+
+	if err := http.ListenAndServe("127.0.0.1:8080", nil); err != nil {
+		panic(err)
+	}
+}
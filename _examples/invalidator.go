@@ -0,0 +1,59 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use an invalidator to remove stale keys in other caches after writing keys.
+	// Channel invalidator works in one process, and use unix invalidator or implement your own one for more processes.
+	invalidator := cachego.NewChannelInvalidator()
+
+	cache := cachego.NewCache(cachego.WithInvalidator(invalidator))
+	defer cache.Close()
+
+	other := cachego.NewCache(cachego.WithInvalidator(invalidator))
+	defer other.Close()
+
+	other.Set("key", "stale", cachego.NoTTL)
+
+	// Setting key publishes an invalidation, so other removes its stale key.
+	cache.Set("key", "value", cachego.NoTTL)
+	time.Sleep(10 * time.Millisecond)
+
+	value, found := other.Get("key")
+	fmt.Println(value, found) // <nil> false
+
+	// Publish an invalidation without source to remove keys from all caches, and prefixes are supported.
+	invalidator.Publish(context.Background(), cachego.Invalidation{Key: "k", Prefix: true})
+	time.Sleep(10 * time.Millisecond)
+
+	value, found = cache.Get("key")
+	fmt.Println(value, found) // <nil> false
+
+	// Unix invalidator delivers invalidations between processes on the same host by unix datagram sockets.
+	unixInvalidator, err := cachego.NewUnixInvalidator("/tmp/cachego-a.sock", "/tmp/cachego-b.sock")
+	if err != nil {
+		panic(err)
+	}
+
+	defer unixInvalidator.Close()
+}
@@ -71,4 +71,10 @@ func main() {
 	// However, the result divided by max entries and shardings may be not an integer which will make the total max entries incorrect.
 	// So we let users decide the exact max entries in each parts of shardings.
 	cache = cachego.NewCache(cachego.WithShardings(2), cachego.WithLFU(10))
+
+	// Frequencies in lfu cache never decay, so old hot entries may stay in cache forever.
+	// Try WithTinyLFU if you want a better hit rate in skewed workloads.
+	// It uses a small window lru for new entries and admits them to main lru by their estimated frequencies.
+	// More details see https://arxiv.org/abs/1512.00727.
+	cache = cachego.NewCache(cachego.WithTinyLFU(10))
 }
@@ -68,4 +68,14 @@ func main() {
 	// However, the result divided by max entries and shardings may be not an integer which will make the total max entries incorrect.
 	// So we let users decide the exact max entries in each parts of shardings.
 	cache = cachego.NewCache(cachego.WithShardings(2), cachego.WithLRU(10))
+
+	// If your workloads switch between recency-heavy and frequency-heavy phases, try WithARC.
+	// It adapts itself between lru and lfu automatically.
+	// More details see https://en.wikipedia.org/wiki/Adaptive_replacement_cache.
+	cache = cachego.NewCache(cachego.WithARC(10))
+
+	// Getting entries in lru cache needs a write lock to move them, so all readers are serialized.
+	// Try WithSIEVE or WithS3FIFO if you have lots of reads, which only mark entries when getting them.
+	cache = cachego.NewCache(cachego.WithSIEVE(10))
+	cache = cachego.NewCache(cachego.WithS3FIFO(10))
 }
@@ -0,0 +1,44 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Batch operations group keys by shardings, so each sharding only locks once.
+	cache := cachego.NewCache(cachego.WithShardings(64))
+
+	cache.SetMulti(map[string]interface{}{"key1": 1, "key2": 2}, time.Minute)
+
+	values, missedKeys := cache.GetMulti([]string{"key1", "key2", "key3"})
+	fmt.Println(values, missedKeys) // map[key1:1 key2:2] [key3]
+
+	// LoadMulti loads all missed keys with one call, so you can fetch them from database with one query.
+	// Keys not returned by load won't be set to cache.
+	values, err := cache.LoadMulti([]string{"key1", "key3", "key4"}, time.Minute, func(missedKeys []string) (map[string]interface{}, error) {
+		fmt.Println(missedKeys) // [key3 key4]
+		return map[string]interface{}{"key3": 3}, nil
+	})
+
+	fmt.Println(values, err) // map[key1:1 key3:3] <nil>
+
+	removedValues := cache.RemoveMulti([]string{"key1", "key2", "key3"})
+	fmt.Println(removedValues) // map[key1:1 key2:2 key3:3]
+}
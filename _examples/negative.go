@@ -0,0 +1,47 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+var errUserNotFound = errors.New("user not found")
+
+func main() {
+	// Use WithNegativeTTL to cache load errors for a while, so missing keys won't hit your database every time.
+	// The second argument decides which errors should be cached, and all errors will be cached if it's nil.
+	cache, reporter := cachego.NewCacheWithReport(cachego.WithNegativeTTL(time.Second, func(err error) bool {
+		return errors.Is(err, errUserNotFound)
+	}))
+
+	for i := 0; i < 3; i++ {
+		_, err := cache.Load("user:404", time.Minute, func() (value interface{}, err error) {
+			fmt.Println("loading user:404 from database")
+			return nil, errUserNotFound
+		})
+
+		fmt.Println(errors.Is(err, errUserNotFound)) // true
+	}
+
+	// Negative hits are counted separately from hits.
+	fmt.Println(reporter.CountLoad())        // 1
+	fmt.Println(reporter.CountNegativeHit()) // 2
+	fmt.Println(reporter.CountHit())         // 0
+}
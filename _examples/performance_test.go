@@ -27,6 +27,9 @@ const (
 	benchTTL        = time.Minute
 	benchMaxKeys    = 10000
 	benchMaxEntries = 100000
+
+	benchHitRateMaxKeys    = 100000
+	benchHitRateMaxEntries = 1000
 )
 
 type benchKeys []string
@@ -85,6 +88,34 @@ func benchmarkCacheSet(b *testing.B, set func(key string, value string)) {
 	})
 }
 
+// benchmarkCacheHitRate gets keys in zipf distribution and sets missed keys to cache.
+// It reports the hit rate of cache so different cache types can be compared.
+func benchmarkCacheHitRate(b *testing.B, cache cachego.Cache) {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	zipf := rand.NewZipf(random, 1.01, 1, benchHitRateMaxKeys-1)
+
+	keys := make([]string, 0, b.N)
+	for i := 0; i < b.N; i++ {
+		keys = append(keys, strconv.FormatUint(zipf.Uint64(), 10))
+	}
+
+	hits := 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for _, key := range keys {
+		if _, ok := cache.Get(key); ok {
+			hits++
+			continue
+		}
+
+		cache.Set(key, key, benchTTL)
+	}
+
+	b.ReportMetric(float64(hits)/float64(b.N), "hit-rate")
+}
+
 // go test -v -bench=^BenchmarkCachegoGet$ -benchtime=1s ./_examples/performance_test.go
 func BenchmarkCachegoGet(b *testing.B) {
 	cache := cachego.NewCache()
@@ -130,6 +161,66 @@ func BenchmarkCachegoGetLFU(b *testing.B) {
 	benchmarkCacheGet(b, set, get)
 }
 
+// go test -v -bench=^BenchmarkCachegoGetTinyLFU$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoGetTinyLFU(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithTinyLFU(benchMaxEntries))
+
+	set := func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	}
+
+	get := func(key string) {
+		cache.Get(key)
+	}
+
+	benchmarkCacheGet(b, set, get)
+}
+
+// go test -v -bench=^BenchmarkCachegoGetARC$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoGetARC(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithARC(benchMaxEntries))
+
+	set := func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	}
+
+	get := func(key string) {
+		cache.Get(key)
+	}
+
+	benchmarkCacheGet(b, set, get)
+}
+
+// go test -v -bench=^BenchmarkCachegoGetSIEVE$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoGetSIEVE(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithSIEVE(benchMaxEntries))
+
+	set := func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	}
+
+	get := func(key string) {
+		cache.Get(key)
+	}
+
+	benchmarkCacheGet(b, set, get)
+}
+
+// go test -v -bench=^BenchmarkCachegoGetS3FIFO$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoGetS3FIFO(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithS3FIFO(benchMaxEntries))
+
+	set := func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	}
+
+	get := func(key string) {
+		cache.Get(key)
+	}
+
+	benchmarkCacheGet(b, set, get)
+}
+
 // go test -v -bench=^BenchmarkCachegoGetSharding$ -benchtime=1s ./_examples/performance_test.go
 func BenchmarkCachegoGetSharding(b *testing.B) {
 	cache := cachego.NewCache(cachego.WithShardings(16))
@@ -172,6 +263,42 @@ func BenchmarkCachegoSetLFU(b *testing.B) {
 	})
 }
 
+// go test -v -bench=^BenchmarkCachegoSetTinyLFU$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoSetTinyLFU(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithTinyLFU(benchMaxEntries))
+
+	benchmarkCacheSet(b, func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	})
+}
+
+// go test -v -bench=^BenchmarkCachegoSetARC$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoSetARC(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithARC(benchMaxEntries))
+
+	benchmarkCacheSet(b, func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	})
+}
+
+// go test -v -bench=^BenchmarkCachegoSetSIEVE$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoSetSIEVE(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithSIEVE(benchMaxEntries))
+
+	benchmarkCacheSet(b, func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	})
+}
+
+// go test -v -bench=^BenchmarkCachegoSetS3FIFO$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoSetS3FIFO(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithS3FIFO(benchMaxEntries))
+
+	benchmarkCacheSet(b, func(key string, value string) {
+		cache.Set(key, value, benchTTL)
+	})
+}
+
 // go test -v -bench=^BenchmarkCachegoSetSharding$ -benchtime=1s ./_examples/performance_test.go
 func BenchmarkCachegoSetSharding(b *testing.B) {
 	cache := cachego.NewCache(cachego.WithShardings(16))
@@ -181,6 +308,24 @@ func BenchmarkCachegoSetSharding(b *testing.B) {
 	})
 }
 
+// go test -v -bench=^BenchmarkCachegoHitRateLRU$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoHitRateLRU(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithLRU(benchHitRateMaxEntries))
+	benchmarkCacheHitRate(b, cache)
+}
+
+// go test -v -bench=^BenchmarkCachegoHitRateSIEVE$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoHitRateSIEVE(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithSIEVE(benchHitRateMaxEntries))
+	benchmarkCacheHitRate(b, cache)
+}
+
+// go test -v -bench=^BenchmarkCachegoHitRateS3FIFO$ -benchtime=1s ./_examples/performance_test.go
+func BenchmarkCachegoHitRateS3FIFO(b *testing.B) {
+	cache := cachego.NewCache(cachego.WithS3FIFO(benchHitRateMaxEntries))
+	benchmarkCacheHitRate(b, cache)
+}
+
 //// go test -v -bench=^BenchmarkGcacheGet$ -benchtime=1s ./_examples/performance_test.go
 //func BenchmarkGcacheGet(b *testing.B) {
 //	cache := gcache.New(benchMaxEntries).Expiration(benchTTL).Build()
@@ -0,0 +1,51 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	cache := cachego.NewCache(cachego.WithLRU(10), cachego.WithRangeInEvictionOrder())
+	cache.Set("user:1", 1, time.Minute)
+	cache.Set("user:2", 2, cachego.NoTTL)
+	cache.Set("order:1", 1, time.Minute)
+
+	// Use Range to iterate all unexpired entries with their remaining ttl.
+	// Return false if you want to stop iterating.
+	// With WithRangeInEvictionOrder, lru and lfu caches will iterate entries in eviction order.
+	cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		fmt.Println(key, value, ttl)
+		return true
+	})
+
+	// Entries are copied before iterating, so you can use cache in Range, like bulk invalidations.
+	cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		if strings.HasPrefix(key, "user:") {
+			cache.Remove(key)
+		}
+
+		return true
+	})
+
+	// Use Keys to get all unexpired keys.
+	keys := cache.Keys()
+	fmt.Println(keys) // [order:1]
+}
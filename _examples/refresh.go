@@ -0,0 +1,60 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	// Use WithRefreshAhead to reload keys in background before they are expired.
+	// Use WithMaxStale to serve expired values for a while, and they will be reloaded in background, too.
+	// Only keys loaded by Load will be refreshed, because cache needs to know their load functions.
+	cache := cachego.NewCache(cachego.WithRefreshAhead(time.Second), cachego.WithMaxStale(time.Minute))
+
+	version := 0
+	load := func() (value interface{}, err error) {
+		version++
+		return version, nil
+	}
+
+	value, _ := cache.Load("key", 2*time.Second, load)
+	fmt.Println(value) // 1
+
+	time.Sleep(1500 * time.Millisecond)
+
+	// The key is in refresh window, so the old value is returned and a reload starts in background.
+	value, _ = cache.Get("key")
+	fmt.Println(value) // 1
+
+	time.Sleep(100 * time.Millisecond)
+
+	value, _ = cache.Get("key")
+	fmt.Println(value) // 2
+
+	// Failed reloads keep the old values in cache, and you can check them in reporter by WithReportLoad.
+	_, reporter := cachego.NewCacheWithReport(cachego.WithRefreshAhead(time.Second),
+		cachego.WithReportLoad(func(reporter *cachego.Reporter, key string, value interface{}, ttl time.Duration, err error) {
+			if err != nil {
+				fmt.Println("reload", key, "failed:", err)
+			}
+		}),
+	)
+
+	fmt.Println(reporter.CountLoad()) // 0
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+// mapStore is a store in memory, and you can use a database in practice.
+type mapStore struct {
+	data map[string]interface{}
+	lock sync.Mutex
+}
+
+func (ms *mapStore) Load(ctx context.Context, key string) (value interface{}, err error) {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	value, ok := ms.data[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+
+	return value, nil
+}
+
+func (ms *mapStore) Store(ctx context.Context, key string, value interface{}) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	ms.data[key] = value
+	return nil
+}
+
+func (ms *mapStore) Delete(ctx context.Context, key string) error {
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+
+	delete(ms.data, key)
+	return nil
+}
+
+func main() {
+	store := &mapStore{data: map[string]interface{}{"key": "value"}}
+
+	// Use WithStore to load missed keys from store in Get and write keys to store in Set.
+	cache := cachego.NewCache(
+		cachego.WithStore(store, cachego.ReadThrough|cachego.WriteThrough),
+		cachego.WithStoreTTL(time.Minute),
+	)
+
+	value, ok := cache.Get("key")
+	fmt.Println(value, ok) // value true
+
+	cache.Set("new", "value", time.Minute)
+	fmt.Println(store.Load(context.Background(), "new")) // value <nil>
+
+	// Use WriteBehind to write keys to store in background.
+	// Writes of the same key will be coalesced, and failed writes will be retried with backoff.
+	cache = cachego.NewCache(
+		cachego.WithStore(store, cachego.ReadThrough|cachego.WriteBehind),
+		cachego.WithWriteBehind(time.Second, 100),
+		cachego.WithWriteBehindRetry(3, 100*time.Millisecond),
+		cachego.WithOnStoreError(func(key string, err error) {
+			fmt.Println("write", key, "failed:", err)
+		}),
+	)
+
+	for i := 0; i < 10; i++ {
+		cache.Set("counter", i, cachego.NoTTL)
+	}
+
+	// Remember to close cache, so all pending writes will be flushed to store.
+	if err := cache.Close(); err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(store.Load(context.Background(), "counter")) // 9 <nil>
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Implement RemoteTier with redis or memcached in practice, and we use a memory tier here.
+	remote := cachego.NewMemoryTier()
+
+	// Use a tiered cache to compose a local cache and a remote tier shared by services.
+	// Keys in local cache will live no longer than local ttl, so they won't be too stale after changing in remote tier.
+	cache := cachego.NewTieredCache(cachego.NewCache(), remote, cachego.WithLocalTTL(10*time.Second))
+
+	if err := cache.Set(ctx, "key", "value", time.Minute); err != nil {
+		panic(err)
+	}
+
+	// Another service gets key from remote tier and backfills it to its local cache.
+	other := cachego.NewTieredCache(cachego.NewCache(), remote, cachego.WithLocalTTL(10*time.Second))
+
+	value, found, err := other.Get(ctx, "key")
+	fmt.Println(value, found, err) // value true <nil>
+
+	// Use Load to load a missed key to both tiers, and load will be called once at a time with singleflight.
+	value, err = other.Load(ctx, "user", time.Minute, func(ctx context.Context) (interface{}, error) {
+		return "from db", nil
+	})
+
+	fmt.Println(value, err) // from db <nil>
+
+	// Values are encoded by gob by default, and use WithRemoteCodec to change it.
+	_ = cachego.NewTieredCache(cachego.NewCache(), remote, cachego.WithRemoteCodec(cachego.JSONCodec{}))
+}
@@ -54,4 +54,25 @@ func main() {
 	// In fact, the entry won't expire as long as its ttl is <= 0.
 	// So you may have known NoTTL is a "readable" value of "<= 0".
 	cache.Set("key", 666, cachego.NoTTL)
+
+	// Use TTL to check how long a key has left, and NoTTL means it's never expired.
+	ttl, ok := cache.TTL("key")
+	fmt.Println(ttl, ok) // 0s true
+
+	// Use Expire to reset the ttl of a key without rewriting its value.
+	cache.Expire("key", time.Minute)
+
+	// Use Touch to reset the ttl of a key and access it like Get, which is useful for extending sessions.
+	cache.Touch("key", time.Hour)
+
+	// Use Persist to remove the ttl of a key.
+	cache.Persist("key")
+
+	// Use WithSlidingExpiration to extend the ttl of a key after each get, which is useful for session caches.
+	// The key will be expired after 24 hours at most even if it's got all the time.
+	cache = cachego.NewCache(cachego.WithSlidingExpiration(24 * time.Hour))
+	cache.Set("session", "token", 30*time.Minute)
+
+	// The session will be expired after 30 minutes since now.
+	cache.Get("session")
 }
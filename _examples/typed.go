@@ -0,0 +1,65 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+type user struct {
+	id   int64
+	name string
+}
+
+func main() {
+	// Use NewTypedCache function to create a typed cache.
+	// Keys and values have their own types, so you don't need to assert the type of values any more.
+	// All options of NewCache can be used here by WithOptions, such as WithLRU and WithShardings.
+	cache := cachego.NewTypedCache[int64, *user](cachego.WithOptions[int64](cachego.WithLRU(100), cachego.WithShardings(4)))
+	defer cache.Close()
+
+	// Set a user to cache with ttl.
+	cache.Set(1, &user{id: 1, name: "fishgoddess"}, time.Minute)
+
+	// Get a user from cache.
+	u, ok := cache.Get(1)
+	fmt.Println(u.name, ok) // fishgoddess true
+
+	// Load function returns a value in type V, too.
+	u, err := cache.Load(2, time.Minute, func() (*user, error) {
+		return &user{id: 2, name: "cachego"}, nil
+	})
+
+	fmt.Println(u.name, err) // cachego <nil>
+
+	// Integer keys are hashed without converting to strings.
+	// Use WithHasher if you want to customize the hash function of keys.
+	// The key type of hasher is checked by compiler, so a hasher of other key types can't be used.
+	hasher := func(key int64) int {
+		return int(key)
+	}
+
+	cache = cachego.NewTypedCache[int64, *user](cachego.WithOptions[int64](cachego.WithShardings(4)), cachego.WithHasher(hasher))
+	defer cache.Close()
+
+	cache.Set(3, &user{id: 3, name: "hasher"}, cachego.NoTTL)
+
+	// Use NewTypedCacheWithReport to create a typed cache with report.
+	_, reporter := cachego.NewTypedCacheWithReport[string, int](cachego.WithOptions[string](cachego.WithCacheName("typed")))
+	fmt.Println(reporter.CacheName())
+}
@@ -0,0 +1,619 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type arcSegment int
+
+const (
+	arcT1 arcSegment = iota
+	arcT2
+	arcB1
+	arcB2
+)
+
+type arcItem struct {
+	entry   *entry
+	segment arcSegment
+}
+
+type arcCache struct {
+	*config
+
+	// elementMap stores elements of resident lists t1 and t2.
+	elementMap map[string]*list.Element
+
+	// ghostMap stores elements of ghost lists b1 and b2 which only keep keys.
+	ghostMap map[string]*list.Element
+
+	t1   *list.List
+	t2   *list.List
+	b1   *list.List
+	b2   *list.List
+	cost int64
+	lock sync.RWMutex
+
+	// p is the target size of t1 which adapts automatically.
+	p int
+
+	loader *loader
+}
+
+func newARCCache(conf *config) Cache {
+	conf.checkEntriesBounded(arc)
+
+	cache := &arcCache{
+		config:     conf,
+		elementMap: make(map[string]*list.Element, mapInitialCap),
+		ghostMap:   make(map[string]*list.Element, mapInitialCap),
+		t1:         list.New(),
+		t2:         list.New(),
+		b1:         list.New(),
+		b2:         list.New(),
+		p:          0,
+		loader:     newLoader(conf),
+	}
+
+	return cache
+}
+
+func (ac *arcCache) unwrap(element *list.Element) *arcItem {
+	item, ok := element.Value.(*arcItem)
+	if !ok {
+		panic("cachego: failed to unwrap arc element's value to item")
+	}
+
+	return item
+}
+
+func (ac *arcCache) listOf(segment arcSegment) *list.List {
+	switch segment {
+	case arcT2:
+		return ac.t2
+	case arcB1:
+		return ac.b1
+	case arcB2:
+		return ac.b2
+	default:
+		return ac.t1
+	}
+}
+
+// moveTo moves element to the front of segment.
+func (ac *arcCache) moveTo(element *list.Element, segment arcSegment) {
+	item := ac.unwrap(element)
+	ac.listOf(item.segment).Remove(element)
+
+	if item.segment <= arcT2 {
+		delete(ac.elementMap, item.entry.key)
+	} else {
+		delete(ac.ghostMap, item.entry.key)
+	}
+
+	item.segment = segment
+	element = ac.listOf(segment).PushFront(item)
+
+	if segment <= arcT2 {
+		ac.elementMap[item.entry.key] = element
+	} else {
+		ac.ghostMap[item.entry.key] = element
+	}
+}
+
+// ghost moves a resident element to a ghost list and returns its value.
+func (ac *arcCache) ghost(element *list.Element, segment arcSegment) (evictedValue interface{}) {
+	item := ac.unwrap(element)
+	evictedValue = item.entry.value
+	ac.notifyEvicted(item.entry.key, evictedValue, RemovalCapacity)
+
+	// Ghosts only keep keys, so release their values.
+	item.entry.value = nil
+	ac.cost -= item.entry.cost
+	item.entry.cost = 0
+
+	ac.moveTo(element, segment)
+	return evictedValue
+}
+
+func (ac *arcCache) removeGhost(element *list.Element) {
+	item := ac.unwrap(element)
+
+	delete(ac.ghostMap, item.entry.key)
+	ac.listOf(item.segment).Remove(element)
+}
+
+// replace evicts an entry from t1 or t2 to its ghost list and returns the evicted value.
+func (ac *arcCache) replace(inB2 bool) (evictedValue interface{}) {
+	t1Len := ac.t1.Len()
+
+	if t1Len > 0 && (t1Len > ac.p || (inB2 && t1Len == ac.p)) {
+		return ac.ghost(ac.t1.Back(), arcB1)
+	}
+
+	if element := ac.t2.Back(); element != nil {
+		return ac.ghost(element, arcB2)
+	}
+
+	if element := ac.t1.Back(); element != nil {
+		return ac.ghost(element, arcB1)
+	}
+
+	return nil
+}
+
+func (ac *arcCache) get(key string) (value interface{}, found bool) {
+	element, ok := ac.elementMap[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := ac.unwrap(element)
+	if item.entry.expired(0) {
+		return nil, false
+	}
+
+	ac.moveTo(element, arcT2)
+	return item.entry.value, true
+}
+
+func (ac *arcCache) setGhost(element *list.Element, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	item := ac.unwrap(element)
+	b1Len, b2Len := ac.b1.Len(), ac.b2.Len()
+
+	if item.segment == arcB1 {
+		delta := 1
+		if b2Len > b1Len {
+			delta = b2Len / b1Len
+		}
+
+		ac.p = min(ac.maxEntries, ac.p+delta)
+	} else {
+		delta := 1
+		if b1Len > b2Len {
+			delta = b1Len / b2Len
+		}
+
+		ac.p = max(0, ac.p-delta)
+	}
+
+	if ac.t1.Len()+ac.t2.Len() >= ac.maxEntries {
+		evictedValue = ac.replace(item.segment == arcB2)
+	}
+
+	item.entry.setup(item.entry.key, value, ttl)
+	item.entry.cost = ac.weigh(item.entry.key, value)
+	ac.cost += item.entry.cost
+	ac.moveTo(element, arcT2)
+
+	return evictedValue
+}
+
+func (ac *arcCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	if element, ok := ac.elementMap[key]; ok {
+		item := ac.unwrap(element)
+		ac.notifyEvicted(key, item.entry.value, replacedCause(item.entry))
+		item.entry.setup(key, value, ttl)
+
+		cost := ac.weigh(key, value)
+		ac.cost += cost - item.entry.cost
+		item.entry.cost = cost
+
+		ac.moveTo(element, arcT2)
+		return nil
+	}
+
+	if element, ok := ac.ghostMap[key]; ok {
+		return ac.setGhost(element, value, ttl)
+	}
+
+	t1Len, b1Len := ac.t1.Len(), ac.b1.Len()
+	residents := t1Len + ac.t2.Len()
+	total := residents + b1Len + ac.b2.Len()
+
+	if t1Len+b1Len >= ac.maxEntries {
+		if t1Len < ac.maxEntries {
+			ac.removeGhost(ac.b1.Back())
+
+			if residents >= ac.maxEntries {
+				evictedValue = ac.replace(false)
+			}
+		} else {
+			evictedValue = ac.removeElement(ac.t1.Back(), RemovalCapacity)
+		}
+	} else if total >= ac.maxEntries {
+		if total >= 2*ac.maxEntries {
+			ac.removeGhost(ac.b2.Back())
+		}
+
+		if residents >= ac.maxEntries {
+			evictedValue = ac.replace(false)
+		}
+	}
+
+	item := &arcItem{
+		entry:   newEntry(key, value, ttl, ac.now),
+		segment: arcT1,
+	}
+
+	item.entry.cost = ac.weigh(key, value)
+	ac.cost += item.entry.cost
+	ac.elementMap[key] = ac.t1.PushFront(item)
+	return evictedValue
+}
+
+func (ac *arcCache) removeElement(element *list.Element, cause RemovalCause) (removedValue interface{}) {
+	item := ac.unwrap(element)
+
+	delete(ac.elementMap, item.entry.key)
+	ac.listOf(item.segment).Remove(element)
+	ac.cost -= item.entry.cost
+
+	ac.notifyEvicted(item.entry.key, item.entry.value, cause)
+	return item.entry.value
+}
+
+func (ac *arcCache) remove(key string) (removedValue interface{}) {
+	if element, ok := ac.ghostMap[key]; ok {
+		ac.removeGhost(element)
+	}
+
+	if element, ok := ac.elementMap[key]; ok {
+		return ac.removeElement(element, RemovalExplicit)
+	}
+
+	return nil
+}
+
+func (ac *arcCache) size() (size int) {
+	return len(ac.elementMap)
+}
+
+func (ac *arcCache) gc() (cleans int) {
+	now := ac.now()
+	scans := 0
+
+	for _, element := range ac.elementMap {
+		scans++
+
+		if item := ac.unwrap(element); item.entry.expired(now) {
+			ac.removeElement(element, RemovalExpired)
+			cleans++
+		}
+
+		if ac.maxScans > 0 && scans >= ac.maxScans {
+			break
+		}
+	}
+
+	return cleans
+}
+
+func (ac *arcCache) reset() {
+	if ac.onEvicted != nil {
+		for _, element := range ac.elementMap {
+			entry := ac.unwrap(element).entry
+			ac.notifyEvicted(entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	ac.elementMap = make(map[string]*list.Element, mapInitialCap)
+	ac.ghostMap = make(map[string]*list.Element, mapInitialCap)
+	ac.t1 = list.New()
+	ac.t2 = list.New()
+	ac.b1 = list.New()
+	ac.b2 = list.New()
+	ac.cost = 0
+	ac.p = 0
+
+	ac.loader.Reset()
+}
+
+func (ac *arcCache) dump() []DumpEntry {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+
+	now := ac.now()
+	entries := make([]DumpEntry, 0, ac.size())
+
+	// Entries in t1 are less valuable than entries in t2.
+	for _, segment := range []*list.List{ac.t1, ac.t2} {
+		for element := segment.Back(); element != nil; element = element.Prev() {
+			if dumpEntry, ok := newDumpEntry(ac.unwrap(element).entry, now); ok {
+				entries = append(entries, dumpEntry)
+			}
+		}
+	}
+
+	return entries
+}
+
+func (ac *arcCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+
+	if entry := ac.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(ac.now()), true
+	}
+
+	return nil, 0, false
+}
+
+func (ac *arcCache) loaderOf() *loader {
+	return ac.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (ac *arcCache) entryOf(key string) *entry {
+	element, ok := ac.elementMap[key]
+	if !ok {
+		return nil
+	}
+
+	if item := ac.unwrap(element); !item.entry.expired(0) {
+		return item.entry
+	}
+
+	return nil
+}
+
+func (ac *arcCache) expire(key string, ttl time.Duration) (found bool) {
+	entry := ac.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	return true
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (ac *arcCache) Get(key string) (value interface{}, found bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return ac.get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See Cache interface.
+func (ac *arcCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return ac.set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (ac *arcCache) Remove(key string) (removedValue interface{}) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return ac.remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (ac *arcCache) TTL(key string) (ttl time.Duration, found bool) {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+
+	if entry := ac.entryOf(key); entry != nil {
+		return entry.ttl(ac.now()), true
+	}
+
+	return 0, false
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (ac *arcCache) Touch(key string, ttl time.Duration) (found bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	if _, found = ac.get(key); !found {
+		return false
+	}
+
+	return ac.expire(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (ac *arcCache) Expire(key string, ttl time.Duration) (found bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return ac.expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (ac *arcCache) Persist(key string) (found bool) {
+	return ac.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (ac *arcCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return getOrSet(ac, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (ac *arcCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return setIfAbsent(ac, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (ac *arcCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return compareAndSwap(ac, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (ac *arcCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return update(ac, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (ac *arcCache) GetAndRemove(key string) (value interface{}, found bool) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return getAndRemove(ac, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (ac *arcCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return incr(ac, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (ac *arcCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return ac.Incr(key, -delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (ac *arcCache) Size() (size int) {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+
+	return ac.size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (ac *arcCache) Cost() (cost int64) {
+	ac.lock.RLock()
+	defer ac.lock.RUnlock()
+
+	return ac.cost
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (ac *arcCache) GC() (cleans int) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return ac.gc()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (ac *arcCache) Reset() {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	ac.reset()
+}
+
+// Close resets cache to release its entries.
+// See Cache interface.
+func (ac *arcCache) Close() error {
+	ac.Reset()
+	return nil
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (ac *arcCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(ac.dump(), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (ac *arcCache) Keys() (keys []string) {
+	return keysOf(ac.dump())
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (ac *arcCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	value, err = ac.loader.Load(key, ttl, load)
+	if err != nil {
+		return value, err
+	}
+
+	ac.Set(key, value, ttl)
+	return value, nil
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (ac *arcCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, ac, ac.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (ac *arcCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return getMulti(keys, ac.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (ac *arcCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	setMulti(entries, ttl, ac.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (ac *arcCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	return removeMulti(keys, ac.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (ac *arcCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(ac, ac.loader, keys, ttl, load)
+}
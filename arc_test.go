@@ -0,0 +1,145 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestARCCache() *arcCache {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newARCCache(conf).(*arcCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestARCCache$
+func TestARCCache(t *testing.T) {
+	cache := newTestARCCache()
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestARCCacheEvict$
+func TestARCCacheEvict(t *testing.T) {
+	cache := newTestARCCache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue := cache.Set(data, data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && evictedValue == nil {
+			t.Fatalf("i %d >= cache.maxEntries %d && evictedValue == nil", i, cache.maxEntries)
+		}
+
+		if cache.t1.Len()+cache.b1.Len() > cache.maxEntries {
+			t.Fatalf("cache.t1.Len() %d + cache.b1.Len() %d > cache.maxEntries %d", cache.t1.Len(), cache.b1.Len(), cache.maxEntries)
+		}
+
+		ghosts := cache.b1.Len() + cache.b2.Len()
+		if cache.Size()+ghosts > 2*cache.maxEntries {
+			t.Fatalf("cache.Size() %d + ghosts %d > 2 * cache.maxEntries %d", cache.Size(), ghosts, cache.maxEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+
+	for i := cache.maxEntries*10 - cache.maxEntries; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+
+		value, ok := cache.Get(data)
+		if !ok || value.(string) != data {
+			t.Fatalf("!ok %+v || value %+v != data %s", !ok, value, data)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestARCCacheAdapt$
+func TestARCCacheAdapt(t *testing.T) {
+	cache := newTestARCCache()
+
+	for i := 0; i < cache.maxEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	// Frequently used entries are moved to t2.
+	for i := 0; i < cache.maxEntries/2; i++ {
+		cache.Get(strconv.Itoa(i))
+	}
+
+	if cache.t2.Len() != cache.maxEntries/2 {
+		t.Fatalf("cache.t2.Len() %d != %d", cache.t2.Len(), cache.maxEntries/2)
+	}
+
+	// New entries evict recently used entries in t1 to b1.
+	for i := cache.maxEntries; i < cache.maxEntries*2; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	if cache.b1.Len() == 0 {
+		t.Fatal("cache.b1.Len() == 0")
+	}
+
+	if cache.p != 0 {
+		t.Fatalf("cache.p %d != 0", cache.p)
+	}
+
+	// Setting a key in b1 means t1 is too small, so p should grow.
+	var ghostKey string
+	for key, element := range cache.ghostMap {
+		if cache.unwrap(element).segment == arcB1 {
+			ghostKey = key
+			break
+		}
+	}
+
+	if _, ok := cache.Get(ghostKey); ok {
+		t.Fatalf("ghost key %s should be not found", ghostKey)
+	}
+
+	cache.Set(ghostKey, ghostKey, NoTTL)
+
+	if cache.p <= 0 {
+		t.Fatalf("cache.p %d <= 0", cache.p)
+	}
+
+	element, ok := cache.elementMap[ghostKey]
+	if !ok {
+		t.Fatalf("ghost key %s should be resident", ghostKey)
+	}
+
+	if segment := cache.unwrap(element).segment; segment != arcT2 {
+		t.Fatalf("segment %d != arcT2", segment)
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestARCCacheShardingReport$
+func TestARCCacheShardingReport(t *testing.T) {
+	cache, reporter := NewCacheWithReport(WithARC(maxTestEntries), WithShardings(testShardings), WithGC(0))
+	testCacheImplement(t, cache)
+
+	if !reporter.CacheType().IsARC() {
+		t.Fatalf("reporter.CacheType() %s is wrong", reporter.CacheType())
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import "time"
+
+// lockedCache is a cache whose unexported methods are called in its lock.
+// Atomic operations are composed of these methods, so they are linearizable if they are called in the same lock.
+type lockedCache interface {
+	get(key string) (value interface{}, found bool)
+	set(key string, value interface{}, ttl time.Duration) (evictedValue interface{})
+	remove(key string) (removedValue interface{})
+	entryOf(key string) *entry
+}
+
+// equals reports whether old and current are equal with ==, and it panics if they are not comparable.
+func equals(old interface{}, current interface{}) bool {
+	return old == current
+}
+
+// getOrSet gets the value of key like get, or sets value with ttl if key is absent.
+func getOrSet(cache lockedCache, key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	if actual, found = cache.get(key); found {
+		return actual, true
+	}
+
+	cache.set(key, value, ttl)
+	return value, false
+}
+
+// setIfAbsent sets value with ttl if key is absent, and the existing value won't be accessed.
+func setIfAbsent(cache lockedCache, key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	if entry := cache.entryOf(key); entry != nil {
+		return entry.value, false
+	}
+
+	cache.set(key, value, ttl)
+	return value, true
+}
+
+// compareAndSwap sets new with ttl if the value of key equals old.
+func compareAndSwap(cache lockedCache, key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	entry := cache.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	if equal == nil {
+		equal = equals
+	}
+
+	if !equal(old, entry.value) {
+		return false
+	}
+
+	cache.set(key, new, ttl)
+	return true
+}
+
+// update sets the new value returned by fn if fn keeps it, or removes key.
+func update(cache lockedCache, key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	var old interface{}
+
+	entry := cache.entryOf(key)
+	if entry != nil {
+		old = entry.value
+	}
+
+	value, ttl, keep := fn(old, entry != nil)
+	if keep {
+		cache.set(key, value, ttl)
+		return value, true
+	}
+
+	if entry != nil {
+		cache.remove(key)
+	}
+
+	return nil, false
+}
+
+// getAndRemove removes key and returns its value if it's unexpired.
+func getAndRemove(cache lockedCache, key string) (value interface{}, found bool) {
+	entry := cache.entryOf(key)
+	if entry == nil {
+		return nil, false
+	}
+
+	value = entry.value
+	cache.remove(key)
+
+	return value, true
+}
+
+// incr adds delta to the value of key and returns the new value.
+// Key will be set with delta and ttl if it's absent or its value isn't an int64, or its expiration is kept.
+// The existing key won't be accessed, so its sliding expiration and eviction order are kept.
+func incr(cache lockedCache, key string, delta int64, ttl time.Duration) (value int64) {
+	if entry := cache.entryOf(key); entry != nil {
+		if n, ok := entry.value.(int64); ok {
+			entry.value = n + delta
+			return n + delta
+		}
+	}
+
+	cache.set(key, delta, ttl)
+	return delta
+}
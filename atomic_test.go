@@ -0,0 +1,132 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestAtomicConcurrently$
+func TestAtomicConcurrently(t *testing.T) {
+	caches := map[string]Cache{
+		"standard": NewCache(),
+		"lru":      NewCache(WithLRU(maxTestEntries)),
+		"lfu":      NewCache(WithLFU(maxTestEntries)),
+		"sharding": NewCache(WithShardings(4)),
+	}
+
+	incr := func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		if !found {
+			return 1, NoTTL, true
+		}
+
+		return old.(int) + 1, NoTTL, true
+	}
+
+	for name, cache := range caches {
+		var stores atomic.Int64
+		var wg sync.WaitGroup
+
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					cache.Update("counter", incr)
+
+					if _, stored := cache.SetIfAbsent("key", j, NoTTL); stored {
+						stores.Add(1)
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if value, found := cache.Get("counter"); !found || value != 1600 {
+			t.Fatalf("%s: value %+v, found %+v is wrong", name, value, found)
+		}
+
+		if stores.Load() != 1 {
+			t.Fatalf("%s: stores %d != 1", name, stores.Load())
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestIncrConcurrently$
+func TestIncrConcurrently(t *testing.T) {
+	caches := map[string]Cache{
+		"standard": NewCache(),
+		"lru":      NewCache(WithLRU(maxTestEntries)),
+		"sharding": NewCache(WithShardings(4)),
+	}
+
+	for name, cache := range caches {
+		var wg sync.WaitGroup
+
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					cache.Incr("counter", 2, NoTTL)
+					cache.Decr("counter", 1, NoTTL)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		if value := cache.Incr("counter", 0, NoTTL); value != 1600 {
+			t.Fatalf("%s: value %d != 1600", name, value)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestIncrWithoutAccess$
+func TestIncrWithoutAccess(t *testing.T) {
+	cache := NewCache(WithLRU(maxTestEntries), WithSlidingExpiration(0), WithGC(0))
+
+	for i := 0; i < maxTestEntries; i++ {
+		cache.Set(strconv.Itoa(i), int64(i), time.Minute)
+	}
+
+	// Increasing 0 doesn't make it the most recently used entry.
+	cache.Incr("0", 1, NoTTL)
+
+	evictedValue := cache.Set("new", "new", NoTTL)
+	if evictedValue.(int64) != 1 {
+		t.Fatalf("evictedValue %+v != 1", evictedValue)
+	}
+
+	cache.Set("sliding", int64(0), 100*time.Millisecond)
+	time.Sleep(60 * time.Millisecond)
+
+	// Increasing doesn't slide the expiration.
+	cache.Incr("sliding", 1, NoTTL)
+	time.Sleep(60 * time.Millisecond)
+
+	if value, found := cache.Get("sliding"); found {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+}
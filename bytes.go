@@ -0,0 +1,448 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	// bytesHeaderSize is the size of an entry's header in buffer:
+	// expiration (8 bytes) + hash (8 bytes) + key length (2 bytes) + value length (4 bytes).
+	bytesHeaderSize = 22
+
+	maxBytesKeyLen = math.MaxUint16
+)
+
+// ErrEntryTooLarge is returned when an entry can't fit in the buffer of bytes cache.
+var ErrEntryTooLarge = errors.New("cachego: entry is too large")
+
+// bytesShard stores entries in a ring buffer in FIFO order.
+// Entries are written from tail and evicted from head, and the index only stores their offsets without pointers.
+// When an entry doesn't fit in the rest of buffer, the data ends at end and the tail wraps to the start of buffer.
+type bytesShard struct {
+	*config
+
+	buf     []byte
+	index   map[uint64]uint32
+	head    int
+	tail    int
+	end     int
+	wrapped bool
+	lock    sync.RWMutex
+}
+
+func newBytesShard(conf *config, capacity int) *bytesShard {
+	return &bytesShard{
+		config: conf,
+		buf:    make([]byte, capacity),
+		index:  make(map[uint64]uint32, mapInitialCap),
+	}
+}
+
+func (bs *bytesShard) header(offset int) (expiration int64, hash uint64, keyLen int, valueLen int) {
+	header := bs.buf[offset : offset+bytesHeaderSize]
+	expiration = int64(binary.LittleEndian.Uint64(header[0:8]))
+	hash = binary.LittleEndian.Uint64(header[8:16])
+	keyLen = int(binary.LittleEndian.Uint16(header[16:18]))
+	valueLen = int(binary.LittleEndian.Uint32(header[18:22]))
+
+	return expiration, hash, keyLen, valueLen
+}
+
+// lookup returns the value of key in buffer and its offset, and the value is borrowed from buffer.
+func (bs *bytesShard) lookup(key string, hash uint64) (value []byte, offset int, found bool) {
+	index, ok := bs.index[hash]
+	if !ok {
+		return nil, 0, false
+	}
+
+	offset = int(index)
+	_, _, keyLen, valueLen := bs.header(offset)
+
+	keyStart := offset + bytesHeaderSize
+	valueStart := keyStart + keyLen
+
+	// Different keys may have the same hash, so we need to check the key stored in buffer.
+	if string(bs.buf[keyStart:valueStart]) != key {
+		return nil, 0, false
+	}
+
+	value = bs.buf[valueStart : valueStart+valueLen]
+	return value, offset, true
+}
+
+func (bs *bytesShard) expired(offset int, now int64) bool {
+	expiration, _, _, _ := bs.header(offset)
+	return expiration > 0 && expiration < now
+}
+
+func (bs *bytesShard) get(key string, hash uint64) (value []byte, found bool) {
+	value, offset, ok := bs.lookup(key, hash)
+	if !ok || bs.expired(offset, bs.now()) {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// notify calls onEvicted with a copy of the entry at offset.
+func (bs *bytesShard) notify(offset int, cause RemovalCause) {
+	if bs.onEvicted == nil {
+		return
+	}
+
+	_, _, keyLen, valueLen := bs.header(offset)
+	keyStart := offset + bytesHeaderSize
+	valueStart := keyStart + keyLen
+
+	key := string(bs.buf[keyStart:valueStart])
+	value := append([]byte(nil), bs.buf[valueStart:valueStart+valueLen]...)
+	bs.onEvicted(key, value, cause)
+}
+
+// evict evicts the entry at head and removes it from index if it's still indexed.
+// Entries removed or replaced before are only dropped from buffer.
+func (bs *bytesShard) evict() {
+	_, hash, keyLen, valueLen := bs.header(bs.head)
+
+	if index, ok := bs.index[hash]; ok && int(index) == bs.head {
+		cause := RemovalCapacity
+		if bs.expired(bs.head, bs.now()) {
+			cause = RemovalExpired
+		}
+
+		bs.notify(bs.head, cause)
+		delete(bs.index, hash)
+	}
+
+	bs.head += bytesHeaderSize + keyLen + valueLen
+
+	if bs.wrapped && bs.head >= bs.end {
+		bs.head = 0
+		bs.wrapped = false
+	}
+
+	if !bs.wrapped && bs.head >= bs.tail {
+		bs.head = 0
+		bs.tail = 0
+	}
+}
+
+// allocate evicts entries from head until size bytes can be written at tail, and returns the offset to write.
+func (bs *bytesShard) allocate(size int) (offset int) {
+	for bs.maxEntries > 0 && len(bs.index) >= bs.maxEntries {
+		bs.evict()
+	}
+
+	for {
+		if !bs.wrapped {
+			if bs.tail+size <= len(bs.buf) {
+				break
+			}
+
+			bs.end = bs.tail
+			bs.tail = 0
+			bs.wrapped = true
+		}
+
+		if bs.tail+size <= bs.head {
+			break
+		}
+
+		bs.evict()
+	}
+
+	offset = bs.tail
+	bs.tail += size
+
+	return offset
+}
+
+func (bs *bytesShard) set(key string, hash uint64, value []byte, ttl time.Duration) {
+	if old, offset, ok := bs.lookup(key, hash); ok {
+		cause := RemovalReplaced
+		if bs.expired(offset, bs.now()) {
+			cause = RemovalExpired
+		}
+
+		if bs.onEvicted != nil {
+			bs.onEvicted(key, append([]byte(nil), old...), cause)
+		}
+
+		delete(bs.index, hash)
+	} else if index, ok := bs.index[hash]; ok {
+		// A different key with the same hash is evicted, because index keeps only one entry of each hash.
+		cause := RemovalCapacity
+		if bs.expired(int(index), bs.now()) {
+			cause = RemovalExpired
+		}
+
+		bs.notify(int(index), cause)
+		delete(bs.index, hash)
+	}
+
+	expiration := int64(0)
+	if ttl > 0 {
+		expiration = bs.now() + ttl.Nanoseconds()
+	}
+
+	size := bytesHeaderSize + len(key) + len(value)
+	offset := bs.allocate(size)
+
+	header := bs.buf[offset : offset+bytesHeaderSize]
+	binary.LittleEndian.PutUint64(header[0:8], uint64(expiration))
+	binary.LittleEndian.PutUint64(header[8:16], hash)
+	binary.LittleEndian.PutUint16(header[16:18], uint16(len(key)))
+	binary.LittleEndian.PutUint32(header[18:22], uint32(len(value)))
+
+	keyStart := offset + bytesHeaderSize
+	copy(bs.buf[keyStart:], key)
+	copy(bs.buf[keyStart+len(key):], value)
+
+	bs.index[hash] = uint32(offset)
+}
+
+func (bs *bytesShard) remove(key string, hash uint64) (removed bool) {
+	if _, offset, ok := bs.lookup(key, hash); ok {
+		bs.notify(offset, RemovalExplicit)
+		delete(bs.index, hash)
+
+		return true
+	}
+
+	return false
+}
+
+func (bs *bytesShard) gc() (cleans int) {
+	now := bs.now()
+	scans := 0
+
+	for hash, index := range bs.index {
+		scans++
+
+		if offset := int(index); bs.expired(offset, now) {
+			bs.notify(offset, RemovalExpired)
+			delete(bs.index, hash)
+			cleans++
+		}
+
+		if bs.maxScans > 0 && scans >= bs.maxScans {
+			break
+		}
+	}
+
+	return cleans
+}
+
+func (bs *bytesShard) reset() {
+	if bs.onEvicted != nil {
+		for _, index := range bs.index {
+			bs.notify(int(index), RemovalReset)
+		}
+	}
+
+	bs.index = make(map[uint64]uint32, mapInitialCap)
+	bs.head = 0
+	bs.tail = 0
+	bs.end = 0
+	bs.wrapped = false
+}
+
+// BytesCache is a cache storing []byte values in pre-allocated ring buffers.
+// Entries are serialized into buffers and indexed by a map without pointers, so it's friendly to gc even if there are millions of entries.
+// Entries are evicted in FIFO order when buffers or max entries are full, and removed or replaced entries won't be reused until they are evicted.
+// Keys are indexed by their hashes, so a key may be evicted by another key having the same hash, and it is notified as RemovalCapacity.
+type BytesCache struct {
+	*config
+
+	shards []*bytesShard
+	cancel func()
+}
+
+func newBytesCache(conf *config) *BytesCache {
+	if conf.maxCost <= 0 {
+		panic("cachego: bytes cache must specify max cost")
+	}
+
+	shardings := conf.shardings
+	if shardings <= 0 {
+		shardings = 1
+	}
+
+	if bits.OnesCount(uint(shardings)) > 1 {
+		panic("cachego: shardings must be the pow of 2 (such as 64).")
+	}
+
+	// Offsets are stored in uint32, so each buffer should be less than 4GB.
+	capacity := conf.maxCost / int64(shardings)
+	if capacity < bytesHeaderSize || capacity > math.MaxUint32 {
+		panic("cachego: max cost of each sharding must be in [22, 4GB)")
+	}
+
+	shards := make([]*bytesShard, 0, shardings)
+	for i := 0; i < shardings; i++ {
+		shards = append(shards, newBytesShard(conf, int(capacity)))
+	}
+
+	cache := &BytesCache{
+		config: conf,
+		shards: shards,
+	}
+
+	return cache
+}
+
+// NewBytesCache creates a bytes cache with options.
+// Max cost is the total bytes of buffers which will be allocated at once, so it must be specified by WithMaxCost.
+// Use WithShardings to split buffers to shardings for concurrency, and each sharding gets a proportional share of max cost.
+// Options like WithMaxEntries, WithMaxScans, WithGC and WithOnEvicted also work in bytes cache.
+func NewBytesCache(opts ...Option) *BytesCache {
+	conf := newDefaultConfig()
+	applyOptions(conf, opts)
+
+	cache := newBytesCache(conf)
+	if conf.gcDuration > 0 {
+		cache.cancel = runGCTask(cache.GC, conf.gcDuration)
+	}
+
+	return cache
+}
+
+func (bc *BytesCache) shardOf(key string) (shard *bytesShard, hash uint64) {
+	hash = uint64(bc.hash(key))
+	mask := uint64(len(bc.shards) - 1)
+
+	return bc.shards[hash&mask], hash
+}
+
+// Get gets the value of key from cache and returns a copy of value if found.
+func (bc *BytesCache) Get(key string) (value []byte, found bool) {
+	shard, hash := bc.shardOf(key)
+
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+
+	value, found = shard.get(key, hash)
+	if !found {
+		return nil, false
+	}
+
+	return append([]byte(nil), value...), true
+}
+
+// View calls fn with the value of key borrowed from buffer and returns false if not found.
+// The value is only valid in fn and must not be modified or retained, so copy it if you need it after fn returns.
+// Do not call other methods of cache in fn, or it may cause a deadlock.
+func (bc *BytesCache) View(key string, fn func(value []byte)) (found bool) {
+	shard, hash := bc.shardOf(key)
+
+	shard.lock.RLock()
+	defer shard.lock.RUnlock()
+
+	value, found := shard.get(key, hash)
+	if found {
+		fn(value)
+	}
+
+	return found
+}
+
+// Set sets key and value to cache with ttl, and the value will be copied to buffer.
+// Returns ErrEntryTooLarge if the entry can't fit in the buffer of a sharding, or ErrClosed if cache is closed.
+func (bc *BytesCache) Set(key string, value []byte, ttl time.Duration) error {
+	shard, hash := bc.shardOf(key)
+
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	if shard.buf == nil {
+		return ErrClosed
+	}
+
+	if len(key) > maxBytesKeyLen || bytesHeaderSize+len(key)+len(value) > len(shard.buf) {
+		return ErrEntryTooLarge
+	}
+
+	shard.set(key, hash, value, ttl)
+	return nil
+}
+
+// Remove removes key and returns true if it's removed.
+func (bc *BytesCache) Remove(key string) (removed bool) {
+	shard, hash := bc.shardOf(key)
+
+	shard.lock.Lock()
+	defer shard.lock.Unlock()
+
+	return shard.remove(key, hash)
+}
+
+// Size returns the count of keys in cache.
+// Expired keys are counted until they are cleaned by gc or evicted.
+func (bc *BytesCache) Size() (size int) {
+	for _, shard := range bc.shards {
+		shard.lock.RLock()
+		size += len(shard.index)
+		shard.lock.RUnlock()
+	}
+
+	return size
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// The bytes of cleaned keys are released after they are evicted from buffers.
+func (bc *BytesCache) GC() (cleans int) {
+	for _, shard := range bc.shards {
+		shard.lock.Lock()
+		cleans += shard.gc()
+		shard.lock.Unlock()
+	}
+
+	return cleans
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// The buffers are reused without being allocated again.
+func (bc *BytesCache) Reset() {
+	for _, shard := range bc.shards {
+		shard.lock.Lock()
+		shard.reset()
+		shard.lock.Unlock()
+	}
+}
+
+// Close closes cache, stops its gc task and releases its buffers.
+// Set returns ErrClosed after closing, and other calls find nothing in cache.
+// Closing a closed cache does nothing and returns nil.
+func (bc *BytesCache) Close() error {
+	if bc.cancel != nil {
+		bc.cancel()
+	}
+
+	for _, shard := range bc.shards {
+		shard.lock.Lock()
+		shard.reset()
+		shard.buf = nil
+		shard.lock.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestBytesCache(maxCost int64) *BytesCache {
+	conf := newDefaultConfig()
+	conf.maxCost = maxCost
+
+	return newBytesCache(conf)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCache$
+func TestBytesCache(t *testing.T) {
+	cache := newTestBytesCache(64 * 1024)
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := []byte(strconv.Itoa(i))
+		if err := cache.Set(string(data), data, NoTTL); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if cache.Size() != maxTestEntries {
+		t.Fatalf("cache.Size() %d != maxTestEntries %d", cache.Size(), maxTestEntries)
+	}
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := []byte(strconv.Itoa(i))
+
+		value, found := cache.Get(string(data))
+		if !found || !bytes.Equal(value, data) {
+			t.Fatalf("key %s: value %s, found %+v is wrong", data, value, found)
+		}
+	}
+
+	// Values returned by Get are copies, so modifying them won't change the cache.
+	value, _ := cache.Get("0")
+	value[0] = 'x'
+
+	found := cache.View("0", func(value []byte) {
+		if string(value) != "0" {
+			t.Fatalf("value %s != 0", value)
+		}
+	})
+
+	if !found {
+		t.Fatal("key 0 not found")
+	}
+
+	cache.Set("0", []byte("new"), NoTTL)
+	if value, _ := cache.Get("0"); string(value) != "new" {
+		t.Fatalf("value %s != new", value)
+	}
+
+	if !cache.Remove("0") || cache.Remove("0") {
+		t.Fatal("removing key 0 is wrong")
+	}
+
+	if _, found := cache.Get("0"); found {
+		t.Fatal("key 0 found after removing")
+	}
+
+	cache.Reset()
+	if cache.Size() != 0 {
+		t.Fatalf("cache.Size() %d != 0", cache.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCacheTTL$
+func TestBytesCacheTTL(t *testing.T) {
+	cache := newTestBytesCache(1024)
+	cache.Set("key", []byte("value"), time.Millisecond)
+	cache.Set("no-ttl", []byte("value"), NoTTL)
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, found := cache.Get("key"); found {
+		t.Fatal("expired key found")
+	}
+
+	if cache.Size() != 2 {
+		t.Fatalf("cache.Size() %d != 2", cache.Size())
+	}
+
+	if cleans := cache.GC(); cleans != 1 {
+		t.Fatalf("cleans %d != 1", cleans)
+	}
+
+	if cache.Size() != 1 {
+		t.Fatalf("cache.Size() %d != 1", cache.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCacheEvict$
+func TestBytesCacheEvict(t *testing.T) {
+	var evictedKeys []string
+
+	conf := newDefaultConfig()
+	conf.maxCost = 10 * (bytesHeaderSize + 8)
+	conf.onEvicted = func(key string, value interface{}, cause RemovalCause) {
+		if cause == RemovalCapacity {
+			evictedKeys = append(evictedKeys, key)
+		}
+	}
+
+	cache := newBytesCache(conf)
+
+	// Each entry takes bytesHeaderSize + 8 bytes, so the buffer can store 10 entries.
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(1000 + i)
+		cache.Set(key, []byte(key), NoTTL)
+
+		if cache.Size() > 10 {
+			t.Fatalf("cache.Size() %d > 10", cache.Size())
+		}
+
+		if value, found := cache.Get(key); !found || string(value) != key {
+			t.Fatalf("key %s: value %s, found %+v is wrong", key, value, found)
+		}
+	}
+
+	if cache.Size() != 10 || len(evictedKeys) != 90 {
+		t.Fatalf("cache.Size() %d, len(evictedKeys) %d is wrong", cache.Size(), len(evictedKeys))
+	}
+
+	// Entries should be evicted in FIFO order.
+	for i, key := range evictedKeys {
+		if want := strconv.Itoa(1000 + i); key != want {
+			t.Fatalf("evicted key %s != %s", key, want)
+		}
+	}
+
+	// Entries in different sizes should wrap around the buffer correctly.
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		value := bytes.Repeat([]byte{'v'}, i%50)
+
+		if err := cache.Set(key, value, NoTTL); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, found := cache.Get(key); !found || !bytes.Equal(got, value) {
+			t.Fatalf("key %s: value %s, found %+v is wrong", key, got, found)
+		}
+	}
+
+	if err := cache.Set("key", make([]byte, conf.maxCost), NoTTL); err != ErrEntryTooLarge {
+		t.Fatalf("err %+v != ErrEntryTooLarge", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCacheMaxEntries$
+func TestBytesCacheMaxEntries(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxCost = 64 * 1024
+	conf.maxEntries = 10
+
+	cache := newBytesCache(conf)
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		cache.Set(key, []byte(key), NoTTL)
+	}
+
+	if cache.Size() != 10 {
+		t.Fatalf("cache.Size() %d != 10", cache.Size())
+	}
+
+	if _, found := cache.Get("89"); found {
+		t.Fatal("key 89 found")
+	}
+
+	if _, found := cache.Get("90"); !found {
+		t.Fatal("key 90 not found")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCacheHashCollision$
+func TestBytesCacheHashCollision(t *testing.T) {
+	var evictedKeys []string
+	var causes []RemovalCause
+
+	conf := newDefaultConfig()
+	conf.maxCost = 64 * 1024
+	conf.hash = func(key string) int {
+		return 1
+	}
+
+	conf.onEvicted = func(key string, value interface{}, cause RemovalCause) {
+		evictedKeys = append(evictedKeys, key)
+		causes = append(causes, cause)
+	}
+
+	cache := newBytesCache(conf)
+	cache.Set("key1", []byte("value1"), NoTTL)
+	cache.Set("key2", []byte("value2"), NoTTL)
+
+	// Key1 is evicted by key2 having the same hash.
+	if len(evictedKeys) != 1 || evictedKeys[0] != "key1" || causes[0] != RemovalCapacity {
+		t.Fatalf("evictedKeys %+v, causes %+v is wrong", evictedKeys, causes)
+	}
+
+	if _, found := cache.Get("key1"); found {
+		t.Fatal("key1 found")
+	}
+
+	if value, found := cache.Get("key2"); !found || string(value) != "value2" {
+		t.Fatalf("value %s, found %+v is wrong", value, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewBytesCache$
+func TestNewBytesCache(t *testing.T) {
+	cache := NewBytesCache(WithMaxCost(64*1024), WithShardings(4))
+	if len(cache.shards) != 4 {
+		t.Fatalf("len(cache.shards) %d != 4", len(cache.shards))
+	}
+
+	for _, shard := range cache.shards {
+		if len(shard.buf) != 16*1024 {
+			t.Fatalf("len(shard.buf) %d != %d", len(shard.buf), 16*1024)
+		}
+	}
+
+	for i := 0; i < maxTestEntries; i++ {
+		key := strconv.Itoa(i)
+		cache.Set(key, []byte(key), NoTTL)
+	}
+
+	if cache.Size() != maxTestEntries {
+		t.Fatalf("cache.Size() %d != maxTestEntries %d", cache.Size(), maxTestEntries)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("new bytes cache without max cost should panic")
+		}
+	}()
+
+	NewBytesCache()
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestBytesCacheClose$
+func TestBytesCacheClose(t *testing.T) {
+	cache := NewBytesCache(WithMaxCost(1024), WithGC(time.Hour))
+	cache.Set("key", []byte("value"), NoTTL)
+
+	for i := 0; i < 2; i++ {
+		if err := cache.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, found := cache.Get("key"); found {
+		t.Fatal("key found after closing")
+	}
+
+	if err := cache.Set("key", []byte("value"), NoTTL); err != ErrClosed {
+		t.Fatalf("err %+v != ErrClosed", err)
+	}
+
+	if cache.Size() != 0 {
+		t.Fatalf("cache.Size() %d != 0", cache.Size())
+	}
+}
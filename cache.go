@@ -31,6 +31,10 @@ var (
 		standard: newStandardCache,
 		lru:      newLRUCache,
 		lfu:      newLFUCache,
+		tinylfu:  newTinyLFUCache,
+		arc:      newARCCache,
+		sieve:    newSIEVECache,
+		s3fifo:   newS3FIFOCache,
 	}
 )
 
@@ -52,10 +56,59 @@ type Cache interface {
 	// A nil value will be returned if key doesn't exist in cache.
 	Remove(key string) (removedValue interface{})
 
+	// TTL returns the remaining ttl of key and returns false if key doesn't exist or is expired.
+	// NoTTL will be returned if key is never expired.
+	TTL(key string) (ttl time.Duration, found bool)
+
+	// Touch resets the ttl of key and accesses key like Get, so it updates the recency in lru and the frequency in lfu.
+	// Returns false if key doesn't exist or is expired.
+	Touch(key string, ttl time.Duration) (found bool)
+
+	// Expire resets the ttl of key without accessing key, and NoTTL means key is never expired.
+	// Returns false if key doesn't exist or is expired.
+	Expire(key string, ttl time.Duration) (found bool)
+
+	// Persist removes the ttl of key so key is never expired.
+	// Returns false if key doesn't exist or is expired.
+	Persist(key string) (found bool)
+
+	// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+	// It returns the existing value and true if found, or returns value and false.
+	GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool)
+
+	// SetIfAbsent sets value with ttl to cache only if key is absent.
+	// It returns value and true if stored, or returns the existing value and false.
+	SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool)
+
+	// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+	// A nil equal uses == to compare, which panics if values are not comparable.
+	CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool)
+
+	// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+	// It returns the new value and true if kept.
+	// Don't call methods of cache in fn, or it may cause a deadlock.
+	Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool)
+
+	// GetAndRemove removes key and returns its value if found.
+	GetAndRemove(key string) (value interface{}, found bool)
+
+	// Incr adds delta to the int64 value of key and returns the new value.
+	// Key will be set with delta and ttl if it's absent or its value isn't an int64, or its ttl won't be changed and it won't be accessed like Get.
+	// Notice that the weigher won't be called again when adding to an existing key.
+	Incr(key string, delta int64, ttl time.Duration) (value int64)
+
+	// Decr subtracts delta from the int64 value of key and returns the new value.
+	// It's the same as Incr with -delta.
+	Decr(key string, delta int64, ttl time.Duration) (value int64)
+
 	// Size returns the count of keys in cache.
 	// The result may be different in different implements.
 	Size() (size int)
 
+	// Cost returns the total cost of entries in cache.
+	// The cost of an entry is computed by weigher, and it's 1 by default. See WithWeigher.
+	Cost() (cost int64)
+
 	// GC cleans the expired keys in cache and returns the exact count cleaned.
 	// The exact cleans depend on implements, however, all implements should have a limit of scanning.
 	GC() (cleans int)
@@ -63,10 +116,43 @@ type Cache interface {
 	// Reset resets cache to initial status which is like a new cache.
 	Reset()
 
+	// Close closes cache, stops its gc task and releases its entries.
+	// Calls after closing are no-ops, and the ones returning errors will return ErrClosed.
+	// Closing a closed cache does nothing and returns nil.
+	Close() error
+
+	// Range calls fn with each unexpired entry and its remaining ttl in cache, and stops if fn returns false.
+	// Entries are copied before calling fn, so it's safe to use cache in fn.
+	// Use WithRangeInEvictionOrder if you want to iterate lru/lfu caches in eviction order.
+	Range(fn func(key string, value interface{}, ttl time.Duration) bool)
+
+	// Keys returns all unexpired keys in cache in the same order as Range.
+	Keys() (keys []string)
+
 	// Load loads a key with ttl to cache and returns an error if failed.
 	// We recommend you use this method to load missed keys to cache,
 	// because it may use singleflight to reduce the times calling load function.
 	Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error)
+
+	// LoadContext loads a key with ttl to cache like Load, but the caller returns ctx.Err() right away if ctx is done.
+	// The load function keeps going for other callers and the loaded value will be set to cache even if the caller leaves.
+	// See WithLoadTimeout and WithCancelAbandonedLoad.
+	LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error)
+
+	// GetMulti gets the values of keys from cache and returns the found values and the missed keys.
+	// It's faster than calling Get one by one because it only locks once (or once for each sharding).
+	GetMulti(keys []string) (values map[string]interface{}, missedKeys []string)
+
+	// SetMulti sets entries to cache with the same ttl.
+	SetMulti(entries map[string]interface{}, ttl time.Duration)
+
+	// RemoveMulti removes keys and returns the removed values of keys.
+	RemoveMulti(keys []string) (removedValues map[string]interface{})
+
+	// LoadMulti gets keys from cache and loads all missed keys with ttl to cache by calling load once.
+	// The missed keys which are not returned by load won't be set to cache or returned.
+	// It uses singleflight for each key if singleflight is enabled.
+	LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error)
 }
 
 func newCache(withReport bool, opts ...Option) (cache Cache, reporter *Reporter) {
@@ -84,14 +170,32 @@ func newCache(withReport bool, opts ...Option) (cache Cache, reporter *Reporter)
 		cache = newCache(conf)
 	}
 
+	if conf.negativeTTL > 0 {
+		cache = newNegativeCache(conf, cache)
+	}
+
 	if withReport {
 		cache, reporter = report(conf, cache)
 	}
 
+	if conf.refreshWindow > 0 || conf.maxStale > 0 {
+		cache = newRefreshableCache(conf, cache)
+	}
+
+	if conf.invalidator != nil {
+		cache = newInvalidatingCache(conf, cache)
+	}
+
+	if conf.store != nil {
+		cache = newStoreCache(conf, cache)
+	}
+
+	var cancel func()
 	if conf.gcDuration > 0 {
-		RunGCTask(cache, conf.gcDuration)
+		cancel = RunGCTask(cache, conf.gcDuration)
 	}
 
+	cache = newClosableCache(cache, cancel)
 	return cache, reporter
 }
 
@@ -118,11 +222,15 @@ func NewCacheWithReport(opts ...Option) (cache Cache, reporter *Reporter) {
 // RunGCTask runs a gc task in a new goroutine and returns a cancel function to cancel the task.
 // However, you don't need to call it manually for most time, instead, use options is a better choice.
 // Making it a public function is for more customizations in some situations.
-// For example, using options to run gc task is un-cancelable, so you can use it to run gc task by your own
-// and get a cancel function to cancel the gc task.
+// For example, you can run gc task on a cache created without WithGC and cancel it whenever you want.
+// Notice that the gc task run by options is stopped when closing cache.
 func RunGCTask(cache Cache, duration time.Duration) (cancel func()) {
+	return runGCTask(cache.GC, duration)
+}
+
+func runGCTask(gc func() (cleans int), duration time.Duration) (cancel func()) {
 	fn := func(ctx context.Context) {
-		cache.GC()
+		gc()
 	}
 
 	ctx := context.Background()
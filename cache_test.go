@@ -15,7 +15,11 @@
 package cachego
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -59,6 +63,82 @@ func (tc *testCache) GC() (cleans int) {
 
 func (tc *testCache) Reset() {}
 
+func (tc *testCache) Close() error {
+	return nil
+}
+
+func (tc *testCache) TTL(key string) (ttl time.Duration, found bool) {
+	return 0, false
+}
+
+func (tc *testCache) Touch(key string, ttl time.Duration) (found bool) {
+	return false
+}
+
+func (tc *testCache) Expire(key string, ttl time.Duration) (found bool) {
+	return false
+}
+
+func (tc *testCache) Persist(key string) (found bool) {
+	return false
+}
+
+func (tc *testCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	return nil, false
+}
+
+func (tc *testCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	return nil, false
+}
+
+func (tc *testCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	return false
+}
+
+func (tc *testCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	return nil, false
+}
+
+func (tc *testCache) GetAndRemove(key string) (value interface{}, found bool) {
+	return nil, false
+}
+
+func (tc *testCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	return 0
+}
+
+func (tc *testCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return 0
+}
+
+func (tc *testCache) Cost() (cost int64) {
+	return 0
+}
+
+func (tc *testCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return nil, nil
+}
+
+func (tc *testCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {}
+
+func (tc *testCache) Keys() (keys []string) {
+	return nil
+}
+
+func (tc *testCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	return nil, keys
+}
+
+func (tc *testCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {}
+
+func (tc *testCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	return nil
+}
+
+func (tc *testCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return nil, nil
+}
+
 func (tc *testCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
 	return nil, nil
 }
@@ -167,6 +247,12 @@ func testCacheSize(t *testing.T, cache Cache) {
 	if size != maxTestEntries {
 		t.Fatalf("size %d is wrong", size)
 	}
+
+	// The cost of each entry is 1 by default.
+	cost := cache.Cost()
+	if cost != maxTestEntries {
+		t.Fatalf("cost %d is wrong", cost)
+	}
 }
 
 func testCacheGC(t *testing.T, cache Cache) {
@@ -241,9 +327,411 @@ func testCacheReset(t *testing.T, cache Cache) {
 	}
 }
 
+func testCacheRange(t *testing.T, cache Cache) {
+	for i := 0; i < maxTestEntries/2; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, time.Hour)
+	}
+
+	cache.Set("expired", "expired", time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	ranges := make(map[string]interface{}, maxTestEntries)
+	cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		if ttl <= 0 || ttl > time.Hour {
+			t.Fatalf("ttl %s of key %s is wrong", ttl, key)
+		}
+
+		ranges[key] = value
+		return true
+	})
+
+	if len(ranges) != maxTestEntries/2 {
+		t.Fatalf("len(ranges) %d != %d", len(ranges), maxTestEntries/2)
+	}
+
+	for i := 0; i < maxTestEntries/2; i++ {
+		data := strconv.Itoa(i)
+
+		if value, ok := ranges[data]; !ok || value.(string) != data {
+			t.Fatalf("value %+v, ok %+v of key %s is wrong", value, ok, data)
+		}
+	}
+
+	keys := cache.Keys()
+	if len(keys) != maxTestEntries/2 {
+		t.Fatalf("len(keys) %d != %d", len(keys), maxTestEntries/2)
+	}
+
+	for _, key := range keys {
+		if _, ok := ranges[key]; !ok {
+			t.Fatalf("key %s not found in ranges", key)
+		}
+	}
+
+	// Range should stop if fn returns false, and it's safe to use cache in fn.
+	count := 0
+	cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		cache.Remove(key)
+
+		count++
+		return count < 2
+	})
+
+	if count != 2 {
+		t.Fatalf("count %d != 2", count)
+	}
+
+	if len(cache.Keys()) != maxTestEntries/2-2 {
+		t.Fatalf("len(cache.Keys()) %d != %d", len(cache.Keys()), maxTestEntries/2-2)
+	}
+}
+
+func testCacheMulti(t *testing.T, cache Cache) {
+	entries := make(map[string]interface{}, maxTestEntries/2)
+	keys := make([]string, 0, maxTestEntries/2)
+
+	for i := 0; i < maxTestEntries/2; i++ {
+		data := strconv.Itoa(i)
+		entries[data] = data
+		keys = append(keys, data)
+	}
+
+	cache.SetMulti(entries, NoTTL)
+
+	values, missedKeys := cache.GetMulti(append(keys, "missed"))
+	if len(missedKeys) != 1 || missedKeys[0] != "missed" {
+		t.Fatalf("missedKeys %+v is wrong", missedKeys)
+	}
+
+	if fmt.Sprint(values) != fmt.Sprint(entries) {
+		t.Fatalf("values %+v != entries %+v", values, entries)
+	}
+
+	var loads []string
+	values, err := cache.LoadMulti([]string{"0", "loaded", "missed"}, NoTTL, func(missedKeys []string) (map[string]interface{}, error) {
+		loads = append(loads, missedKeys...)
+		return map[string]interface{}{"loaded": "loaded"}, nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(loads) != fmt.Sprint([]string{"loaded", "missed"}) && fmt.Sprint(loads) != fmt.Sprint([]string{"missed", "loaded"}) {
+		t.Fatalf("loads %+v is wrong", loads)
+	}
+
+	expect := map[string]interface{}{"0": "0", "loaded": "loaded"}
+	if fmt.Sprint(values) != fmt.Sprint(expect) {
+		t.Fatalf("values %+v != expect %+v", values, expect)
+	}
+
+	if value, ok := cache.Get("loaded"); !ok || value.(string) != "loaded" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	if _, ok := cache.Get("missed"); ok {
+		t.Fatal("missed key shouldn't be set to cache")
+	}
+
+	removedValues := cache.RemoveMulti(append(keys, "missed"))
+	if fmt.Sprint(removedValues) != fmt.Sprint(entries) {
+		t.Fatalf("removedValues %+v != entries %+v", removedValues, entries)
+	}
+
+	if cache.Size() != 1 {
+		t.Fatalf("cache.Size() %d != 1", cache.Size())
+	}
+}
+
+func testCacheLoadContext(t *testing.T, cache Cache) {
+	value, err := cache.LoadContext(context.Background(), "key", NoTTL, func(ctx context.Context) (value interface{}, err error) {
+		return "value", nil
+	})
+
+	if err != nil || value.(string) != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	if value, ok := cache.Get("key"); !ok || value.(string) != "value" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// The caller leaves, but the load function keeps going and sets the value to cache.
+	loaded := make(chan struct{})
+	_, err = cache.LoadContext(ctx, "slow", NoTTL, func(ctx context.Context) (value interface{}, err error) {
+		defer close(loaded)
+
+		time.Sleep(50 * time.Millisecond)
+		return "slow", nil
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("err %+v != context.DeadlineExceeded", err)
+	}
+
+	<-loaded
+	time.Sleep(10 * time.Millisecond)
+
+	if value, ok := cache.Get("slow"); !ok || value.(string) != "slow" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+}
+
+func testCacheTTL(t *testing.T, cache Cache) {
+	if _, found := cache.TTL("key"); found {
+		t.Fatal("key found before setting")
+	}
+
+	if cache.Touch("key", time.Second) || cache.Expire("key", time.Second) || cache.Persist("key") {
+		t.Fatal("missing key shouldn't be found")
+	}
+
+	cache.Set("key", "value", NoTTL)
+
+	ttl, found := cache.TTL("key")
+	if !found || ttl != NoTTL {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	if !cache.Expire("key", time.Minute) {
+		t.Fatal("expire key failed")
+	}
+
+	ttl, found = cache.TTL("key")
+	if !found || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	if !cache.Touch("key", time.Hour) {
+		t.Fatal("touch key failed")
+	}
+
+	ttl, found = cache.TTL("key")
+	if !found || ttl <= time.Minute || ttl > time.Hour {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	if !cache.Persist("key") {
+		t.Fatal("persist key failed")
+	}
+
+	ttl, found = cache.TTL("key")
+	if !found || ttl != NoTTL {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	cache.Expire("key", time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if _, found = cache.TTL("key"); found {
+		t.Fatal("expired key found")
+	}
+
+	if cache.Touch("key", time.Second) || cache.Expire("key", time.Second) || cache.Persist("key") {
+		t.Fatal("expired key shouldn't be found")
+	}
+
+	if value, found := cache.Get("key"); found {
+		t.Fatalf("expired key found with value %+v", value)
+	}
+}
+
+func testCacheAtomic(t *testing.T, cache Cache) {
+	if actual, found := cache.GetOrSet("key", 1, NoTTL); found || actual != 1 {
+		t.Fatalf("actual %+v, found %+v is wrong", actual, found)
+	}
+
+	if actual, found := cache.GetOrSet("key", 2, NoTTL); !found || actual != 1 {
+		t.Fatalf("actual %+v, found %+v is wrong", actual, found)
+	}
+
+	if actual, stored := cache.SetIfAbsent("key", 2, NoTTL); stored || actual != 1 {
+		t.Fatalf("actual %+v, stored %+v is wrong", actual, stored)
+	}
+
+	if actual, stored := cache.SetIfAbsent("absent", 2, NoTTL); !stored || actual != 2 {
+		t.Fatalf("actual %+v, stored %+v is wrong", actual, stored)
+	}
+
+	if cache.CompareAndSwap("key", 2, 3, NoTTL, nil) {
+		t.Fatal("swapping key with wrong old value should fail")
+	}
+
+	if cache.CompareAndSwap("missing", nil, 3, NoTTL, nil) {
+		t.Fatal("swapping missing key should fail")
+	}
+
+	if !cache.CompareAndSwap("key", 1, 3, NoTTL, nil) {
+		t.Fatal("swapping key with right old value failed")
+	}
+
+	equal := func(old interface{}, current interface{}) bool {
+		return old.([]int)[0] == current.([]int)[0]
+	}
+
+	cache.Set("slice", []int{1}, NoTTL)
+	if !cache.CompareAndSwap("slice", []int{1}, []int{2}, NoTTL, equal) {
+		t.Fatal("swapping key with equal func failed")
+	}
+
+	incr := func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		if !found {
+			return 1, time.Minute, true
+		}
+
+		return old.(int) + 1, time.Minute, true
+	}
+
+	for i := 1; i <= 3; i++ {
+		if value, kept := cache.Update("counter", incr); !kept || value != i {
+			t.Fatalf("value %+v, kept %+v is wrong", value, kept)
+		}
+	}
+
+	if ttl, found := cache.TTL("counter"); !found || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	drop := func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		if !found || old != 3 {
+			t.Fatalf("old %+v, found %+v is wrong", old, found)
+		}
+
+		return nil, NoTTL, false
+	}
+
+	if value, kept := cache.Update("counter", drop); kept || value != nil {
+		t.Fatalf("value %+v, kept %+v is wrong", value, kept)
+	}
+
+	if _, found := cache.Get("counter"); found {
+		t.Fatal("counter found after dropping")
+	}
+
+	if value, found := cache.GetAndRemove("key"); !found || value != 3 {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if value, found := cache.GetAndRemove("key"); found {
+		t.Fatalf("removed key found with value %+v", value)
+	}
+
+	cache.Set("expired", 1, time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if actual, stored := cache.SetIfAbsent("expired", 2, NoTTL); !stored || actual != 2 {
+		t.Fatalf("actual %+v, stored %+v is wrong", actual, stored)
+	}
+
+	cache.Set("expired", 1, time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if value, found := cache.GetAndRemove("expired"); found {
+		t.Fatalf("expired key found with value %+v", value)
+	}
+}
+
+func testCacheIncr(t *testing.T, cache Cache) {
+	if value := cache.Incr("counter", 2, time.Minute); value != 2 {
+		t.Fatalf("value %d != 2", value)
+	}
+
+	cache.Expire("counter", time.Hour)
+
+	if value := cache.Incr("counter", 3, time.Minute); value != 5 {
+		t.Fatalf("value %d != 5", value)
+	}
+
+	if value := cache.Decr("counter", 1, time.Minute); value != 4 {
+		t.Fatalf("value %d != 4", value)
+	}
+
+	// The ttl of an existing key shouldn't be changed.
+	if ttl, found := cache.TTL("counter"); !found || ttl <= time.Minute || ttl > time.Hour {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	if value, found := cache.Get("counter"); !found || value != int64(4) {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	cache.Set("string", "value", NoTTL)
+	if value := cache.Incr("string", 1, NoTTL); value != 1 {
+		t.Fatalf("value %d != 1", value)
+	}
+
+	cache.Set("expired", int64(100), time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if value := cache.Decr("expired", 1, time.Minute); value != -1 {
+		t.Fatalf("value %d != -1", value)
+	}
+
+	if ttl, found := cache.TTL("expired"); !found || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+}
+
+// testCacheSlidingExpiration tests a cache with sliding expiration of 10ns and max lifetime of 25ns.
+func testCacheSlidingExpiration(t *testing.T, cache Cache, clock *atomic.Int64) {
+	clock.Store(1000)
+	cache.Set("key", "value", 10)
+	cache.Set("no-ttl", "value", NoTTL)
+
+	// Each get should extend the expiration by its original ttl.
+	for _, now := range []int64{1008, 1016} {
+		clock.Store(now)
+
+		if _, found := cache.Get("key"); !found {
+			t.Fatalf("key not found at %d", now)
+		}
+	}
+
+	// The expiration shouldn't exceed the max lifetime.
+	clock.Store(1024)
+	if _, found := cache.Get("key"); !found {
+		t.Fatal("key not found at 1024")
+	}
+
+	if ttl, found := cache.TTL("key"); !found || ttl != 1 {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	clock.Store(1026)
+	if _, found := cache.Get("key"); found {
+		t.Fatal("key found after max lifetime")
+	}
+
+	if ttl, found := cache.TTL("no-ttl"); !found || ttl != NoTTL {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	// Setting key again should reset its max lifetime.
+	cache.Set("key", "value", 10)
+	clock.Store(1034)
+
+	if _, found := cache.Get("key"); !found {
+		t.Fatal("key not found at 1034")
+	}
+
+	if cleans := cache.GC(); cleans != 0 {
+		t.Fatalf("cleans %d != 0", cleans)
+	}
+
+	clock.Store(1045)
+	if cleans := cache.GC(); cleans != 1 {
+		t.Fatalf("cleans %d != 1", cleans)
+	}
+}
+
 func testCacheImplement(t *testing.T, cache Cache) {
 	testCaches := []func(t *testing.T, cache Cache){
-		testCacheGet, testCacheSet, testCacheRemove, testCacheSize, testCacheGC, testCacheReset,
+		testCacheGet, testCacheSet, testCacheRemove, testCacheSize, testCacheGC, testCacheReset, testCacheRange, testCacheMulti, testCacheLoadContext, testCacheAtomic, testCacheIncr, testCacheTTL,
 	}
 
 	for _, testCache := range testCaches {
@@ -256,7 +744,7 @@ func testCacheImplement(t *testing.T, cache Cache) {
 func TestNewCache(t *testing.T) {
 	cache := NewCache()
 
-	sc1, ok := cache.(*standardCache)
+	sc1, ok := cache.(*closableCache).cache.(*standardCache)
 	if !ok {
 		t.Fatalf("cache.(*standardCache) %T not ok", cache)
 	}
@@ -267,7 +755,7 @@ func TestNewCache(t *testing.T) {
 
 	cache = NewCache(WithLRU(16))
 
-	sc2, ok := cache.(*lruCache)
+	sc2, ok := cache.(*closableCache).cache.(*lruCache)
 	if !ok {
 		t.Fatalf("cache.(*lruCache) %T not ok", cache)
 	}
@@ -278,7 +766,7 @@ func TestNewCache(t *testing.T) {
 
 	cache = NewCache(WithShardings(64))
 
-	sc, ok := cache.(*shardingCache)
+	sc, ok := cache.(*closableCache).cache.(*shardingCache)
 	if !ok {
 		t.Fatalf("cache.(*shardingCache) %T not ok", cache)
 	}
@@ -296,11 +784,42 @@ func TestNewCache(t *testing.T) {
 	cache = NewCache(WithLRU(0))
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewCacheBoundedByEntries$
+func TestNewCacheBoundedByEntries(t *testing.T) {
+	withCacheTypes := map[CacheType]func(maxEntries int) Option{
+		tinylfu: WithTinyLFU,
+		arc:     WithARC,
+		sieve:   WithSIEVE,
+		s3fifo:  WithS3FIFO,
+	}
+
+	for cacheType, withCacheType := range withCacheTypes {
+		optsList := [][]Option{
+			{withCacheType(0), WithMaxCost(1024)},
+			{withCacheType(maxTestEntries), WithSlidingExpiration(0)},
+			{withCacheType(maxTestEntries), WithExpirationIndex()},
+		}
+
+		for _, opts := range optsList {
+			func() {
+				defer func() {
+					r := recover()
+					if msg, ok := r.(string); !ok || !strings.Contains(msg, cacheType.String()) {
+						t.Fatalf("%s: recover %+v is wrong", cacheType, r)
+					}
+				}()
+
+				NewCache(opts...)
+			}()
+		}
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1=^TestNewCacheWithReport$
 func TestNewCacheWithReport(t *testing.T) {
 	cache, reporter := NewCacheWithReport()
 
-	sc1, ok := cache.(*reportableCache)
+	sc1, ok := cache.(*closableCache).cache.(*reportableCache)
 	if !ok {
 		t.Fatalf("cache.(*reportableCache) %T not ok", cache)
 	}
@@ -314,6 +833,51 @@ func TestNewCacheWithReport(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestCacheCost$
+func TestCacheCost(t *testing.T) {
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(key)) + value.(int64)
+	}
+
+	for cacheType, newCache := range newCaches {
+		conf := newDefaultConfig()
+		conf.maxEntries = 16
+		conf.weigher = weigher
+
+		cache := newCache(conf)
+		random := rand.New(rand.NewSource(int64(len(cacheType))))
+
+		// Churn keys so entries will be replaced, removed and evicted.
+		for i := 0; i < 1000; i++ {
+			key := strconv.Itoa(random.Intn(64))
+
+			switch random.Intn(4) {
+			case 0:
+				cache.Remove(key)
+			case 1:
+				cache.Get(key)
+			default:
+				cache.Set(key, random.Int63n(100), NoTTL)
+			}
+		}
+
+		var expect int64
+		cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+			expect += weigher(key, value)
+			return true
+		})
+
+		if cost := cache.Cost(); cost != expect {
+			t.Fatalf("%s: cost %d != expect %d", cacheType, cost, expect)
+		}
+
+		cache.Reset()
+		if cost := cache.Cost(); cost != 0 {
+			t.Fatalf("%s: cost %d != 0", cacheType, cost)
+		}
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1=^TestRunGCTask$
 func TestRunGCTask(t *testing.T) {
 	cache := new(testCache)
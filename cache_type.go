@@ -26,6 +26,26 @@ const (
 	// lfu cache is a cache using lfu to evict entries.
 	// More details see https://en.wikipedia.org/wiki/Cache_replacement_policies#Least-frequently_used_(LFU).
 	lfu CacheType = "lfu"
+
+	// tinylfu cache is a cache using w-tinylfu to evict entries.
+	// It has a window lru for new entries and a segmented main lru, and admits entries by frequencies estimated by a count-min sketch.
+	// More details see https://arxiv.org/abs/1512.00727.
+	tinylfu CacheType = "tinylfu"
+
+	// arc cache is a cache using arc to evict entries.
+	// It keeps two resident lists and two ghost lists, and adapts the target size between recency and frequency automatically.
+	// More details see https://en.wikipedia.org/wiki/Adaptive_replacement_cache.
+	arc CacheType = "arc"
+
+	// sieve cache is a cache using sieve to evict entries.
+	// It only marks entries visited when getting them, so getting entries won't block each other.
+	// More details see https://cachemon.github.io/SIEVE-website/.
+	sieve CacheType = "sieve"
+
+	// s3fifo cache is a cache using s3-fifo to evict entries.
+	// It uses a small fifo, a main fifo and a ghost fifo, and getting entries only increases their frequencies.
+	// More details see https://s3fifo.com/.
+	s3fifo CacheType = "s3fifo"
 )
 
 // CacheType is the type of cache.
@@ -50,3 +70,23 @@ func (ct CacheType) IsLRU() bool {
 func (ct CacheType) IsLFU() bool {
 	return ct == lfu
 }
+
+// IsTinyLFU returns if cache type is tinylfu.
+func (ct CacheType) IsTinyLFU() bool {
+	return ct == tinylfu
+}
+
+// IsARC returns if cache type is arc.
+func (ct CacheType) IsARC() bool {
+	return ct == arc
+}
+
+// IsSIEVE returns if cache type is sieve.
+func (ct CacheType) IsSIEVE() bool {
+	return ct == sieve
+}
+
+// IsS3FIFO returns if cache type is s3fifo.
+func (ct CacheType) IsS3FIFO() bool {
+	return ct == s3fifo
+}
@@ -30,6 +30,22 @@ func TestCacheType(t *testing.T) {
 		t.Fatalf("lfu.String() %s is wrong", lfu.String())
 	}
 
+	if tinylfu.String() != string(tinylfu) {
+		t.Fatalf("tinylfu.String() %s is wrong", tinylfu.String())
+	}
+
+	if arc.String() != string(arc) {
+		t.Fatalf("arc.String() %s is wrong", arc.String())
+	}
+
+	if sieve.String() != string(sieve) {
+		t.Fatalf("sieve.String() %s is wrong", sieve.String())
+	}
+
+	if s3fifo.String() != string(s3fifo) {
+		t.Fatalf("s3fifo.String() %s is wrong", s3fifo.String())
+	}
+
 	if !standard.IsStandard() {
 		t.Fatal("!standard.IsStandard()")
 	}
@@ -41,4 +57,20 @@ func TestCacheType(t *testing.T) {
 	if !lfu.IsLFU() {
 		t.Fatal("!standard.IsLFU()")
 	}
+
+	if !tinylfu.IsTinyLFU() {
+		t.Fatal("!tinylfu.IsTinyLFU()")
+	}
+
+	if !arc.IsARC() {
+		t.Fatal("!arc.IsARC()")
+	}
+
+	if !sieve.IsSIEVE() {
+		t.Fatal("!sieve.IsSIEVE()")
+	}
+
+	if !s3fifo.IsS3FIFO() {
+		t.Fatal("!s3fifo.IsS3FIFO()")
+	}
 }
@@ -0,0 +1,460 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed is returned when calling a closed cache.
+var ErrClosed = errors.New("cachego: cache is closed")
+
+// closeGuard tracks running calls of cache, so closing rejects new calls and waits for running ones without locking.
+type closeGuard struct {
+	closed   atomic.Bool
+	calls    atomic.Int64
+	idle     chan struct{}
+	idleOnce sync.Once
+}
+
+func newCloseGuard() *closeGuard {
+	return &closeGuard{
+		idle: make(chan struct{}),
+	}
+}
+
+// enter counts a running call and returns false if closed.
+func (cg *closeGuard) enter() bool {
+	cg.calls.Add(1)
+
+	if cg.closed.Load() {
+		cg.leave()
+		return false
+	}
+
+	return true
+}
+
+// leave uncounts a running call entered successfully.
+func (cg *closeGuard) leave() {
+	if cg.calls.Add(-1) == 0 && cg.closed.Load() {
+		cg.idleOnce.Do(func() {
+			close(cg.idle)
+		})
+	}
+}
+
+// close marks closed and waits for running calls to leave.
+// It returns false if closed already.
+func (cg *closeGuard) close() bool {
+	if !cg.closed.CompareAndSwap(false, true) {
+		return false
+	}
+
+	if cg.calls.Load() > 0 {
+		<-cg.idle
+	}
+
+	return true
+}
+
+// closableCache is the outermost cache which stops the gc task and rejects calls after closing.
+type closableCache struct {
+	cache  Cache
+	cancel func()
+	guard  *closeGuard
+}
+
+func newClosableCache(cache Cache, cancel func()) Cache {
+	return &closableCache{
+		cache:  cache,
+		cancel: cancel,
+		guard:  newCloseGuard(),
+	}
+}
+
+func (cc *closableCache) dump() []DumpEntry {
+	if !cc.guard.enter() {
+		return nil
+	}
+
+	defer cc.guard.leave()
+
+	if dc, ok := cc.cache.(dumpableCache); ok {
+		return dc.dump()
+	}
+
+	return nil
+}
+
+func (cc *closableCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	if !cc.guard.enter() {
+		return nil, 0, false
+	}
+
+	defer cc.guard.leave()
+
+	return Peek(cc.cache, key)
+}
+
+func (cc *closableCache) restore(entry *DumpEntry) {
+	if !cc.guard.enter() {
+		return
+	}
+
+	defer cc.guard.leave()
+
+	if restorable, ok := cc.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	cc.cache.Set(entry.Key, entry.Value, entry.TTL)
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (cc *closableCache) Get(key string) (value interface{}, found bool) {
+	if !cc.guard.enter() {
+		return nil, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists.
+// See Cache interface.
+func (cc *closableCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	if !cc.guard.enter() {
+		return nil
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (cc *closableCache) Remove(key string) (removedValue interface{}) {
+	if !cc.guard.enter() {
+		return nil
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (cc *closableCache) TTL(key string) (ttl time.Duration, found bool) {
+	if !cc.guard.enter() {
+		return 0, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (cc *closableCache) Touch(key string, ttl time.Duration) (found bool) {
+	if !cc.guard.enter() {
+		return false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (cc *closableCache) Expire(key string, ttl time.Duration) (found bool) {
+	if !cc.guard.enter() {
+		return false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (cc *closableCache) Persist(key string) (found bool) {
+	if !cc.guard.enter() {
+		return false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (cc *closableCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	if !cc.guard.enter() {
+		return nil, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.GetOrSet(key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (cc *closableCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	if !cc.guard.enter() {
+		return nil, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.SetIfAbsent(key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (cc *closableCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	if !cc.guard.enter() {
+		return false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.CompareAndSwap(key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (cc *closableCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	if !cc.guard.enter() {
+		return nil, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Update(key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (cc *closableCache) GetAndRemove(key string) (value interface{}, found bool) {
+	if !cc.guard.enter() {
+		return nil, false
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.GetAndRemove(key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (cc *closableCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	if !cc.guard.enter() {
+		return 0
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Incr(key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (cc *closableCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	if !cc.guard.enter() {
+		return 0
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Decr(key, delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (cc *closableCache) Size() (size int) {
+	if !cc.guard.enter() {
+		return 0
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (cc *closableCache) Cost() (cost int64) {
+	if !cc.guard.enter() {
+		return 0
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Cost()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (cc *closableCache) GC() (cleans int) {
+	if !cc.guard.enter() {
+		return 0
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.GC()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (cc *closableCache) Reset() {
+	if !cc.guard.enter() {
+		return
+	}
+
+	defer cc.guard.leave()
+
+	cc.cache.Reset()
+}
+
+// Close closes cache, stops its gc task and releases its entries.
+// It rejects new calls and waits for running calls to return, so don't close cache in functions passed to cache.
+// See Cache interface.
+func (cc *closableCache) Close() error {
+	if !cc.guard.close() {
+		return nil
+	}
+
+	if cc.cancel != nil {
+		cc.cancel()
+	}
+
+	return cc.cache.Close()
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (cc *closableCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	if !cc.guard.enter() {
+		return
+	}
+
+	defer cc.guard.leave()
+
+	cc.cache.Range(fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (cc *closableCache) Keys() (keys []string) {
+	if !cc.guard.enter() {
+		return nil
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Keys()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// It returns ErrClosed if cache is closed.
+// See Cache interface.
+func (cc *closableCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	if !cc.guard.enter() {
+		return nil, ErrClosed
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.Load(key, ttl, load)
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// It returns ErrClosed if cache is closed.
+// See Cache interface.
+func (cc *closableCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	if !cc.guard.enter() {
+		return nil, ErrClosed
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.LoadContext(ctx, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// All keys are missed if cache is closed.
+// See Cache interface.
+func (cc *closableCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	if !cc.guard.enter() {
+		return map[string]interface{}{}, keys
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.GetMulti(keys)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (cc *closableCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	if !cc.guard.enter() {
+		return
+	}
+
+	defer cc.guard.leave()
+
+	cc.cache.SetMulti(entries, ttl)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (cc *closableCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	if !cc.guard.enter() {
+		return map[string]interface{}{}
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.RemoveMulti(keys)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// It returns ErrClosed if cache is closed.
+// See Cache interface.
+func (cc *closableCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	if !cc.guard.enter() {
+		return nil, ErrClosed
+	}
+
+	defer cc.guard.leave()
+
+	return cc.cache.LoadMulti(keys, ttl, load)
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestClosableCache$
+func TestClosableCache(t *testing.T) {
+	cache := newClosableCache(newTestStandardCache(), nil)
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestClosableCacheClose$
+func TestClosableCacheClose(t *testing.T) {
+	cancels := 0
+	standardCache := newTestStandardCache()
+
+	cache := newClosableCache(standardCache, func() {
+		cancels++
+	})
+
+	cache.Set("key", "value", NoTTL)
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing a closed cache should do nothing.
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cancels != 1 {
+		t.Fatalf("cancels %d != 1", cancels)
+	}
+
+	if standardCache.Size() != 0 {
+		t.Fatalf("standardCache.Size() %d != 0", standardCache.Size())
+	}
+
+	cache.Set("key", "value", NoTTL)
+	if _, found := cache.Get("key"); found {
+		t.Fatal("key found after closing")
+	}
+
+	if cache.Size() != 0 || standardCache.Size() != 0 {
+		t.Fatalf("cache.Size() %d, standardCache.Size() %d is wrong", cache.Size(), standardCache.Size())
+	}
+
+	load := func() (interface{}, error) {
+		return "value", nil
+	}
+
+	if _, err := cache.Load("key", NoTTL, load); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err %+v != ErrClosed", err)
+	}
+
+	loadContext := func(ctx context.Context) (interface{}, error) {
+		return "value", nil
+	}
+
+	if _, err := cache.LoadContext(context.Background(), "key", NoTTL, loadContext); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err %+v != ErrClosed", err)
+	}
+
+	loadMulti := func(keys []string) (map[string]interface{}, error) {
+		return nil, nil
+	}
+
+	if _, err := cache.LoadMulti([]string{"key"}, NoTTL, loadMulti); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err %+v != ErrClosed", err)
+	}
+
+	values, missedKeys := cache.GetMulti([]string{"key"})
+	if len(values) != 0 || len(missedKeys) != 1 {
+		t.Fatalf("values %+v, missedKeys %+v is wrong", values, missedKeys)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestClosableCacheCloseConcurrently$
+func TestClosableCacheCloseConcurrently(t *testing.T) {
+	standardCache := newTestStandardCache()
+	cache := newClosableCache(standardCache, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				cache.Set(strconv.Itoa(i*1000+j), j, NoTTL)
+			}
+		}(i)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+
+	// No calls should run after closing, so entries set concurrently are all released.
+	if standardCache.Size() != 0 {
+		t.Fatalf("standardCache.Size() %d != 0", standardCache.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestClosableCacheCloseLoading$
+func TestClosableCacheCloseLoading(t *testing.T) {
+	cache := newClosableCache(newTestStandardCache(), nil)
+
+	loading := make(chan struct{})
+	unblock := make(chan struct{})
+	loaded := make(chan error, 1)
+
+	go func() {
+		_, err := cache.Load("key", NoTTL, func() (interface{}, error) {
+			close(loading)
+			<-unblock
+
+			// Calling cache in a load function while closing should be rejected instead of deadlock.
+			if _, found := cache.Get("other"); found {
+				t.Error("other found while closing")
+			}
+
+			return "value", nil
+		})
+
+		loaded <- err
+	}()
+
+	<-loading
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- cache.Close()
+	}()
+
+	waitFor(t, func() bool {
+		_, found := cache.Get("key")
+		return !found
+	})
+
+	select {
+	case <-closed:
+		t.Fatal("cache is closed before the running load returns")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(unblock)
+
+	if err := <-loaded; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-closed; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewCacheClose$
+func TestNewCacheClose(t *testing.T) {
+	goroutines := runtime.NumGoroutine()
+
+	caches := make([]Cache, 0, 16)
+	for i := 0; i < 16; i++ {
+		caches = append(caches, NewCache(WithGC(time.Hour)))
+	}
+
+	if got := runtime.NumGoroutine(); got < goroutines+16 {
+		t.Fatalf("got %d < goroutines %d + 16", got, goroutines)
+	}
+
+	for _, cache := range caches {
+		if err := cache.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The gc tasks should be stopped after closing.
+	time.Sleep(10 * time.Millisecond)
+
+	if got := runtime.NumGoroutine(); got > goroutines {
+		t.Fatalf("got %d > goroutines %d", got, goroutines)
+	}
+}
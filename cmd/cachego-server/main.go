@@ -0,0 +1,68 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command cachego-server serves a cache over the redis resp2 protocol, so it can be used by redis-cli or non-Go sidecars.
+//
+//	cachego-server -addr 127.0.0.1:6379 -shardings 16 -max-entries 100000
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+	"github.com/FishGoddess/cachego/pkg/resp"
+)
+
+func main() {
+	address := flag.String("addr", "127.0.0.1:6379", "the address to listen on")
+	shardings := flag.Int("shardings", 0, "the count of cache shardings, and 0 means no sharding")
+	maxEntries := flag.Int("max-entries", 100000, "the max entries of cache, and a value <= 0 means no limit")
+	gc := flag.Duration("gc", 10*time.Minute, "the duration of cleaning expired entries, and 0 means no gc")
+	flag.Parse()
+
+	opts := []cachego.Option{cachego.WithCacheName("cachego-server"), cachego.WithMaxEntries(*maxEntries), cachego.WithGC(*gc)}
+	if *shardings > 0 {
+		opts = append(opts, cachego.WithShardings(*shardings))
+	}
+
+	cache, reporter := cachego.NewCacheWithReport(opts...)
+	server := resp.NewServer(cache, reporter)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-signals
+		server.Close()
+	}()
+
+	log.Printf("cachego-server is serving on %s", *address)
+
+	// Don't use log.Fatal here, or cache won't be closed.
+	err := server.ListenAndServe(*address)
+	cache.Close()
+
+	if !errors.Is(err, resp.ErrServerClosed) {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	log.Println("cachego-server is closed")
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Encoder encodes entries to somewhere.
+type Encoder interface {
+	// Encode encodes an entry and returns an error if failed.
+	Encode(entry *DumpEntry) error
+}
+
+// Decoder decodes entries from somewhere.
+type Decoder interface {
+	// Decode decodes an entry and returns an error if failed.
+	// It should return io.EOF if there are no more entries.
+	Decode(entry *DumpEntry) error
+}
+
+// Codec creates encoders and decoders for dumping and restoring cache.
+// Implement it if you want to use a custom format or handle custom value types.
+type Codec interface {
+	// NewEncoder creates an encoder writing to w.
+	NewEncoder(w io.Writer) Encoder
+
+	// NewDecoder creates a decoder reading from r.
+	NewDecoder(r io.Reader) Decoder
+}
+
+// GobCodec is a codec using gob.
+// Notice that you should register the concrete types of values by gob.Register before using it.
+type GobCodec struct{}
+
+// NewEncoder creates a gob encoder writing to w.
+func (GobCodec) NewEncoder(w io.Writer) Encoder {
+	return gobEncoder{encoder: gob.NewEncoder(w)}
+}
+
+// NewDecoder creates a gob decoder reading from r.
+func (GobCodec) NewDecoder(r io.Reader) Decoder {
+	return gobDecoder{decoder: gob.NewDecoder(r)}
+}
+
+type gobEncoder struct {
+	encoder *gob.Encoder
+}
+
+func (ge gobEncoder) Encode(entry *DumpEntry) error {
+	return ge.encoder.Encode(entry)
+}
+
+type gobDecoder struct {
+	decoder *gob.Decoder
+}
+
+func (gd gobDecoder) Decode(entry *DumpEntry) error {
+	return gd.decoder.Decode(entry)
+}
+
+// JSONCodec is a codec using json.
+// By default, values are decoded as what json.Unmarshal does to an interface{}, such as float64 for numbers.
+// Set UnmarshalValue if you want to decode values to your own types.
+type JSONCodec struct {
+	// UnmarshalValue unmarshals the value of key from data.
+	UnmarshalValue func(key string, data []byte) (value interface{}, err error)
+}
+
+// NewEncoder creates a json encoder writing to w.
+func (JSONCodec) NewEncoder(w io.Writer) Encoder {
+	return jsonEncoder{encoder: json.NewEncoder(w)}
+}
+
+// NewDecoder creates a json decoder reading from r.
+func (jc JSONCodec) NewDecoder(r io.Reader) Decoder {
+	return jsonDecoder{decoder: json.NewDecoder(r), unmarshalValue: jc.UnmarshalValue}
+}
+
+type jsonEntry struct {
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+	TTL    time.Duration   `json:"ttl"`
+	Weight uint64          `json:"weight,omitempty"`
+}
+
+type jsonEncoder struct {
+	encoder *json.Encoder
+}
+
+func (je jsonEncoder) Encode(entry *DumpEntry) error {
+	value, err := json.Marshal(entry.Value)
+	if err != nil {
+		return err
+	}
+
+	encoded := jsonEntry{
+		Key:    entry.Key,
+		Value:  value,
+		TTL:    entry.TTL,
+		Weight: entry.Weight,
+	}
+
+	return je.encoder.Encode(&encoded)
+}
+
+type jsonDecoder struct {
+	decoder        *json.Decoder
+	unmarshalValue func(key string, data []byte) (value interface{}, err error)
+}
+
+func (jd jsonDecoder) Decode(entry *DumpEntry) error {
+	var decoded jsonEntry
+	if err := jd.decoder.Decode(&decoded); err != nil {
+		return err
+	}
+
+	entry.Key = decoded.Key
+	entry.TTL = decoded.TTL
+	entry.Weight = decoded.Weight
+
+	if jd.unmarshalValue != nil {
+		value, err := jd.unmarshalValue(decoded.Key, decoded.Value)
+		if err != nil {
+			return err
+		}
+
+		entry.Value = value
+		return nil
+	}
+
+	entry.Value = nil
+	return json.Unmarshal(decoded.Value, &entry.Value)
+}
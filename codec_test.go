@@ -0,0 +1,110 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+)
+
+type testCodecValue struct {
+	Name string
+	Age  int
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGobCodec$
+func TestGobCodec(t *testing.T) {
+	codec := GobCodec{}
+	buffer := bytes.NewBuffer(nil)
+
+	entries := []DumpEntry{
+		{Key: "key1", Value: "value", TTL: time.Second, Weight: 1},
+		{Key: "key2", Value: 123, TTL: NoTTL},
+	}
+
+	encoder := codec.NewEncoder(buffer)
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	decoder := codec.NewDecoder(buffer)
+	for _, expect := range entries {
+		var entry DumpEntry
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+
+		if entry != expect {
+			t.Fatalf("entry %+v != expect %+v", entry, expect)
+		}
+	}
+
+	var entry DumpEntry
+	if err := decoder.Decode(&entry); err != io.EOF {
+		t.Fatalf("err %+v != io.EOF", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestJSONCodec$
+func TestJSONCodec(t *testing.T) {
+	codec := JSONCodec{
+		UnmarshalValue: func(key string, data []byte) (value interface{}, err error) {
+			var v testCodecValue
+			err = json.Unmarshal(data, &v)
+			return v, err
+		},
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	expect := DumpEntry{Key: "key", Value: testCodecValue{Name: "fish", Age: 18}, TTL: time.Minute, Weight: 3}
+
+	if err := codec.NewEncoder(buffer).Encode(&expect); err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := codec.NewDecoder(buffer)
+
+	var entry DumpEntry
+	if err := decoder.Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry != expect {
+		t.Fatalf("entry %+v != expect %+v", entry, expect)
+	}
+
+	if err := decoder.Decode(&entry); err != io.EOF {
+		t.Fatalf("err %+v != io.EOF", err)
+	}
+
+	// Without UnmarshalValue, values are unmarshalled to interface{}.
+	buffer.Reset()
+	if err := codec.NewEncoder(buffer).Encode(&DumpEntry{Key: "key", Value: "value"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (JSONCodec{}).NewDecoder(buffer).Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Value.(string) != "value" {
+		t.Fatalf("entry.Value %+v != value", entry.Value)
+	}
+}
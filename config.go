@@ -14,7 +14,10 @@
 
 package cachego
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type config struct {
 	cacheName    string
@@ -25,6 +28,35 @@ type config struct {
 
 	maxScans   int
 	maxEntries int
+	maxCost    int64
+	weigher    func(key string, value interface{}) int64
+
+	rangeInEvictionOrder bool
+	expirationIndex      bool
+
+	slidingExpiration bool
+	maxLifetime       time.Duration
+
+	refreshWindow time.Duration
+	maxStale      time.Duration
+
+	loadTimeout         time.Duration
+	cancelAbandonedLoad bool
+
+	negativeTTL time.Duration
+	isNegative  func(err error) bool
+
+	store               Store
+	storeMode           StoreMode
+	storeTTL            time.Duration
+	writeBehindInterval time.Duration
+	writeBehindBatch    int
+	writeBehindRetries  int
+	writeBehindBackoff  time.Duration
+	onStoreError        func(key string, err error)
+
+	invalidator       Invalidator
+	onInvalidateError func(key string, err error)
 
 	now  func() int64
 	hash func(key string) int
@@ -38,6 +70,8 @@ type config struct {
 	reportHit    func(reporter *Reporter, key string, value interface{})
 	reportGC     func(reporter *Reporter, cost time.Duration, cleans int)
 	reportLoad   func(reporter *Reporter, key string, value interface{}, ttl time.Duration, err error)
+
+	onEvicted func(key string, value interface{}, cause RemovalCause)
 }
 
 func newDefaultConfig() *config {
@@ -49,6 +83,12 @@ func newDefaultConfig() *config {
 		gcDuration:   10 * time.Minute,
 		maxScans:     10000,
 		maxEntries:   100000,
+
+		writeBehindInterval: time.Second,
+		writeBehindBatch:    100,
+		writeBehindRetries:  3,
+		writeBehindBackoff:  100 * time.Millisecond,
+
 		now:          now,
 		hash:         hash,
 		recordMissed: true,
@@ -57,3 +97,95 @@ func newDefaultConfig() *config {
 		recordLoad:   true,
 	}
 }
+
+// weigh returns the cost of key and value computed by weigher, and it's 1 if weigher is nil.
+// Negative entries are internal, so they cost negativeCost without calling weigher.
+func (c *config) weigh(key string, value interface{}) int64 {
+	if c.weigher == nil {
+		return 1
+	}
+
+	if _, ok := value.(*negativeEntry); ok {
+		return negativeCost
+	}
+
+	return c.weigher(key, value)
+}
+
+// slidingEntry is an entry which can slide its expiration.
+type slidingEntry interface {
+	setupSliding(ttl time.Duration, maxLifetime time.Duration)
+}
+
+// setupSliding makes entry slide its expiration with ttl if sliding expiration is enabled.
+func (c *config) setupSliding(entry slidingEntry, ttl time.Duration) {
+	if c.slidingExpiration {
+		entry.setupSliding(ttl, c.maxLifetime)
+	}
+}
+
+// notifyEvicted calls onEvicted with key, value and cause if onEvicted exists.
+// Negative entries are internal, so they won't be notified.
+func (c *config) notifyEvicted(key string, value interface{}, cause RemovalCause) {
+	if c.onEvicted == nil {
+		return
+	}
+
+	if _, ok := value.(*negativeEntry); ok {
+		return
+	}
+
+	c.onEvicted(key, value, cause)
+}
+
+// weighTyped returns the cost of key and value in typed caches like weigh.
+// Keys are passed to weigher in their string forms.
+func weighTyped[K comparable, V any](c *config, key K, value V) int64 {
+	if c.weigher == nil {
+		return 1
+	}
+
+	if _, ok := any(value).(*negativeEntry); ok {
+		return negativeCost
+	}
+
+	return c.weigher(keyString(key), value)
+}
+
+// notifyTypedEvicted calls onEvicted with key, value and cause in typed caches like notifyEvicted.
+// Keys are passed to onEvicted in their string forms.
+func notifyTypedEvicted[K comparable, V any](c *config, key K, value V, cause RemovalCause) {
+	if c.onEvicted != nil {
+		c.notifyEvicted(keyString(key), value, cause)
+	}
+}
+
+// notifyInvalidateError calls onInvalidateError with key and err if onInvalidateError exists.
+func (c *config) notifyInvalidateError(key string, err error) {
+	if c.onInvalidateError != nil {
+		c.onInvalidateError(key, err)
+	}
+}
+
+// notifyStoreError calls onStoreError with key and err if onStoreError exists.
+func (c *config) notifyStoreError(key string, err error) {
+	if c.onStoreError != nil {
+		c.onStoreError(key, err)
+	}
+}
+
+// checkEntriesBounded panics if conf has options which caches bounded by max entries don't support.
+// Tinylfu, arc, sieve and s3fifo caches size their lists by max entries and don't slide or index expirations of entries.
+func (c *config) checkEntriesBounded(cacheType CacheType) {
+	if c.maxEntries <= 0 {
+		panic(fmt.Sprintf("cachego: %s cache must specify max entries even if max cost is specified", cacheType))
+	}
+
+	if c.slidingExpiration {
+		panic(fmt.Sprintf("cachego: %s cache doesn't support sliding expiration", cacheType))
+	}
+
+	if c.expirationIndex {
+		panic(fmt.Sprintf("cachego: %s cache doesn't support expiration index", cacheType))
+	}
+}
@@ -44,6 +44,94 @@ func isConfigEquals(conf1 *config, conf2 *config) bool {
 		return false
 	}
 
+	if conf1.maxCost != conf2.maxCost {
+		return false
+	}
+
+	if fmt.Sprintf("%p", conf1.weigher) != fmt.Sprintf("%p", conf2.weigher) {
+		return false
+	}
+
+	if conf1.rangeInEvictionOrder != conf2.rangeInEvictionOrder {
+		return false
+	}
+
+	if conf1.expirationIndex != conf2.expirationIndex {
+		return false
+	}
+
+	if conf1.slidingExpiration != conf2.slidingExpiration {
+		return false
+	}
+
+	if conf1.maxLifetime != conf2.maxLifetime {
+		return false
+	}
+
+	if conf1.refreshWindow != conf2.refreshWindow {
+		return false
+	}
+
+	if conf1.maxStale != conf2.maxStale {
+		return false
+	}
+
+	if conf1.loadTimeout != conf2.loadTimeout {
+		return false
+	}
+
+	if conf1.cancelAbandonedLoad != conf2.cancelAbandonedLoad {
+		return false
+	}
+
+	if conf1.negativeTTL != conf2.negativeTTL {
+		return false
+	}
+
+	if fmt.Sprintf("%p", conf1.isNegative) != fmt.Sprintf("%p", conf2.isNegative) {
+		return false
+	}
+
+	if conf1.store != conf2.store {
+		return false
+	}
+
+	if conf1.storeMode != conf2.storeMode {
+		return false
+	}
+
+	if conf1.storeTTL != conf2.storeTTL {
+		return false
+	}
+
+	if conf1.writeBehindInterval != conf2.writeBehindInterval {
+		return false
+	}
+
+	if conf1.writeBehindBatch != conf2.writeBehindBatch {
+		return false
+	}
+
+	if conf1.writeBehindRetries != conf2.writeBehindRetries {
+		return false
+	}
+
+	if conf1.writeBehindBackoff != conf2.writeBehindBackoff {
+		return false
+	}
+
+	if fmt.Sprintf("%p", conf1.onStoreError) != fmt.Sprintf("%p", conf2.onStoreError) {
+		return false
+	}
+
+	if conf1.invalidator != conf2.invalidator {
+		return false
+	}
+
+	if fmt.Sprintf("%p", conf1.onInvalidateError) != fmt.Sprintf("%p", conf2.onInvalidateError) {
+		return false
+	}
+
 	if fmt.Sprintf("%p", conf1.now) != fmt.Sprintf("%p", conf2.now) {
 		return false
 	}
@@ -84,6 +172,10 @@ func isConfigEquals(conf1 *config, conf2 *config) bool {
 		return false
 	}
 
+	if fmt.Sprintf("%p", conf1.onEvicted) != fmt.Sprintf("%p", conf2.onEvicted) {
+		return false
+	}
+
 	return true
 }
 
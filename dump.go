@@ -0,0 +1,130 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// DumpEntry is an entry dumped from cache.
+type DumpEntry struct {
+	// Key is the key of entry.
+	Key string
+
+	// Value is the value of entry.
+	Value interface{}
+
+	// TTL is the remaining ttl of entry when dumping, and NoTTL means it's never expired.
+	TTL time.Duration
+
+	// Weight is the frequency of entry in lfu cache, and it's zero in other caches.
+	Weight uint64
+}
+
+// dumpableCache is a cache which can dump its entries.
+type dumpableCache interface {
+	// dump returns all unexpired entries in the order of restoring.
+	// The most valuable entries should be the last ones, so restoring them one by one keeps their orders.
+	dump() []DumpEntry
+}
+
+// restorableCache is a cache which can restore entries with their weights.
+type restorableCache interface {
+	// restore restores an entry to cache.
+	restore(entry *DumpEntry)
+}
+
+func newDumpEntry(entry *entry, now int64) (dumpEntry DumpEntry, ok bool) {
+	if entry.expired(now) {
+		return dumpEntry, false
+	}
+
+	dumpEntry = DumpEntry{
+		Key:   entry.key,
+		Value: entry.value,
+		TTL:   entry.ttl(now),
+	}
+
+	return dumpEntry, true
+}
+
+// rangeEntries calls fn with each entry until fn returns false.
+func rangeEntries(entries []DumpEntry, fn func(key string, value interface{}, ttl time.Duration) bool) {
+	for _, entry := range entries {
+		if !fn(entry.Key, entry.Value, entry.TTL) {
+			return
+		}
+	}
+}
+
+// keysOf returns the keys of entries.
+func keysOf(entries []DumpEntry) []string {
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+
+	return keys
+}
+
+// Dump dumps all unexpired entries in cache to w with codec.
+// It keeps the key, value and remaining ttl of entries, and keeps the orders of entries in lru and lfu caches.
+// Returns an error if cache doesn't support dumping or encoding failed.
+func Dump(cache Cache, w io.Writer, codec Codec) error {
+	dc, ok := cache.(dumpableCache)
+	if !ok {
+		return errors.New("cachego: cache doesn't support dumping")
+	}
+
+	encoder := codec.NewEncoder(w)
+	entries := dc.dump()
+
+	for i := range entries {
+		if err := encoder.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore restores entries from r with codec to cache.
+// Entries are set to cache one by one in the order of dumping.
+// Returns an error if decoding failed.
+func Restore(cache Cache, r io.Reader, codec Codec) error {
+	decoder := codec.NewDecoder(r)
+	rc, restorable := cache.(restorableCache)
+
+	for {
+		var entry DumpEntry
+
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if restorable {
+			rc.restore(&entry)
+		} else {
+			cache.Set(entry.Key, entry.Value, entry.TTL)
+		}
+	}
+}
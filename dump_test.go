@@ -0,0 +1,193 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type testUndumpableCache struct {
+	Cache
+}
+
+func testDumpAndRestore(t *testing.T, cache Cache, newCache func() Cache, codec Codec) {
+	for i := 0; i < maxTestEntries/2; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	cache.Set("ttl", "ttl", time.Hour)
+	cache.Set("expired", "expired", time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	buffer := bytes.NewBuffer(nil)
+	if err := Dump(cache, buffer, codec); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := newCache()
+	if err := Restore(restored, buffer, codec); err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.Size() != maxTestEntries/2+1 {
+		t.Fatalf("restored.Size() %d != %d", restored.Size(), maxTestEntries/2+1)
+	}
+
+	for i := 0; i < maxTestEntries/2; i++ {
+		data := strconv.Itoa(i)
+
+		value, ok := restored.Get(data)
+		if !ok || value.(string) != data {
+			t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+		}
+	}
+
+	if value, ok := restored.Get("ttl"); !ok || value.(string) != "ttl" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	if value, ok := restored.Get("expired"); ok {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestDumpAndRestore$
+func TestDumpAndRestore(t *testing.T) {
+	codecs := map[string]Codec{
+		"gob":  GobCodec{},
+		"json": JSONCodec{},
+	}
+
+	for cacheType := range newCaches {
+		cacheType := cacheType
+		withCacheType := func(conf *config) {
+			conf.cacheType = cacheType
+		}
+
+		for codecName, codec := range codecs {
+			newCache := func() Cache {
+				return NewCache(withCacheType, WithMaxEntries(maxTestEntries), WithGC(0))
+			}
+
+			newShardingCache := func() Cache {
+				return NewCache(withCacheType, WithMaxEntries(maxTestEntries), WithShardings(testShardings), WithGC(0))
+			}
+
+			newReportableCache := func() Cache {
+				cache, _ := NewCacheWithReport(withCacheType, WithMaxEntries(maxTestEntries), WithGC(0))
+				return cache
+			}
+
+			t.Run(string(cacheType)+"-"+codecName, func(t *testing.T) {
+				testDumpAndRestore(t, newCache(), newCache, codec)
+			})
+
+			t.Run(string(cacheType)+"-sharding-"+codecName, func(t *testing.T) {
+				testDumpAndRestore(t, newShardingCache(), newShardingCache, codec)
+			})
+
+			t.Run(string(cacheType)+"-report-"+codecName, func(t *testing.T) {
+				testDumpAndRestore(t, newReportableCache(), newReportableCache, codec)
+			})
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestDumpUndumpable$
+func TestDumpUndumpable(t *testing.T) {
+	cache := testUndumpableCache{Cache: NewCache()}
+
+	if err := Dump(cache, bytes.NewBuffer(nil), GobCodec{}); err == nil {
+		t.Fatal("dumping an undumpable cache should return an error")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestDumpLRUOrder$
+func TestDumpLRUOrder(t *testing.T) {
+	cache := newTestLRUCache()
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	// Now 0 is the most recently used entry.
+	cache.Get("0")
+
+	buffer := bytes.NewBuffer(nil)
+	if err := Dump(cache, buffer, GobCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := newTestLRUCache()
+	if err := Restore(restored, buffer, GobCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The least recently used entry should be 1, so it will be evicted first.
+	evictedValue := restored.Set("new", "new", NoTTL)
+	if evictedValue.(string) != "1" {
+		t.Fatalf("evictedValue %+v != 1", evictedValue)
+	}
+
+	evictedValue = restored.Set("new2", "new2", NoTTL)
+	if evictedValue.(string) != "2" {
+		t.Fatalf("evictedValue %+v != 2", evictedValue)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestDumpLFUWeight$
+func TestDumpLFUWeight(t *testing.T) {
+	cache := newTestLFUCache()
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+
+		for j := 0; j < i; j++ {
+			cache.Get(data)
+		}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	if err := Dump(cache, buffer, JSONCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := newTestLFUCache()
+	if err := Restore(restored, buffer, JSONCodec{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, item := range restored.itemMap {
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if item.Weight() != uint64(i) {
+			t.Fatalf("item.Weight() %d != %d", item.Weight(), i)
+		}
+	}
+
+	evictedValue := restored.Set("new", "new", NoTTL)
+	if evictedValue.(string) != "0" {
+		t.Fatalf("evictedValue %+v != 0", evictedValue)
+	}
+}
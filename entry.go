@@ -14,19 +14,38 @@
 
 package cachego
 
-import "time"
+import (
+	"sync/atomic"
+	"time"
+)
 
-type entry struct {
-	key   string
-	value interface{}
+type typedEntry[K comparable, V any] struct {
+	key   K
+	value V
 
 	// Time in nanosecond, valid util 2262 year (enough, right?)
 	expiration int64
 	now        func() int64
+
+	// cost is computed by weigher when setting entry to a cache bounded by cost.
+	cost int64
+
+	// sliding is a flag checking if entry extends its expiration by slidingTTL after each access.
+	sliding    bool
+	slidingTTL time.Duration
+
+	// deadline is the max expiration of a sliding entry, and 0 means no limit.
+	deadline int64
 }
 
+type entry = typedEntry[string, interface{}]
+
 func newEntry(key string, value interface{}, ttl time.Duration, now func() int64) *entry {
-	e := &entry{
+	return newTypedEntry(key, value, ttl, now)
+}
+
+func newTypedEntry[K comparable, V any](key K, value V, ttl time.Duration, now func() int64) *typedEntry[K, V] {
+	e := &typedEntry[K, V]{
 		now: now,
 	}
 
@@ -34,20 +53,78 @@ func newEntry(key string, value interface{}, ttl time.Duration, now func() int64
 	return e
 }
 
-func (e *entry) setup(key string, value interface{}, ttl time.Duration) {
+func (e *typedEntry[K, V]) setup(key K, value V, ttl time.Duration) {
 	e.key = key
 	e.value = value
-	e.expiration = 0
+	e.sliding = false
+	e.slidingTTL = 0
+	e.deadline = 0
+	e.expire(ttl)
+}
 
+// setupSliding makes entry extend its expiration by ttl after each access.
+// The expiration won't exceed maxLifetime from now if maxLifetime > 0, and entries without ttl are never expired.
+func (e *typedEntry[K, V]) setupSliding(ttl time.Duration, maxLifetime time.Duration) {
+	e.sliding = true
+	e.deadline = 0
+
+	if ttl > 0 && maxLifetime > 0 {
+		e.deadline = e.now() + maxLifetime.Nanoseconds()
+	}
+
+	e.expire(ttl)
+}
+
+// expire resets the expiration of entry with ttl, and NoTTL means entry is never expired.
+// A sliding entry will slide by ttl after resetting, and its expiration won't exceed its deadline.
+func (e *typedEntry[K, V]) expire(ttl time.Duration) {
+	expiration := int64(0)
 	if ttl > 0 {
-		e.expiration = e.now() + ttl.Nanoseconds()
+		expiration = e.now() + ttl.Nanoseconds()
+	}
+
+	if e.deadline > 0 && (expiration <= 0 || expiration > e.deadline) {
+		expiration = e.deadline
 	}
+
+	if e.sliding {
+		e.slidingTTL = ttl
+	}
+
+	atomic.StoreInt64(&e.expiration, expiration)
+}
+
+// slide extends the expiration of a sliding entry by its sliding ttl.
+// It only changes the expiration atomically, so it can be called in read lock.
+func (e *typedEntry[K, V]) slide() {
+	if !e.sliding || e.slidingTTL <= 0 {
+		return
+	}
+
+	expiration := e.now() + e.slidingTTL.Nanoseconds()
+	if e.deadline > 0 && expiration > e.deadline {
+		expiration = e.deadline
+	}
+
+	atomic.StoreInt64(&e.expiration, expiration)
+}
+
+// ttl returns the remaining ttl of entry at now, and NoTTL means entry is never expired.
+// Keep at least one nanosecond so an entry with expiration won't become a NoTTL one.
+func (e *typedEntry[K, V]) ttl(now int64) time.Duration {
+	expiration := atomic.LoadInt64(&e.expiration)
+	if expiration <= 0 {
+		return NoTTL
+	}
+
+	return max(time.Duration(expiration-now), time.Nanosecond)
 }
 
-func (e *entry) expired(now int64) bool {
-	if now > 0 {
-		return e.expiration > 0 && e.expiration < now
+func (e *typedEntry[K, V]) expired(now int64) bool {
+	if now <= 0 {
+		now = e.now()
 	}
 
-	return e.expiration > 0 && e.expiration < e.now()
+	expiration := atomic.LoadInt64(&e.expiration)
+	return expiration > 0 && expiration < now
 }
@@ -125,3 +125,47 @@ func TestEntryExpired(t *testing.T) {
 		t.Fatal("e should be expired!")
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestEntrySlide$
+func TestEntrySlide(t *testing.T) {
+	clock := int64(1000)
+	now := func() int64 {
+		return clock
+	}
+
+	e := newEntry("key", "value", 10, now)
+	e.setupSliding(10, 25)
+
+	if e.expiration != 1010 || e.deadline != 1025 {
+		t.Fatalf("e.expiration %d, e.deadline %d is wrong", e.expiration, e.deadline)
+	}
+
+	clock = 1005
+	e.slide()
+
+	if e.expiration != 1015 {
+		t.Fatalf("e.expiration %d != 1015", e.expiration)
+	}
+
+	// The expiration shouldn't exceed the deadline.
+	clock = 1020
+	e.slide()
+
+	if e.expiration != 1025 {
+		t.Fatalf("e.expiration %d != 1025", e.expiration)
+	}
+
+	e.expire(NoTTL)
+	if e.expiration != 1025 || e.slidingTTL != NoTTL {
+		t.Fatalf("e.expiration %d, e.slidingTTL %d is wrong", e.expiration, e.slidingTTL)
+	}
+
+	// Entries not sliding shouldn't slide.
+	e.setup("key", "value", 10)
+	clock = 1030
+	e.slide()
+
+	if e.expiration != 1030 || e.deadline != 0 {
+		t.Fatalf("e.expiration %d, e.deadline %d is wrong", e.expiration, e.deadline)
+	}
+}
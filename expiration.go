@@ -0,0 +1,101 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import "github.com/FishGoddess/cachego/pkg/heap"
+
+// typedExpirationIndex indexes keys by their expirations in a min-heap.
+// Expired keys can be found from the top of heap without scanning all entries.
+// Keys without expiration aren't indexed.
+type typedExpirationIndex[K comparable] struct {
+	itemMap  map[K]*heap.Item
+	itemHeap *heap.Heap
+}
+
+type expirationIndex = typedExpirationIndex[string]
+
+// newExpirationIndex returns an expiration index if it's enabled in conf, or returns nil.
+func newExpirationIndex(conf *config) *expirationIndex {
+	return newTypedExpirationIndex[string](conf)
+}
+
+// newTypedExpirationIndex returns a typed expiration index if it's enabled in conf, or returns nil.
+func newTypedExpirationIndex[K comparable](conf *config) *typedExpirationIndex[K] {
+	if !conf.expirationIndex {
+		return nil
+	}
+
+	index := &typedExpirationIndex[K]{
+		itemMap:  make(map[K]*heap.Item, mapInitialCap),
+		itemHeap: heap.New(sliceInitialCap),
+	}
+
+	return index
+}
+
+// update updates the expiration of key, and removes key from index if expiration is 0.
+func (ei *typedExpirationIndex[K]) update(key K, expiration int64) {
+	item, ok := ei.itemMap[key]
+	if expiration <= 0 {
+		if ok {
+			delete(ei.itemMap, key)
+			ei.itemHeap.Remove(item)
+		}
+
+		return
+	}
+
+	if ok {
+		item.Adjust(uint64(expiration))
+		return
+	}
+
+	ei.itemMap[key] = ei.itemHeap.Push(uint64(expiration), key)
+}
+
+// remove removes key from index.
+func (ei *typedExpirationIndex[K]) remove(key K) {
+	if item, ok := ei.itemMap[key]; ok {
+		delete(ei.itemMap, key)
+		ei.itemHeap.Remove(item)
+	}
+}
+
+// clean pops all keys expired before now and calls remove with each of them.
+// A key may not be removed if its expiration slides after indexing, and it should be updated to index again.
+// It returns the count of keys removed, so it costs O(expired) instead of scanning all keys.
+func (ei *typedExpirationIndex[K]) clean(now int64, remove func(key K) (removed bool)) (cleans int) {
+	for {
+		item := ei.itemHeap.Peek()
+		if item == nil || int64(item.Weight()) >= now {
+			return cleans
+		}
+
+		ei.itemHeap.Pop()
+
+		key := item.Value.(K)
+		delete(ei.itemMap, key)
+
+		if remove(key) {
+			cleans++
+		}
+	}
+}
+
+// reset resets index to initial status.
+func (ei *typedExpirationIndex[K]) reset() {
+	ei.itemMap = make(map[K]*heap.Item, mapInitialCap)
+	ei.itemHeap = heap.New(sliceInitialCap)
+}
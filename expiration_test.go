@@ -0,0 +1,111 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestExpirationIndex$
+func TestExpirationIndex(t *testing.T) {
+	if index := newExpirationIndex(newDefaultConfig()); index != nil {
+		t.Fatalf("index %+v should be nil", index)
+	}
+
+	conf := newDefaultConfig()
+	conf.expirationIndex = true
+
+	index := newExpirationIndex(conf)
+	for i := 1; i <= 10; i++ {
+		index.update(strconv.Itoa(i), int64(i))
+	}
+
+	// Keys without expiration shouldn't be indexed.
+	index.update("no-ttl", 0)
+	index.update("10", 0)
+	index.update("9", 100)
+	index.remove("8")
+
+	if index.itemHeap.Size() != 8 || len(index.itemMap) != 8 {
+		t.Fatalf("index.itemHeap.Size() %d, len(index.itemMap) %d is wrong", index.itemHeap.Size(), len(index.itemMap))
+	}
+
+	var keys []string
+	cleans := index.clean(6, func(key string) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	if cleans != 5 || len(keys) != 5 {
+		t.Fatalf("cleans %d, len(keys) %d is wrong", cleans, len(keys))
+	}
+
+	// Keys should be cleaned in the order of their expirations.
+	for i, key := range keys {
+		if want := strconv.Itoa(i + 1); key != want {
+			t.Fatalf("key %s != %s", key, want)
+		}
+	}
+
+	// Keys not removed shouldn't be counted.
+	cleans = index.clean(101, func(key string) bool {
+		return key != "9"
+	})
+
+	if cleans != 2 {
+		t.Fatalf("cleans %d != 2", cleans)
+	}
+
+	index.update("key", 1)
+	index.reset()
+
+	if index.itemHeap.Size() != 0 || len(index.itemMap) != 0 {
+		t.Fatalf("index.itemHeap.Size() %d, len(index.itemMap) %d is wrong", index.itemHeap.Size(), len(index.itemMap))
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestExpirationIndexGC$
+func TestExpirationIndexGC(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxEntries = 0
+	conf.maxScans = 1
+	conf.expirationIndex = true
+
+	cache := newStandardCache(conf).(*standardCache)
+	for i := 0; i < 100; i++ {
+		key := strconv.Itoa(i)
+		cache.Set(key, i, time.Millisecond)
+		cache.Set(key+"-no-ttl", i, NoTTL)
+	}
+
+	// Replacing an entry without ttl should remove it from index.
+	cache.Set("0", 0, NoTTL)
+	time.Sleep(2 * time.Millisecond)
+
+	// Max scans is ignored, so all expired entries should be cleaned at once.
+	if cleans := cache.GC(); cleans != 99 {
+		t.Fatalf("cleans %d != 99", cleans)
+	}
+
+	if cache.Size() != 101 {
+		t.Fatalf("cache.Size() %d != 101", cache.Size())
+	}
+
+	if cleans := cache.GC(); cleans != 0 {
+		t.Fatalf("cleans %d != 0", cleans)
+	}
+}
@@ -0,0 +1,442 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package http provides an http handler for debugging caches, which shows their stats and manages their keys.
+package http
+
+import (
+	"container/heap"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/FishGoddess/cachego"
+)
+
+const (
+	// defaultLimit is the default count of keys in a page.
+	defaultLimit = 100
+
+	// maxLimit is the max count of keys in a page.
+	maxLimit = 1000
+
+	// maxBodySize is the max size of a value in setting requests.
+	maxBodySize = 4 * 1024 * 1024
+)
+
+// Stats is the stats of a cache.
+// Fields from reporter are zero values if cache is registered without a reporter.
+type Stats struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type,omitempty"`
+	Shardings    int     `json:"shardings"`
+	GC           string  `json:"gc,omitempty"`
+	Size         int     `json:"size"`
+	Cost         int64   `json:"cost"`
+	Hits         uint64  `json:"hits"`
+	Misses       uint64  `json:"misses"`
+	NegativeHits uint64  `json:"negative_hits"`
+	HitRate      float64 `json:"hit_rate"`
+	GCs          uint64  `json:"gcs"`
+	Loads        uint64  `json:"loads"`
+}
+
+// Entry is a key with its value and remaining ttl in seconds, and a zero ttl means key is never expired.
+type Entry struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+	Type  string      `json:"type"`
+	TTL   float64     `json:"ttl"`
+}
+
+// Page is a page of sorted keys, and an empty next cursor means there are no more keys.
+type Page struct {
+	Keys       []string `json:"keys"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+// keyHeap is a max heap of keys, so the largest key is replaced first when selecting the smallest keys.
+type keyHeap []string
+
+func (kh keyHeap) Len() int {
+	return len(kh)
+}
+
+func (kh keyHeap) Less(i, j int) bool {
+	return kh[i] > kh[j]
+}
+
+func (kh keyHeap) Swap(i, j int) {
+	kh[i], kh[j] = kh[j], kh[i]
+}
+
+func (kh *keyHeap) Push(x interface{}) {
+	*kh = append(*kh, x.(string))
+}
+
+func (kh *keyHeap) Pop() interface{} {
+	old := *kh
+	key := old[len(old)-1]
+	*kh = old[:len(old)-1]
+	return key
+}
+
+type registeredCache struct {
+	cache    cachego.Cache
+	reporter *cachego.Reporter
+}
+
+// Handler is an http handler for debugging registered caches, which replies in json.
+// Mount it with http.StripPrefix, and paths are relative to the mounted prefix:
+//
+//	GET    /                       lists the stats of all caches
+//	GET    /{cache}                shows the stats of cache
+//	GET    /{cache}/keys           pages through sorted keys with ?cursor=&limit=100&prefix=
+//	GET    /{cache}/keys/{key}     looks up key
+//	PUT    /{cache}/keys/{key}     sets key to request body with ?ttl=10s
+//	DELETE /{cache}/keys/{key}     deletes key
+//	POST   /{cache}/gc             runs gc of cache
+//	POST   /{cache}/reset          resets cache
+//
+// Cache names and keys in paths should be escaped by url.PathEscape.
+type Handler struct {
+	caches map[string]registeredCache
+	lock   sync.RWMutex
+
+	readOnly  bool
+	auth      func(r *http.Request) error
+	writeAuth func(r *http.Request) error
+}
+
+// NewHandler creates a handler with options.
+// See WithReadOnly, WithAuth and WithWriteAuth.
+func NewHandler(opts ...Option) *Handler {
+	h := &Handler{
+		caches: make(map[string]registeredCache),
+	}
+
+	applyOptions(h, opts)
+	return h
+}
+
+// Register registers cache with name to handler, and replaces the registered one with the same name.
+// Reporter is used for showing stats and can be nil, see cachego.NewCacheWithReport.
+func (h *Handler) Register(name string, cache cachego.Cache, reporter *cachego.Reporter) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.caches[name] = registeredCache{cache: cache, reporter: reporter}
+}
+
+// Unregister unregisters the cache with name from handler.
+func (h *Handler) Unregister(name string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.caches, name)
+}
+
+func (h *Handler) cacheOf(name string) (registeredCache, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	rc, ok := h.caches[name]
+	return rc, ok
+}
+
+func (h *Handler) names() []string {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	names := make([]string, 0, len(h.caches))
+	for name := range h.caches {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+// split splits the escaped path into the cache name, the action and the key.
+func split(path string) (name string, action string, key string, err error) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return "", "", "", nil
+	}
+
+	segments := strings.SplitN(path, "/", 3)
+	if name, err = url.PathUnescape(segments[0]); err != nil {
+		return "", "", "", err
+	}
+
+	if len(segments) > 1 {
+		action = segments[1]
+	}
+
+	if len(segments) > 2 {
+		if key, err = url.PathUnescape(segments[2]); err != nil {
+			return "", "", "", err
+		}
+	}
+
+	return name, action, key, nil
+}
+
+// ServeHTTP serves requests of debugging caches.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.auth != nil {
+		if err := h.auth(r); err != nil {
+			writeError(w, http.StatusUnauthorized, "%s", err.Error())
+			return
+		}
+	}
+
+	name, action, key, err := split(r.URL.EscapedPath())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid path: %s", err.Error())
+		return
+	}
+
+	if name == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method %s isn't allowed", r.Method)
+			return
+		}
+
+		h.list(w, r)
+		return
+	}
+
+	rc, ok := h.cacheOf(name)
+	if !ok {
+		writeError(w, http.StatusNotFound, "cache %s isn't registered", name)
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, statsOf(name, rc))
+	case action == "keys" && key == "" && r.Method == http.MethodGet:
+		h.page(w, r, rc)
+	case action == "keys" && key != "" && r.Method == http.MethodGet:
+		h.get(w, r, rc, key)
+	case action == "keys" && key != "" && r.Method == http.MethodPut:
+		h.write(w, r, func() { h.set(w, r, rc, key) })
+	case action == "keys" && key != "" && r.Method == http.MethodDelete:
+		h.write(w, r, func() { h.delete(w, r, rc, key) })
+	case action == "gc" && r.Method == http.MethodPost:
+		h.write(w, r, func() { writeJSON(w, http.StatusOK, map[string]int{"cleans": rc.cache.GC()}) })
+	case action == "reset" && r.Method == http.MethodPost:
+		h.write(w, r, func() { rc.cache.Reset(); w.WriteHeader(http.StatusNoContent) })
+	case action == "" || action == "keys" || action == "gc" || action == "reset":
+		writeError(w, http.StatusMethodNotAllowed, "method %s isn't allowed", r.Method)
+	default:
+		writeError(w, http.StatusNotFound, "path %s isn't found", r.URL.Path)
+	}
+}
+
+// write calls fn if handler isn't read-only and request passes write auth.
+func (h *Handler) write(w http.ResponseWriter, r *http.Request, fn func()) {
+	if h.readOnly {
+		writeError(w, http.StatusForbidden, "handler is read-only")
+		return
+	}
+
+	if h.writeAuth != nil {
+		if err := h.writeAuth(r); err != nil {
+			writeError(w, http.StatusForbidden, "%s", err.Error())
+			return
+		}
+	}
+
+	fn()
+}
+
+func statsOf(name string, rc registeredCache) Stats {
+	stats := Stats{
+		Name: name,
+		Size: rc.cache.Size(),
+		Cost: rc.cache.Cost(),
+	}
+
+	if r := rc.reporter; r != nil {
+		stats.Type = r.CacheType().String()
+		stats.Shardings = r.CacheShardings()
+		stats.GC = r.CacheGC().String()
+		stats.Hits = r.CountHit()
+		stats.Misses = r.CountMissed()
+		stats.NegativeHits = r.CountNegativeHit()
+		stats.HitRate = r.HitRate()
+		stats.GCs = r.CountGC()
+		stats.Loads = r.CountLoad()
+	}
+
+	return stats
+}
+
+func (h *Handler) list(w http.ResponseWriter, r *http.Request) {
+	names := h.names()
+
+	stats := make([]Stats, 0, len(names))
+	for _, name := range names {
+		if rc, ok := h.cacheOf(name); ok {
+			stats = append(stats, statsOf(name, rc))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// page replies a page of sorted keys after cursor, and the cursor is the last key of previous page.
+// Only the smallest limit keys after cursor are kept in a heap, so keys aren't sorted all on every request.
+func (h *Handler) page(w http.ResponseWriter, r *http.Request, rc registeredCache) {
+	query := r.URL.Query()
+	cursor := query.Get("cursor")
+	prefix := query.Get("prefix")
+
+	limit := defaultLimit
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxLimit {
+			writeError(w, http.StatusBadRequest, "invalid limit %s", s)
+			return
+		}
+
+		limit = n
+	}
+
+	// Keep one more key than limit, so we know whether there are more keys.
+	selected := make(keyHeap, 0, limit+1)
+	for _, key := range rc.cache.Keys() {
+		if !strings.HasPrefix(key, prefix) || (cursor != "" && key <= cursor) {
+			continue
+		}
+
+		if len(selected) <= limit {
+			heap.Push(&selected, key)
+			continue
+		}
+
+		if key < selected[0] {
+			selected[0] = key
+			heap.Fix(&selected, 0)
+		}
+	}
+
+	keys := []string(selected)
+	sort.Strings(keys)
+
+	page := Page{Keys: keys}
+	if len(keys) > limit {
+		page.Keys = keys[:limit]
+		page.NextCursor = keys[limit-1]
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// get replies key with its value, and []byte values are replied as strings if they are valid utf8.
+// Key is looked up by cachego.Peek instead of Get, so it won't change stats, eviction order, sliding ttl or trigger a refresh.
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, rc registeredCache, key string) {
+	value, ttl, found := cachego.Peek(rc.cache, key)
+	if !found {
+		writeError(w, http.StatusNotFound, "key %s isn't found", key)
+		return
+	}
+
+	entry := Entry{Key: key, Value: value, Type: fmt.Sprintf("%T", value)}
+	if data, ok := value.([]byte); ok && utf8.Valid(data) {
+		entry.Value = string(data)
+	}
+
+	if ttl > 0 {
+		entry.TTL = ttl.Seconds()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "value of %s isn't json encodable: %s", entry.Type, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(data, '\n'))
+}
+
+// set sets key to request body with ttl in query.
+// The body is decoded as json if its content type is application/json, otherwise it's set as a string.
+func (h *Handler) set(w http.ResponseWriter, r *http.Request, rc registeredCache, key string) {
+	ttl := time.Duration(cachego.NoTTL)
+	if s := r.URL.Query().Get("ttl"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d < 0 {
+			writeError(w, http.StatusBadRequest, "invalid ttl %s", s)
+			return
+		}
+
+		ttl = d
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, "body is larger than %d bytes", maxBytesErr.Limit)
+		return
+	}
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%s", err.Error())
+		return
+	}
+
+	var value interface{} = string(body)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err = json.Unmarshal(body, &value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid json: %s", err.Error())
+			return
+		}
+	}
+
+	rc.cache.Set(key, value, ttl)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, rc registeredCache, key string) {
+	if _, found := rc.cache.GetAndRemove(key); !found {
+		writeError(w, http.StatusNotFound, "key %s isn't found", key)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
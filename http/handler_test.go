@@ -0,0 +1,308 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+func newTestHandler(t *testing.T, opts ...Option) (*Handler, cachego.Cache) {
+	cache, reporter := cachego.NewCacheWithReport(cachego.WithCacheName("test"), cachego.WithShardings(4))
+	t.Cleanup(func() { cache.Close() })
+
+	handler := NewHandler(opts...)
+	handler.Register("test", cache, reporter)
+	handler.Register("plain", cachego.NewCache(), nil)
+
+	return handler, cache
+}
+
+func serve(handler http.Handler, method string, target string, contentType string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func decode(t *testing.T, recorder *httptest.ResponseRecorder, status int, v interface{}) {
+	t.Helper()
+
+	if recorder.Code != status {
+		t.Fatalf("code %d != %d, body %s", recorder.Code, status, recorder.Body.String())
+	}
+
+	if v == nil {
+		return
+	}
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerStats$
+func TestHandlerStats(t *testing.T) {
+	handler, cache := newTestHandler(t)
+
+	cache.Set("key", "value", cachego.NoTTL)
+	cache.Get("key")
+	cache.Get("missing")
+
+	var list []Stats
+	decode(t, serve(handler, http.MethodGet, "/", "", ""), http.StatusOK, &list)
+
+	if len(list) != 2 || list[0].Name != "plain" || list[1].Name != "test" {
+		t.Fatalf("list %+v is wrong", list)
+	}
+
+	if list[0].Type != "" || list[0].Size != 0 {
+		t.Fatalf("stats %+v of plain is wrong", list[0])
+	}
+
+	var stats Stats
+	decode(t, serve(handler, http.MethodGet, "/test", "", ""), http.StatusOK, &stats)
+
+	want := Stats{Name: "test", Type: "standard", Shardings: 4, GC: "10m0s", Size: 1, Cost: 1, Hits: 1, Misses: 1, HitRate: 0.5}
+	if stats != want {
+		t.Fatalf("stats %+v != %+v", stats, want)
+	}
+
+	// Looking up keys by handler shouldn't be counted.
+	decode(t, serve(handler, http.MethodGet, "/test/keys/key", "", ""), http.StatusOK, nil)
+	decode(t, serve(handler, http.MethodGet, "/test/keys/missing", "", ""), http.StatusNotFound, nil)
+	decode(t, serve(handler, http.MethodGet, "/test", "", ""), http.StatusOK, &stats)
+
+	if stats != want {
+		t.Fatalf("stats %+v != %+v", stats, want)
+	}
+
+	decode(t, serve(handler, http.MethodGet, "/missing", "", ""), http.StatusNotFound, nil)
+	decode(t, serve(handler, http.MethodPost, "/", "", ""), http.StatusMethodNotAllowed, nil)
+	decode(t, serve(handler, http.MethodPost, "/test", "", ""), http.StatusMethodNotAllowed, nil)
+	decode(t, serve(handler, http.MethodGet, "/test/unknown", "", ""), http.StatusNotFound, nil)
+
+	handler.Unregister("plain")
+	decode(t, serve(handler, http.MethodGet, "/", "", ""), http.StatusOK, &list)
+
+	if len(list) != 1 || list[0].Name != "test" {
+		t.Fatalf("list %+v is wrong", list)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerKey$
+func TestHandlerKey(t *testing.T) {
+	handler, cache := newTestHandler(t)
+
+	key := url.PathEscape("a/b c")
+	decode(t, serve(handler, http.MethodGet, "/test/keys/"+key, "", ""), http.StatusNotFound, nil)
+	decode(t, serve(handler, http.MethodPut, "/test/keys/"+key+"?ttl=1m", "", "value"), http.StatusNoContent, nil)
+
+	var entry Entry
+	decode(t, serve(handler, http.MethodGet, "/test/keys/"+key, "", ""), http.StatusOK, &entry)
+
+	if entry.Key != "a/b c" || entry.Value != "value" || entry.Type != "string" || entry.TTL <= 59 || entry.TTL > 60 {
+		t.Fatalf("entry %+v is wrong", entry)
+	}
+
+	body := `{"name":"cachego","stars":100}`
+	decode(t, serve(handler, http.MethodPut, "/test/keys/json", "application/json", body), http.StatusNoContent, nil)
+	decode(t, serve(handler, http.MethodGet, "/test/keys/json", "", ""), http.StatusOK, &entry)
+
+	if got := fmt.Sprint(entry.Value); got != "map[name:cachego stars:100]" || entry.TTL != 0 {
+		t.Fatalf("entry %+v is wrong", entry)
+	}
+
+	cache.Set("bytes", []byte("value"), cachego.NoTTL)
+	decode(t, serve(handler, http.MethodGet, "/test/keys/bytes", "", ""), http.StatusOK, &entry)
+
+	if entry.Value != "value" || entry.Type != "[]uint8" {
+		t.Fatalf("entry %+v is wrong", entry)
+	}
+
+	cache.Set("func", func() {}, cachego.NoTTL)
+	decode(t, serve(handler, http.MethodGet, "/test/keys/func", "", ""), http.StatusUnprocessableEntity, nil)
+
+	decode(t, serve(handler, http.MethodPut, "/test/keys/key?ttl=x", "", "value"), http.StatusBadRequest, nil)
+	decode(t, serve(handler, http.MethodPut, "/test/keys/key", "application/json", "{"), http.StatusBadRequest, nil)
+	decode(t, serve(handler, http.MethodPut, "/test/keys/key", "", strings.Repeat("x", maxBodySize+1)), http.StatusRequestEntityTooLarge, nil)
+	decode(t, serve(handler, http.MethodPost, "/test/keys/key", "", ""), http.StatusMethodNotAllowed, nil)
+
+	decode(t, serve(handler, http.MethodDelete, "/test/keys/"+key, "", ""), http.StatusNoContent, nil)
+	decode(t, serve(handler, http.MethodDelete, "/test/keys/"+key, "", ""), http.StatusNotFound, nil)
+
+	if _, found := cache.Get("a/b c"); found {
+		t.Fatal("key isn't deleted")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerKeys$
+func TestHandlerKeys(t *testing.T) {
+	handler, cache := newTestHandler(t)
+
+	for i := 0; i < 25; i++ {
+		cache.Set(fmt.Sprintf("key%02d", i), i, cachego.NoTTL)
+	}
+
+	cache.Set("other", 0, cachego.NoTTL)
+
+	var keys []string
+	cursor := ""
+
+	for {
+		var page Page
+		decode(t, serve(handler, http.MethodGet, "/test/keys?prefix=key&limit=7&cursor="+url.QueryEscape(cursor), "", ""), http.StatusOK, &page)
+
+		keys = append(keys, page.Keys...)
+		if cursor = page.NextCursor; cursor == "" {
+			break
+		}
+	}
+
+	if len(keys) != 25 {
+		t.Fatalf("len(keys) %d != 25", len(keys))
+	}
+
+	for i, key := range keys {
+		if key != fmt.Sprintf("key%02d", i) {
+			t.Fatalf("key %s != key%02d", key, i)
+		}
+	}
+
+	var page Page
+	decode(t, serve(handler, http.MethodGet, "/test/keys?cursor=zzz", "", ""), http.StatusOK, &page)
+
+	if page.Keys == nil || len(page.Keys) != 0 || page.NextCursor != "" {
+		t.Fatalf("page %+v is wrong", page)
+	}
+
+	// Keys removed during paging won't make next page skip keys.
+	decode(t, serve(handler, http.MethodGet, "/test/keys?prefix=key&limit=5", "", ""), http.StatusOK, &page)
+	cache.Remove("key00")
+
+	decode(t, serve(handler, http.MethodGet, "/test/keys?prefix=key&limit=5&cursor="+url.QueryEscape(page.NextCursor), "", ""), http.StatusOK, &page)
+	if len(page.Keys) != 5 || page.Keys[0] != "key05" || page.NextCursor != "key09" {
+		t.Fatalf("page %+v is wrong", page)
+	}
+
+	decode(t, serve(handler, http.MethodGet, "/test/keys?limit=0", "", ""), http.StatusBadRequest, nil)
+	decode(t, serve(handler, http.MethodGet, "/test/keys?limit=1001", "", ""), http.StatusBadRequest, nil)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerGCAndReset$
+func TestHandlerGCAndReset(t *testing.T) {
+	handler, cache := newTestHandler(t)
+
+	cache.Set("expired", 1, time.Millisecond)
+	cache.Set("key", 1, cachego.NoTTL)
+	time.Sleep(5 * time.Millisecond)
+
+	var result map[string]int
+	decode(t, serve(handler, http.MethodPost, "/test/gc", "", ""), http.StatusOK, &result)
+
+	if result["cleans"] != 1 || cache.Size() != 1 {
+		t.Fatalf("result %+v is wrong", result)
+	}
+
+	decode(t, serve(handler, http.MethodPost, "/test/reset", "", ""), http.StatusNoContent, nil)
+
+	if cache.Size() != 0 {
+		t.Fatalf("cache.Size() %d != 0", cache.Size())
+	}
+
+	decode(t, serve(handler, http.MethodGet, "/test/gc", "", ""), http.StatusMethodNotAllowed, nil)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerReadOnly$
+func TestHandlerReadOnly(t *testing.T) {
+	handler, cache := newTestHandler(t, WithReadOnly())
+	cache.Set("key", "value", cachego.NoTTL)
+
+	decode(t, serve(handler, http.MethodGet, "/test/keys/key", "", ""), http.StatusOK, nil)
+	decode(t, serve(handler, http.MethodPut, "/test/keys/key", "", "new"), http.StatusForbidden, nil)
+	decode(t, serve(handler, http.MethodDelete, "/test/keys/key", "", ""), http.StatusForbidden, nil)
+	decode(t, serve(handler, http.MethodPost, "/test/gc", "", ""), http.StatusForbidden, nil)
+	decode(t, serve(handler, http.MethodPost, "/test/reset", "", ""), http.StatusForbidden, nil)
+
+	if value, found := cache.Get("key"); !found || value != "value" {
+		t.Fatalf("value %+v is modified", value)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHandlerAuth$
+func TestHandlerAuth(t *testing.T) {
+	auth := func(r *http.Request) error {
+		if r.Header.Get("Authorization") == "" {
+			return errors.New("unauthorized")
+		}
+
+		return nil
+	}
+
+	writeAuth := func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "admin" {
+			return errors.New("forbidden")
+		}
+
+		return nil
+	}
+
+	handler, _ := newTestHandler(t, WithAuth(auth), WithWriteAuth(writeAuth))
+
+	serveAs := func(user string, method string, target string) int {
+		request := httptest.NewRequest(method, target, strings.NewReader("value"))
+		if user != "" {
+			request.Header.Set("Authorization", user)
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		return recorder.Code
+	}
+
+	testCases := []struct {
+		user   string
+		method string
+		code   int
+	}{
+		{user: "", method: http.MethodGet, code: http.StatusUnauthorized},
+		{user: "", method: http.MethodPut, code: http.StatusUnauthorized},
+		{user: "guest", method: http.MethodGet, code: http.StatusNotFound},
+		{user: "guest", method: http.MethodPut, code: http.StatusForbidden},
+		{user: "admin", method: http.MethodPut, code: http.StatusNoContent},
+		{user: "admin", method: http.MethodGet, code: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		if code := serveAs(testCase.user, testCase.method, "/test/keys/key"); code != testCase.code {
+			t.Fatalf("%s %s: code %d != %d", testCase.user, testCase.method, code, testCase.code)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+)
+
+// Option applies to handler and sets some values to handler.
+type Option func(h *Handler)
+
+func (o Option) applyTo(h *Handler) {
+	o(h)
+}
+
+func applyOptions(h *Handler, opts []Option) {
+	for _, opt := range opts {
+		opt.applyTo(h)
+	}
+}
+
+// WithReadOnly returns an option setting the readOnly of handler.
+// A read-only handler rejects requests setting or deleting keys, running gc and resetting caches with 403.
+func WithReadOnly() Option {
+	return func(h *Handler) {
+		h.readOnly = true
+	}
+}
+
+// WithAuth returns an option setting the auth of handler.
+// Auth is called before handling every request, and requests are rejected with 401 if it returns an error.
+func WithAuth(auth func(r *http.Request) error) Option {
+	return func(h *Handler) {
+		h.auth = auth
+	}
+}
+
+// WithWriteAuth returns an option setting the writeAuth of handler.
+// Write auth is called after auth before handling requests which modify caches, and requests are rejected with 403 if it returns an error.
+// It's useful for allowing everyone to read but only admins to write.
+func WithWriteAuth(writeAuth func(r *http.Request) error) Option {
+	return func(h *Handler) {
+		h.writeAuth = writeAuth
+	}
+}
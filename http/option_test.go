@@ -0,0 +1,53 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithReadOnly$
+func TestWithReadOnly(t *testing.T) {
+	h := &Handler{readOnly: false}
+
+	WithReadOnly().applyTo(h)
+	if !h.readOnly {
+		t.Fatalf("h.readOnly %+v != true", h.readOnly)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithAuth$
+func TestWithAuth(t *testing.T) {
+	errAuth := errors.New("auth")
+	h := &Handler{auth: nil}
+
+	WithAuth(func(r *http.Request) error { return errAuth }).applyTo(h)
+	if h.auth == nil || h.auth(nil) != errAuth {
+		t.Fatal("h.auth isn't set")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithWriteAuth$
+func TestWithWriteAuth(t *testing.T) {
+	errAuth := errors.New("write auth")
+	h := &Handler{writeAuth: nil}
+
+	WithWriteAuth(func(r *http.Request) error { return errAuth }).applyTo(h)
+	if h.writeAuth == nil || h.writeAuth(nil) != errAuth {
+		t.Fatal("h.writeAuth isn't set")
+	}
+}
@@ -0,0 +1,432 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// publishTimeout is the max duration of publishing an invalidation, so a slow invalidator won't stall writes.
+const publishTimeout = time.Second
+
+// ErrInvalidationDropped is returned by ChannelInvalidator.Publish if a subscriber is too slow to receive the invalidation.
+var ErrInvalidationDropped = errors.New("cachego: invalidation is dropped")
+
+// Invalidation is a message telling caches to remove a key or keys with a prefix.
+type Invalidation struct {
+	// Source is the id of cache publishing the invalidation, and caches ignore their own invalidations.
+	// An invalidation without source will be applied by all caches.
+	Source string `json:"source,omitempty"`
+
+	// Key is the key to be removed, or the prefix of keys to be removed if Prefix is true.
+	Key    string `json:"key"`
+	Prefix bool   `json:"prefix,omitempty"`
+}
+
+// Invalidator is a bus of invalidations between caches, such as a message queue.
+type Invalidator interface {
+	// Publish publishes an invalidation to all subscribers.
+	Publish(ctx context.Context, invalidation Invalidation) error
+
+	// Subscribe calls fn with each invalidation published and returns a function to unsubscribe.
+	Subscribe(fn func(invalidation Invalidation)) (unsubscribe func())
+}
+
+// ChannelInvalidator is an in-process invalidator delivering invalidations by channels, which is useful for tests.
+// Each subscriber has a buffered channel and a goroutine calling its function, and Publish never blocks on a full channel.
+type ChannelInvalidator struct {
+	channels map[chan Invalidation]struct{}
+	lock     sync.RWMutex
+}
+
+// NewChannelInvalidator creates a channel invalidator.
+func NewChannelInvalidator() *ChannelInvalidator {
+	ci := &ChannelInvalidator{
+		channels: make(map[chan Invalidation]struct{}, 4),
+	}
+
+	return ci
+}
+
+// Publish publishes an invalidation to all subscribers and returns ctx.Err() if ctx is done before sending.
+// The invalidation is dropped for subscribers whose channels are full, and ErrInvalidationDropped will be returned.
+func (ci *ChannelInvalidator) Publish(ctx context.Context, invalidation Invalidation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ci.lock.RLock()
+	defer ci.lock.RUnlock()
+
+	dropped := false
+	for ch := range ci.channels {
+		select {
+		case ch <- invalidation:
+		default:
+			dropped = true
+		}
+	}
+
+	if dropped {
+		return ErrInvalidationDropped
+	}
+
+	return nil
+}
+
+// Subscribe calls fn with each invalidation published in a new goroutine and returns a function to unsubscribe.
+func (ci *ChannelInvalidator) Subscribe(fn func(invalidation Invalidation)) (unsubscribe func()) {
+	ch := make(chan Invalidation, 1024)
+
+	ci.lock.Lock()
+	ci.channels[ch] = struct{}{}
+	ci.lock.Unlock()
+
+	go func() {
+		for invalidation := range ch {
+			fn(invalidation)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ci.lock.Lock()
+			delete(ci.channels, ch)
+			ci.lock.Unlock()
+
+			close(ch)
+		})
+	}
+}
+
+// newSource returns a random id of cache.
+func newSource() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(id)
+}
+
+// invalidatingCache publishes invalidations of keys written to cache, and removes keys invalidated by others.
+// Publishing errors are reported to onInvalidateError only, because keys are written to cache already and they will be expired after ttl.
+type invalidatingCache struct {
+	*config
+
+	cache       Cache
+	source      string
+	unsubscribe func()
+}
+
+func newInvalidatingCache(conf *config, cache Cache) Cache {
+	ic := &invalidatingCache{
+		config: conf,
+		cache:  cache,
+		source: newSource(),
+	}
+
+	ic.unsubscribe = conf.invalidator.Subscribe(ic.invalidate)
+	return ic
+}
+
+// invalidate removes keys of invalidation from cache without publishing it again.
+func (ic *invalidatingCache) invalidate(invalidation Invalidation) {
+	if invalidation.Source != "" && invalidation.Source == ic.source {
+		return
+	}
+
+	if !invalidation.Prefix {
+		ic.cache.Remove(invalidation.Key)
+		return
+	}
+
+	var keys []string
+	for _, key := range ic.cache.Keys() {
+		if strings.HasPrefix(key, invalidation.Key) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) > 0 {
+		ic.cache.RemoveMulti(keys)
+	}
+}
+
+// publish publishes invalidations of keys in publishTimeout and reports the failed ones.
+func (ic *invalidatingCache) publish(keys ...string) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := ic.invalidator.Publish(ctx, Invalidation{Source: ic.source, Key: key}); err != nil {
+			ic.notifyInvalidateError(key, err)
+		}
+	}
+}
+
+func (ic *invalidatingCache) dump() []DumpEntry {
+	if dc, ok := ic.cache.(dumpableCache); ok {
+		return dc.dump()
+	}
+
+	return nil
+}
+
+func (ic *invalidatingCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	return Peek(ic.cache, key)
+}
+
+func (ic *invalidatingCache) restore(entry *DumpEntry) {
+	if restorable, ok := ic.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	ic.cache.Set(entry.Key, entry.Value, entry.TTL)
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (ic *invalidatingCache) Get(key string) (value interface{}, found bool) {
+	return ic.cache.Get(key)
+}
+
+// Set sets key and value to cache with ttl and publishes an invalidation of key.
+// See Cache interface.
+func (ic *invalidatingCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	evictedValue = ic.cache.Set(key, value, ttl)
+	ic.publish(key)
+
+	return evictedValue
+}
+
+// Remove removes key and publishes an invalidation of key.
+// See Cache interface.
+func (ic *invalidatingCache) Remove(key string) (removedValue interface{}) {
+	removedValue = ic.cache.Remove(key)
+	ic.publish(key)
+
+	return removedValue
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (ic *invalidatingCache) TTL(key string) (ttl time.Duration, found bool) {
+	return ic.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (ic *invalidatingCache) Touch(key string, ttl time.Duration) (found bool) {
+	return ic.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (ic *invalidatingCache) Expire(key string, ttl time.Duration) (found bool) {
+	return ic.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (ic *invalidatingCache) Persist(key string) (found bool) {
+	return ic.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// An invalidation of key will be published if value is set.
+// See Cache interface.
+func (ic *invalidatingCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	actual, found = ic.cache.GetOrSet(key, value, ttl)
+	if !found {
+		ic.publish(key)
+	}
+
+	return actual, found
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// An invalidation of key will be published if value is stored.
+// See Cache interface.
+func (ic *invalidatingCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	actual, stored = ic.cache.SetIfAbsent(key, value, ttl)
+	if stored {
+		ic.publish(key)
+	}
+
+	return actual, stored
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// An invalidation of key will be published if new is swapped.
+// See Cache interface.
+func (ic *invalidatingCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	swapped = ic.cache.CompareAndSwap(key, old, new, ttl, equal)
+	if swapped {
+		ic.publish(key)
+	}
+
+	return swapped
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// An invalidation of key will be published only if a different value is set or key is removed.
+// See Cache interface.
+func (ic *invalidatingCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	changed := false
+	value, kept = ic.cache.Update(key, func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool) {
+		new, ttl, keep = fn(old, found)
+		changed = (keep && (!found || !reflect.DeepEqual(old, new))) || (!keep && found)
+
+		return new, ttl, keep
+	})
+
+	if changed {
+		ic.publish(key)
+	}
+
+	return value, kept
+}
+
+// GetAndRemove removes key and returns its value if found.
+// An invalidation of key will be published if found.
+// See Cache interface.
+func (ic *invalidatingCache) GetAndRemove(key string) (value interface{}, found bool) {
+	value, found = ic.cache.GetAndRemove(key)
+	if found {
+		ic.publish(key)
+	}
+
+	return value, found
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// An invalidation of key will be published, so don't share a counter between caches by invalidator.
+// See Cache interface.
+func (ic *invalidatingCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	value = ic.cache.Incr(key, delta, ttl)
+	ic.publish(key)
+
+	return value
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// An invalidation of key will be published, so don't share a counter between caches by invalidator.
+// See Cache interface.
+func (ic *invalidatingCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	value = ic.cache.Decr(key, delta, ttl)
+	ic.publish(key)
+
+	return value
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (ic *invalidatingCache) Size() (size int) {
+	return ic.cache.Size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (ic *invalidatingCache) Cost() (cost int64) {
+	return ic.cache.Cost()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (ic *invalidatingCache) GC() (cleans int) {
+	return ic.cache.GC()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// Notice that it won't publish any invalidations.
+// See Cache interface.
+func (ic *invalidatingCache) Reset() {
+	ic.cache.Reset()
+}
+
+// Close unsubscribes invalidations and closes cache.
+// See Cache interface.
+func (ic *invalidatingCache) Close() error {
+	ic.unsubscribe()
+	return ic.cache.Close()
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (ic *invalidatingCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	ic.cache.Range(fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (ic *invalidatingCache) Keys() (keys []string) {
+	return ic.cache.Keys()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// Notice that loaded keys won't be published, because they are loaded from the source of truth.
+// See Cache interface.
+func (ic *invalidatingCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	return ic.cache.Load(key, ttl, load)
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// Notice that loaded keys won't be published, because they are loaded from the source of truth.
+// See Cache interface.
+func (ic *invalidatingCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return ic.cache.LoadContext(ctx, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (ic *invalidatingCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	return ic.cache.GetMulti(keys)
+}
+
+// SetMulti sets entries to cache with ttl and publishes invalidations of their keys.
+// See Cache interface.
+func (ic *invalidatingCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	ic.cache.SetMulti(entries, ttl)
+
+	for key := range entries {
+		ic.publish(key)
+	}
+}
+
+// RemoveMulti removes keys and publishes invalidations of them.
+// See Cache interface.
+func (ic *invalidatingCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	removedValues = ic.cache.RemoveMulti(keys)
+	ic.publish(keys...)
+
+	return removedValues
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// Notice that loaded keys won't be published, because they are loaded from the source of truth.
+// See Cache interface.
+func (ic *invalidatingCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return ic.cache.LoadMulti(keys, ttl, load)
+}
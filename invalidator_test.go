@@ -0,0 +1,231 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitFor waits until fn returns true or 1 second passes.
+func waitFor(t *testing.T, fn func() bool) {
+	t.Helper()
+
+	for i := 0; i < 1000; i++ {
+		if fn() {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("wait for too long")
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestInvalidatingCache$
+func TestInvalidatingCache(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.invalidator = NewChannelInvalidator()
+
+	cache := newInvalidatingCache(conf, newStandardCache(conf))
+	defer cache.Close()
+
+	testCacheImplement(t, cache)
+}
+
+// failedInvalidator is an invalidator failing to publish all invalidations.
+type failedInvalidator struct {
+	err error
+}
+
+func (fi failedInvalidator) Publish(ctx context.Context, invalidation Invalidation) error {
+	return fi.err
+}
+
+func (fi failedInvalidator) Subscribe(fn func(invalidation Invalidation)) (unsubscribe func()) {
+	return func() {}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestInvalidatingCachePublishError$
+func TestInvalidatingCachePublishError(t *testing.T) {
+	wantErr := errors.New("failed")
+
+	var gotKeys []string
+	onInvalidateError := func(key string, err error) {
+		if err != wantErr {
+			t.Fatalf("err %+v != wantErr %+v", err, wantErr)
+		}
+
+		gotKeys = append(gotKeys, key)
+	}
+
+	cache := NewCache(WithInvalidator(failedInvalidator{err: wantErr}), WithOnInvalidateError(onInvalidateError))
+	defer cache.Close()
+
+	cache.Set("key", "value", NoTTL)
+	cache.Remove("key")
+
+	if len(gotKeys) != 2 || gotKeys[0] != "key" || gotKeys[1] != "key" {
+		t.Fatalf("gotKeys %+v is wrong", gotKeys)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestInvalidatingCacheUpdate$
+func TestInvalidatingCacheUpdate(t *testing.T) {
+	publishes := 0
+	onInvalidateError := func(key string, err error) {
+		publishes++
+	}
+
+	cache := NewCache(WithInvalidator(failedInvalidator{err: errors.New("failed")}), WithOnInvalidateError(onInvalidateError))
+	defer cache.Close()
+
+	keep := func(value interface{}) func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		return func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+			return value, NoTTL, true
+		}
+	}
+
+	remove := func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		return nil, NoTTL, false
+	}
+
+	// Nothing is written or removed, so no invalidations should be published.
+	cache.Update("key", remove)
+	if publishes != 0 {
+		t.Fatalf("publishes %d != 0", publishes)
+	}
+
+	cache.Update("key", keep([]int{1}))
+	cache.Update("key", keep([]int{1}))
+	if publishes != 1 {
+		t.Fatalf("publishes %d != 1", publishes)
+	}
+
+	cache.Update("key", keep([]int{2}))
+	cache.Update("key", remove)
+	if publishes != 3 {
+		t.Fatalf("publishes %d != 3", publishes)
+	}
+}
+
+// testCacheInvalidate tests caches attached to the same invalidator.
+func testCacheInvalidate(t *testing.T, invalidator Invalidator, cache Cache, other Cache) {
+	// Invalidations are delivered in order, so wait for a sentinel to make sure others are delivered.
+	cache.Set("sentinel", "value", NoTTL)
+
+	other.Set("key", "other", NoTTL)
+	other.Set("user:1", 1, NoTTL)
+	other.Set("user:2", 2, NoTTL)
+	other.Remove("sentinel")
+
+	waitFor(t, func() bool {
+		_, found := cache.Get("sentinel")
+		return !found
+	})
+
+	// Invalidations published by cache itself should be ignored.
+	cache.Set("key", "value", NoTTL)
+
+	waitFor(t, func() bool {
+		_, found := other.Get("key")
+		return !found
+	})
+
+	if value, found := cache.Get("key"); !found || value != "value" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	other.Set("key", "other", NoTTL)
+	waitFor(t, func() bool {
+		_, found := cache.Get("key")
+		return !found
+	})
+
+	// Invalidations without source should be applied by all caches.
+	cache.Set("user:3", 3, NoTTL)
+	invalidator.Publish(context.Background(), Invalidation{Key: "user:", Prefix: true})
+
+	waitFor(t, func() bool {
+		return cache.Size() == 0 && other.Size() == 1
+	})
+
+	if value, found := other.Get("key"); !found || value != "other" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestInvalidatingCacheInvalidate$
+func TestInvalidatingCacheInvalidate(t *testing.T) {
+	invalidator := NewChannelInvalidator()
+
+	cache := NewCache(WithInvalidator(invalidator), WithShardings(4))
+	defer cache.Close()
+
+	other := NewCache(WithInvalidator(invalidator), WithLRU(maxTestEntries))
+	defer other.Close()
+
+	testCacheInvalidate(t, invalidator, cache, other)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestChannelInvalidator$
+func TestChannelInvalidator(t *testing.T) {
+	invalidator := NewChannelInvalidator()
+
+	received := make(chan Invalidation, 1)
+	unsubscribe := invalidator.Subscribe(func(invalidation Invalidation) {
+		received <- invalidation
+	})
+
+	want := Invalidation{Source: "test", Key: "key"}
+	if err := invalidator.Publish(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := <-received; got != want {
+		t.Fatalf("got %+v != want %+v", got, want)
+	}
+
+	// Publishing should be dropped without blocking if the channel is full.
+	// The subscriber holds 2 invalidations at most because received is full, so the channel is full after 1024+2 ones.
+	dropped := false
+	for i := 0; i < 1024+3 && !dropped; i++ {
+		dropped = errors.Is(invalidator.Publish(context.Background(), want), ErrInvalidationDropped)
+	}
+
+	if !dropped {
+		t.Fatal("publishing to a full channel isn't dropped")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := invalidator.Publish(ctx, want); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err %+v isn't context.Canceled", err)
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if err := invalidator.Publish(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(invalidator.channels) != 0 {
+		t.Fatalf("len(invalidator.channels) %d != 0", len(invalidator.channels))
+	}
+}
@@ -15,39 +15,58 @@
 package cachego
 
 import (
+	"context"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/FishGoddess/cachego/pkg/heap"
 )
 
-type lfuCache struct {
+// typedLFUCache is the core of lfu caches whose keys are in type K and values are in type V.
+type typedLFUCache[K comparable, V any] struct {
 	*config
 
-	itemMap  map[string]*heap.Item
-	itemHeap *heap.Heap
-	lock     sync.RWMutex
+	itemMap     map[K]*heap.Item
+	itemHeap    *heap.Heap
+	expirations *typedExpirationIndex[K]
+	cost        int64
+	lock        sync.RWMutex
 
-	loader *loader
+	loader *typedLoader[K, V]
 }
 
-func newLFUCache(conf *config) Cache {
-	if conf.maxEntries <= 0 {
-		panic("cachego: lfu cache must specify max entries")
+func newTypedLFUCache[K comparable, V any](conf *config) *typedLFUCache[K, V] {
+	if conf.maxEntries <= 0 && conf.maxCost <= 0 {
+		panic("cachego: lfu cache must specify max entries or max cost")
+	}
+
+	cache := &typedLFUCache[K, V]{
+		config:      conf,
+		itemMap:     make(map[K]*heap.Item, mapInitialCap),
+		itemHeap:    heap.New(sliceInitialCap),
+		expirations: newTypedExpirationIndex[K](conf),
+		loader:      newTypedLoader[K, V](conf),
 	}
 
+	return cache
+}
+
+// lfuCache is the lfu cache whose keys are strings and values are in any types.
+type lfuCache struct {
+	*typedLFUCache[string, interface{}]
+}
+
+func newLFUCache(conf *config) Cache {
 	cache := &lfuCache{
-		config:   conf,
-		itemMap:  make(map[string]*heap.Item, mapInitialCap),
-		itemHeap: heap.New(sliceInitialCap),
-		loader:   newLoader(conf.singleflight),
+		typedLFUCache: newTypedLFUCache[string, interface{}](conf),
 	}
 
 	return cache
 }
 
-func (lc *lfuCache) unwrap(item *heap.Item) *entry {
-	entry, ok := item.Value.(*entry)
+func (tfc *typedLFUCache[K, V]) unwrap(item *heap.Item) *typedEntry[K, V] {
+	entry, ok := item.Value.(*typedEntry[K, V])
 	if !ok {
 		panic("cachego: failed to unwrap lfu item's value to entry")
 	}
@@ -55,83 +74,151 @@ func (lc *lfuCache) unwrap(item *heap.Item) *entry {
 	return entry
 }
 
-func (lc *lfuCache) evict() (evictedValue interface{}) {
-	if item := lc.itemHeap.Pop(); item != nil {
-		return lc.removeItem(item)
+func (tfc *typedLFUCache[K, V]) evict() (evictedValue V, evicted bool) {
+	if item := tfc.itemHeap.Pop(); item != nil {
+		return tfc.removeItem(item, RemovalCapacity), true
 	}
 
-	return nil
+	return evictedValue, false
 }
 
-func (lc *lfuCache) get(key string) (value interface{}, found bool) {
-	item, ok := lc.itemMap[key]
+func (tfc *typedLFUCache[K, V]) get(key K) (value V, found bool) {
+	item, ok := tfc.itemMap[key]
 	if !ok {
-		return nil, false
+		return value, false
 	}
 
-	entry := lc.unwrap(item)
+	entry := tfc.unwrap(item)
 	if entry.expired(0) {
-		return nil, false
+		return value, false
 	}
 
+	entry.slide()
 	item.Adjust(item.Weight() + 1)
+
 	return entry.value, true
 }
 
-func (lc *lfuCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
-	item, ok := lc.itemMap[key]
+// evictByCost evicts entries until cost can be added without exceeding maxCost or size reaches minSize.
+func (tfc *typedLFUCache[K, V]) evictByCost(cost int64, minSize int) (evictedValue V, evicted bool) {
+	for tfc.maxCost > 0 && tfc.size() > minSize && tfc.cost+cost > tfc.maxCost {
+		evictedValue, evicted = tfc.evict()
+	}
+
+	return evictedValue, evicted
+}
+
+func (tfc *typedLFUCache[K, V]) set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	cost := weighTyped(tfc.config, key, value)
+
+	item, ok := tfc.itemMap[key]
 	if ok {
-		entry := lc.unwrap(item)
+		entry := tfc.unwrap(item)
+		notifyTypedEvicted(tfc.config, key, entry.value, replacedCause(entry))
 		entry.setup(key, value, ttl)
+		tfc.setupSliding(entry, ttl)
+		tfc.updateExpiration(entry)
 
-		item.Adjust(item.Weight() + 1)
-		return nil
+		tfc.cost += cost - entry.cost
+		entry.cost = cost
+
+		if tfc.maxCost <= 0 || tfc.cost <= tfc.maxCost {
+			item.Adjust(item.Weight() + 1)
+			return evictedValue, false
+		}
+
+		// Detach item so it won't be evicted by itself.
+		weight := item.Weight() + 1
+		delete(tfc.itemMap, key)
+		tfc.itemHeap.Remove(item)
+
+		evictedValue, evicted = tfc.evictByCost(0, 0)
+		tfc.itemMap[key] = tfc.itemHeap.Push(weight, entry)
+
+		return evictedValue, evicted
 	}
 
-	if lc.maxEntries > 0 && lc.itemHeap.Size() >= lc.maxEntries {
-		evictedValue = lc.evict()
+	if tfc.maxEntries > 0 && tfc.itemHeap.Size() >= tfc.maxEntries {
+		evictedValue, evicted = tfc.evict()
 	}
 
-	item = lc.itemHeap.Push(0, newEntry(key, value, ttl, lc.now))
-	lc.itemMap[key] = item
+	if value, ok := tfc.evictByCost(cost, 0); ok {
+		evictedValue, evicted = value, true
+	}
 
-	return evictedValue
+	entry := newTypedEntry(key, value, ttl, tfc.now)
+	tfc.setupSliding(entry, ttl)
+	entry.cost = cost
+
+	item = tfc.itemHeap.Push(0, entry)
+	tfc.itemMap[key] = item
+	tfc.cost += cost
+	tfc.updateExpiration(entry)
+
+	return evictedValue, evicted
 }
 
-func (lc *lfuCache) removeItem(item *heap.Item) (removedValue interface{}) {
-	entry := lc.unwrap(item)
+func (tfc *typedLFUCache[K, V]) updateExpiration(entry *typedEntry[K, V]) {
+	if tfc.expirations != nil {
+		tfc.expirations.update(entry.key, entry.expiration)
+	}
+}
 
-	delete(lc.itemMap, entry.key)
-	lc.itemHeap.Remove(item)
+func (tfc *typedLFUCache[K, V]) removeItem(item *heap.Item, cause RemovalCause) (removedValue V) {
+	entry := tfc.unwrap(item)
 
+	delete(tfc.itemMap, entry.key)
+	tfc.itemHeap.Remove(item)
+	tfc.cost -= entry.cost
+
+	if tfc.expirations != nil {
+		tfc.expirations.remove(entry.key)
+	}
+
+	notifyTypedEvicted(tfc.config, entry.key, entry.value, cause)
 	return entry.value
 }
 
-func (lc *lfuCache) remove(key string) (removedValue interface{}) {
-	if item, ok := lc.itemMap[key]; ok {
-		return lc.removeItem(item)
+func (tfc *typedLFUCache[K, V]) remove(key K) (removedValue V, removed bool) {
+	if item, ok := tfc.itemMap[key]; ok {
+		return tfc.removeItem(item, RemovalExplicit), true
 	}
 
-	return nil
+	return removedValue, false
 }
 
-func (lc *lfuCache) size() (size int) {
-	return len(lc.itemMap)
+func (tfc *typedLFUCache[K, V]) size() (size int) {
+	return len(tfc.itemMap)
 }
 
-func (lc *lfuCache) gc() (cleans int) {
-	now := lc.now()
+func (tfc *typedLFUCache[K, V]) gc() (cleans int) {
+	now := tfc.now()
+
+	if tfc.expirations != nil {
+		return tfc.expirations.clean(now, func(key K) bool {
+			item := tfc.itemMap[key]
+			if entry := tfc.unwrap(item); !entry.expired(now) {
+				// The expiration of entry may slide after indexing.
+				tfc.updateExpiration(entry)
+				return false
+			}
+
+			tfc.removeItem(item, RemovalExpired)
+			return true
+		})
+	}
+
 	scans := 0
 
-	for _, item := range lc.itemMap {
+	for _, item := range tfc.itemMap {
 		scans++
 
-		if entry := lc.unwrap(item); entry.expired(now) {
-			lc.removeItem(item)
+		if entry := tfc.unwrap(item); entry.expired(now) {
+			tfc.removeItem(item, RemovalExpired)
 			cleans++
 		}
 
-		if lc.maxScans > 0 && scans >= lc.maxScans {
+		if tfc.maxScans > 0 && scans >= tfc.maxScans {
 			break
 		}
 	}
@@ -139,20 +226,114 @@ func (lc *lfuCache) gc() (cleans int) {
 	return cleans
 }
 
-func (lc *lfuCache) reset() {
-	lc.itemMap = make(map[string]*heap.Item, mapInitialCap)
-	lc.itemHeap = heap.New(sliceInitialCap)
+func (tfc *typedLFUCache[K, V]) reset() {
+	if tfc.onEvicted != nil {
+		for _, item := range tfc.itemMap {
+			entry := tfc.unwrap(item)
+			notifyTypedEvicted(tfc.config, entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	tfc.itemMap = make(map[K]*heap.Item, mapInitialCap)
+	tfc.itemHeap = heap.New(sliceInitialCap)
+	tfc.cost = 0
+
+	if tfc.expirations != nil {
+		tfc.expirations.reset()
+	}
+
+	tfc.loader.Reset()
+}
+
+func (tfc *typedLFUCache[K, V]) loaderOf() *typedLoader[K, V] {
+	return tfc.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (tfc *typedLFUCache[K, V]) entryOf(key K) *typedEntry[K, V] {
+	item, ok := tfc.itemMap[key]
+	if !ok {
+		return nil
+	}
+
+	if entry := tfc.unwrap(item); !entry.expired(0) {
+		return entry
+	}
+
+	return nil
+}
+
+func (tfc *typedLFUCache[K, V]) expire(key K, ttl time.Duration) (found bool) {
+	entry := tfc.entryOf(key)
+	if entry == nil {
+		return false
+	}
 
-	lc.loader.Reset()
+	entry.expire(ttl)
+	tfc.updateExpiration(entry)
+	return true
 }
 
-// Get gets the value of key from cache and returns value if found.
-// See Cache interface.
-func (lc *lfuCache) Get(key string) (value interface{}, found bool) {
+func (lc *lfuCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	evictedValue, _ = lc.typedLFUCache.set(key, value, ttl)
+	return evictedValue
+}
+
+func (lc *lfuCache) remove(key string) (removedValue interface{}) {
+	removedValue, _ = lc.typedLFUCache.remove(key)
+	return removedValue
+}
+
+// snapshot returns all unexpired entries with their weights.
+// The entries will be sorted from the least frequently used one if evictionOrder is true.
+func (lc *lfuCache) snapshot(evictionOrder bool) []DumpEntry {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+
+	now := lc.now()
+	entries := make([]DumpEntry, 0, lc.size())
+
+	for _, item := range lc.itemMap {
+		if dumpEntry, ok := newDumpEntry(lc.unwrap(item), now); ok {
+			dumpEntry.Weight = item.Weight()
+			entries = append(entries, dumpEntry)
+		}
+	}
+
+	if evictionOrder {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Weight < entries[j].Weight
+		})
+	}
+
+	return entries
+}
+
+func (lc *lfuCache) dump() []DumpEntry {
+	// The least frequently used entries should be restored first.
+	return lc.snapshot(true)
+}
+
+func (lc *lfuCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+
+	if entry := lc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(lc.now()), true
+	}
+
+	return nil, 0, false
+}
+
+func (lc *lfuCache) restore(entry *DumpEntry) {
 	lc.lock.Lock()
 	defer lc.lock.Unlock()
 
-	return lc.get(key)
+	lc.set(entry.Key, entry.Value, entry.TTL)
+
+	if item, ok := lc.itemMap[entry.Key]; ok {
+		item.Adjust(entry.Weight)
+	}
 }
 
 // Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
@@ -173,41 +354,163 @@ func (lc *lfuCache) Remove(key string) (removedValue interface{}) {
 	return lc.remove(key)
 }
 
-// Size returns the count of keys in cache.
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
 // See Cache interface.
-func (lc *lfuCache) Size() (size int) {
+func (lc *lfuCache) TTL(key string) (ttl time.Duration, found bool) {
 	lc.lock.RLock()
 	defer lc.lock.RUnlock()
 
-	return lc.size()
+	if entry := lc.entryOf(key); entry != nil {
+		return entry.ttl(lc.now()), true
+	}
+
+	return 0, false
 }
 
-// GC cleans the expired keys in cache and returns the exact count cleaned.
+// Touch resets the ttl of key and accesses key like Get.
 // See Cache interface.
-func (lc *lfuCache) GC() (cleans int) {
+func (lc *lfuCache) Touch(key string, ttl time.Duration) (found bool) {
 	lc.lock.Lock()
 	defer lc.lock.Unlock()
 
-	return lc.gc()
+	if _, found = lc.get(key); !found {
+		return false
+	}
+
+	return lc.expire(key, ttl)
 }
 
-// Reset resets cache to initial status which is like a new cache.
+// Expire resets the ttl of key without accessing key.
 // See Cache interface.
-func (lc *lfuCache) Reset() {
+func (lc *lfuCache) Expire(key string, ttl time.Duration) (found bool) {
 	lc.lock.Lock()
 	defer lc.lock.Unlock()
 
-	lc.reset()
+	return lc.expire(key, ttl)
 }
 
-// Load loads a value by load function and sets it to cache.
-// Returns an error if load failed.
-func (lc *lfuCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
-	value, err = lc.loader.Load(key, ttl, load)
-	if err != nil {
-		return value, err
-	}
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (lc *lfuCache) Persist(key string) (found bool) {
+	return lc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (lc *lfuCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getOrSet(lc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (lc *lfuCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return setIfAbsent(lc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (lc *lfuCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return compareAndSwap(lc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (lc *lfuCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return update(lc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (lc *lfuCache) GetAndRemove(key string) (value interface{}, found bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getAndRemove(lc, key)
+}
 
-	lc.Set(key, value, ttl)
-	return value, nil
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (lc *lfuCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return incr(lc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (lc *lfuCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return lc.Incr(key, -delta, ttl)
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (lc *lfuCache) Cost() (cost int64) {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+
+	return lc.cost
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (lc *lfuCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(lc.snapshot(lc.rangeInEvictionOrder), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (lc *lfuCache) Keys() (keys []string) {
+	return keysOf(lc.snapshot(lc.rangeInEvictionOrder))
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (lc *lfuCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, lc, lc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (lc *lfuCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getMulti(keys, lc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (lc *lfuCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	setMulti(entries, ttl, lc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (lc *lfuCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return removeMulti(keys, lc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (lc *lfuCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(lc, lc.loader, keys, ttl, load)
 }
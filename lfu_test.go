@@ -18,6 +18,7 @@ import (
 	"math/rand"
 	"sort"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -35,6 +36,40 @@ func TestLFUCache(t *testing.T) {
 	testCacheImplement(t, cache)
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheWithExpirationIndex$
+func TestLFUCacheWithExpirationIndex(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+	conf.expirationIndex = true
+
+	cache := newLFUCache(conf).(*lfuCache)
+	testCacheImplement(t, cache)
+
+	// All entries with ttl are expired, so gc should clean up the index.
+	cache.GC()
+
+	if cache.expirations.itemHeap.Size() != 0 {
+		t.Fatalf("cache.expirations.itemHeap.Size() %d != 0", cache.expirations.itemHeap.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheSlidingExpiration$
+func TestLFUCacheSlidingExpiration(t *testing.T) {
+	for _, expirationIndex := range []bool{false, true} {
+		var clock atomic.Int64
+
+		conf := newDefaultConfig()
+		conf.maxEntries = maxTestEntries
+		conf.expirationIndex = expirationIndex
+		conf.slidingExpiration = true
+		conf.maxLifetime = 25
+		conf.now = clock.Load
+
+		cache := newLFUCache(conf)
+		testCacheSlidingExpiration(t, cache, &clock)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheEvict$
 func TestLFUCacheEvict(t *testing.T) {
 	cache := newTestLFUCache()
@@ -176,3 +211,138 @@ func TestLFUCacheEvictSimulate(t *testing.T) {
 		index++
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheRangeOrder$
+func TestLFUCacheRangeOrder(t *testing.T) {
+	cache := newTestLFUCache()
+	cache.rangeInEvictionOrder = true
+
+	for i := maxTestEntries - 1; i >= 0; i-- {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+
+		for j := 0; j < i; j++ {
+			cache.Get(data)
+		}
+	}
+
+	keys := cache.Keys()
+	for i, key := range keys {
+		if key != strconv.Itoa(i) {
+			t.Fatalf("key %s != %d", key, i)
+		}
+	}
+
+	if len(keys) != maxTestEntries {
+		t.Fatalf("len(keys) %d != maxTestEntries %d", len(keys), maxTestEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheMaxCost$
+func TestLFUCacheMaxCost(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxCost = 10
+	conf.weigher = func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := newLFUCache(conf).(*lfuCache)
+	cache.Set("1", "1234", NoTTL)
+	cache.Set("2", "1234", NoTTL)
+
+	if cache.Cost() != 8 {
+		t.Fatalf("cache.Cost() %d != 8", cache.Cost())
+	}
+
+	// The oldest entry should be evicted to make room for the new one.
+	evictedValue := cache.Set("3", "1234", NoTTL)
+	if evictedValue == nil || cache.Cost() != 8 || cache.Size() != 2 {
+		t.Fatalf("evictedValue %+v, cache.Cost() %d, cache.Size() %d is wrong", evictedValue, cache.Cost(), cache.Size())
+	}
+
+	// Replacing a value changes the cost, and entries are evicted if it exceeds.
+	cache.Set("3", "1", NoTTL)
+	if cache.Cost() != 5 {
+		t.Fatalf("cache.Cost() %d != 5", cache.Cost())
+	}
+
+	cache.Set("3", "12345678", NoTTL)
+	if cache.Cost() != 8 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Remove("3")
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+
+	// An entry costing more than maxCost still can be set after evicting all others.
+	cache.Set("1", "1", NoTTL)
+	cache.Set("big", "12345678901", NoTTL)
+
+	if cache.Cost() != 11 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Reset()
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheTouch$
+func TestLFUCacheTouch(t *testing.T) {
+	cache := newTestLFUCache()
+	cache.Set("key", "value", NoTTL)
+
+	cache.Expire("key", time.Minute)
+	if weight := cache.itemMap["key"].Weight(); weight != 0 {
+		t.Fatalf("weight %d != 0", weight)
+	}
+
+	// Touch should update the frequency, but Expire shouldn't.
+	cache.Touch("key", time.Minute)
+	if weight := cache.itemMap["key"].Weight(); weight != 1 {
+		t.Fatalf("weight %d != 1", weight)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLFUCacheMaxCostOnly$
+func TestLFUCacheMaxCostOnly(t *testing.T) {
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := NewCache(WithLFU(0), WithMaxCost(10), WithWeigher(weigher))
+	defer cache.Close()
+
+	for i := 0; i < 10; i++ {
+		cache.Set(strconv.Itoa(i), "1234", NoTTL)
+	}
+
+	if cache.Cost() != 8 || cache.Size() != 2 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	sharding := NewCache(WithLFU(0), WithMaxCost(40), WithWeigher(weigher), WithShardings(4))
+	defer sharding.Close()
+
+	for i := 0; i < 100; i++ {
+		sharding.Set(strconv.Itoa(i), "1234", NoTTL)
+	}
+
+	if sharding.Cost() > 40 {
+		t.Fatalf("sharding.Cost() %d > 40", sharding.Cost())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewLFUCacheWithoutLimit$
+func TestNewLFUCacheWithoutLimit(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("new lfu cache without limit should panic")
+		}
+	}()
+
+	NewCache(WithLFU(0))
+}
@@ -15,33 +15,73 @@
 package cachego
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	flight "github.com/FishGoddess/cachego/pkg/singleflight"
 )
 
+var errNilLoad = errors.New("cachego: load function is nil in loader")
+
+// typedLoader loads values from somewhere.
+type typedLoader[K comparable, V any] struct {
+	group   *flight.TypedGroup[K, V]
+	timeout time.Duration
+}
+
 // loader loads values from somewhere.
-type loader struct {
-	group *flight.Group
+type loader = typedLoader[string, interface{}]
+
+// loadableCache is a cache having its own loader.
+type loadableCache interface {
+	// loaderOf returns the loader used by Load of the cache.
+	loaderOf() *loader
+}
+
+// keyLoadableCache is a cache finding the loader used by Load of a key, such as sharding caches and wrappers of caches.
+type keyLoadableCache interface {
+	// loaderOfKey returns the loader used by Load of key, or nil if not found.
+	loaderOfKey(key string) *loader
+}
+
+// loaderOfKey returns the loader used by Load of key in cache, or nil if cache doesn't have one.
+func loaderOfKey(cache Cache, key string) *loader {
+	switch c := cache.(type) {
+	case loadableCache:
+		return c.loaderOf()
+	case keyLoadableCache:
+		return c.loaderOfKey(key)
+	default:
+		return nil
+	}
+}
+
+// newLoader creates a loader with conf.
+// It also creates a singleflight group to call load if singleflight is true.
+func newLoader(conf *config) *loader {
+	return newTypedLoader[string, interface{}](conf)
 }
 
-// newLoader creates a loader.
+// newTypedLoader creates a typed loader with conf.
 // It also creates a singleflight group to call load if singleflight is true.
-func newLoader(singleflight bool) *loader {
-	loader := new(loader)
+func newTypedLoader[K comparable, V any](conf *config) *typedLoader[K, V] {
+	loader := &typedLoader[K, V]{
+		timeout: conf.loadTimeout,
+	}
 
-	if singleflight {
-		loader.group = flight.NewGroup(mapInitialCap)
+	if conf.singleflight {
+		loader.group = flight.NewTypedGroup[K, V](mapInitialCap)
+		loader.group.SetCancelAbandoned(conf.cancelAbandonedLoad)
 	}
 
 	return loader
 }
 
 // Load loads a value of key with ttl and returns an error if failed.
-func (l *loader) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+func (l *typedLoader[K, V]) Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error) {
 	if load == nil {
-		return nil, errors.New("cachego: load function is nil in loader")
+		return value, errNilLoad
 	}
 
 	if l.group == nil {
@@ -51,8 +91,69 @@ func (l *loader) Load(key string, ttl time.Duration, load func() (value interfac
 	return l.group.Call(key, load)
 }
 
+// LoadContext loads a value of key with ctx and returns an error if failed.
+// If singleflight is enabled, it returns right away if ctx is done, and the load function keeps going for other callers.
+// Both ctx and the context passed to load will be timeout if timeout of loader is set.
+func (l *typedLoader[K, V]) LoadContext(ctx context.Context, key K, load func(ctx context.Context) (value V, err error)) (value V, err error) {
+	if load == nil {
+		return value, errNilLoad
+	}
+
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+
+		timeoutLoad := load
+		load = func(ctx context.Context) (value V, err error) {
+			ctx, cancel := context.WithTimeout(ctx, l.timeout)
+			defer cancel()
+
+			return timeoutLoad(ctx)
+		}
+	}
+
+	if l.group == nil {
+		return load(ctx)
+	}
+
+	return l.group.CallContext(ctx, key, load)
+}
+
+// LoadMulti loads values of keys by calling load once and returns an error if failed.
+// Keys being loaded by others won't be loaded again if singleflight is enabled.
+func (l *typedLoader[K, V]) LoadMulti(keys []K, load func(keys []K) (values map[K]V, err error)) (values map[K]V, err error) {
+	if load == nil {
+		return nil, errNilLoad
+	}
+
+	if l.group == nil {
+		return load(keys)
+	}
+
+	return l.group.CallMulti(keys, load)
+}
+
+// loadContext loads key with ctx by loader and sets the loaded value to cache with ttl.
+// The value is set in the load function, so it will be set even if the caller leaves.
+func loadContext(ctx context.Context, cache Cache, loader *loader, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	if load == nil {
+		return nil, errNilLoad
+	}
+
+	return loader.LoadContext(ctx, key, func(ctx context.Context) (value interface{}, err error) {
+		value, err = load(ctx)
+		if err != nil {
+			return value, err
+		}
+
+		cache.Set(key, value, ttl)
+		return value, nil
+	})
+}
+
 // Reset resets loader to initial status which is like a new loader.
-func (l *loader) Reset() {
+func (l *typedLoader[K, V]) Reset() {
 	if l.group != nil {
 		l.group.Reset()
 	}
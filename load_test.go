@@ -15,6 +15,7 @@
 package cachego
 
 import (
+	"context"
 	"strconv"
 	"sync"
 	"testing"
@@ -31,7 +32,7 @@ type testLoadCache struct {
 
 func newTestLoadCache(singleflight bool) Cache {
 	cache := &testLoadCache{
-		loader: newLoader(singleflight),
+		loader: newLoader(&config{singleflight: singleflight}),
 	}
 
 	return cache
@@ -63,18 +64,94 @@ func (tlc *testLoadCache) GC() (cleans int) {
 
 func (tlc *testLoadCache) Reset() {}
 
+func (tlc *testLoadCache) Close() error {
+	return nil
+}
+
+func (tlc *testLoadCache) TTL(key string) (ttl time.Duration, found bool) {
+	return 0, false
+}
+
+func (tlc *testLoadCache) Touch(key string, ttl time.Duration) (found bool) {
+	return false
+}
+
+func (tlc *testLoadCache) Expire(key string, ttl time.Duration) (found bool) {
+	return false
+}
+
+func (tlc *testLoadCache) Persist(key string) (found bool) {
+	return false
+}
+
+func (tlc *testLoadCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	return nil, false
+}
+
+func (tlc *testLoadCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	return nil, false
+}
+
+func (tlc *testLoadCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	return false
+}
+
+func (tlc *testLoadCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	return nil, false
+}
+
+func (tlc *testLoadCache) GetAndRemove(key string) (value interface{}, found bool) {
+	return nil, false
+}
+
+func (tlc *testLoadCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	return 0
+}
+
+func (tlc *testLoadCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return 0
+}
+
+func (tlc *testLoadCache) Cost() (cost int64) {
+	return 0
+}
+
+func (tlc *testLoadCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return nil, nil
+}
+
+func (tlc *testLoadCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {}
+
+func (tlc *testLoadCache) Keys() (keys []string) {
+	return nil
+}
+
+func (tlc *testLoadCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	return nil, keys
+}
+
+func (tlc *testLoadCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {}
+
+func (tlc *testLoadCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	return nil
+}
+
+func (tlc *testLoadCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return nil, nil
+}
+
 func (tlc *testLoadCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
 	return tlc.loader.Load(key, ttl, load)
 }
 
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestNewLoader$
 func TestNewLoader(t *testing.T) {
-	loader := newLoader(false)
+	loader := newLoader(&config{singleflight: false})
 	if loader.group != nil {
 		t.Fatalf("loader.group %+v != nil", loader.group)
 	}
 
-	loader = newLoader(true)
+	loader = newLoader(&config{singleflight: true})
 	if loader.group == nil {
 		t.Fatal("loader.group == nil")
 	}
@@ -148,3 +225,74 @@ func TestLoaderLoad(t *testing.T) {
 		t.Fatalf("loadCount %d != 1", loadCount)
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLoaderLoadMulti$
+func TestLoaderLoadMulti(t *testing.T) {
+	for _, singleflight := range []bool{false, true} {
+		loader := newLoader(&config{singleflight: singleflight})
+
+		if _, err := loader.LoadMulti([]string{"key"}, nil); err == nil {
+			t.Fatal("loading with a nil load function should return an error")
+		}
+
+		values, err := loader.LoadMulti([]string{"key1", "key2"}, func(keys []string) (map[string]interface{}, error) {
+			return map[string]interface{}{"key1": 1}, nil
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(values) != 1 || values["key1"].(int) != 1 {
+			t.Fatalf("values %+v is wrong", values)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLoaderLoadContext$
+func TestLoaderLoadContext(t *testing.T) {
+	loader := newLoader(&config{singleflight: true, loadTimeout: 10 * time.Millisecond})
+
+	if _, err := loader.LoadContext(context.Background(), "key", nil); err == nil {
+		t.Fatal("loading with a nil load function should return an error")
+	}
+
+	value, err := loader.LoadContext(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		return "value", nil
+	})
+
+	if err != nil || value.(string) != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	// The load function should be timeout, too.
+	loadErr := make(chan error, 1)
+	_, err = loader.LoadContext(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		loadErr <- ctx.Err()
+		return nil, ctx.Err()
+	})
+
+	if err != context.DeadlineExceeded {
+		t.Fatalf("err %+v != context.DeadlineExceeded", err)
+	}
+
+	select {
+	case err = <-loadErr:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("err %+v != context.DeadlineExceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("load function should be timeout")
+	}
+
+	loader = newLoader(&config{singleflight: false})
+
+	value, err = loader.LoadContext(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		return "value", nil
+	})
+
+	if err != nil || value.(string) != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+}
@@ -16,37 +16,55 @@ package cachego
 
 import (
 	"container/list"
+	"context"
 	"sync"
 	"time"
 )
 
-type lruCache struct {
+// typedLRUCache is the core of lru caches whose keys are in type K and values are in type V.
+type typedLRUCache[K comparable, V any] struct {
 	*config
 
-	elementMap  map[string]*list.Element
+	elementMap  map[K]*list.Element
 	elementList *list.List
+	expirations *typedExpirationIndex[K]
+	cost        int64
 	lock        sync.RWMutex
 
-	loader *loader
+	loader *typedLoader[K, V]
 }
 
-func newLRUCache(conf *config) Cache {
-	if conf.maxEntries <= 0 {
-		panic("cachego: lru cache must specify max entries")
+func newTypedLRUCache[K comparable, V any](conf *config) *typedLRUCache[K, V] {
+	if conf.maxEntries <= 0 && conf.maxCost <= 0 {
+		panic("cachego: lru cache must specify max entries or max cost")
 	}
 
-	cache := &lruCache{
+	cache := &typedLRUCache[K, V]{
 		config:      conf,
-		elementMap:  make(map[string]*list.Element, mapInitialCap),
+		elementMap:  make(map[K]*list.Element, mapInitialCap),
 		elementList: list.New(),
-		loader:      newLoader(conf.singleflight),
+		expirations: newTypedExpirationIndex[K](conf),
+		loader:      newTypedLoader[K, V](conf),
 	}
 
 	return cache
 }
 
-func (lc *lruCache) unwrap(element *list.Element) *entry {
-	entry, ok := element.Value.(*entry)
+// lruCache is the lru cache whose keys are strings and values are in any types.
+type lruCache struct {
+	*typedLRUCache[string, interface{}]
+}
+
+func newLRUCache(conf *config) Cache {
+	cache := &lruCache{
+		typedLRUCache: newTypedLRUCache[string, interface{}](conf),
+	}
+
+	return cache
+}
+
+func (tlc *typedLRUCache[K, V]) unwrap(element *list.Element) *typedEntry[K, V] {
+	entry, ok := element.Value.(*typedEntry[K, V])
 	if !ok {
 		panic("cachego: failed to unwrap lru element's value to entry")
 	}
@@ -54,83 +72,139 @@ func (lc *lruCache) unwrap(element *list.Element) *entry {
 	return entry
 }
 
-func (lc *lruCache) evict() (evictedValue interface{}) {
-	if element := lc.elementList.Back(); element != nil {
-		return lc.removeElement(element)
+func (tlc *typedLRUCache[K, V]) evict() (evictedValue V, evicted bool) {
+	if element := tlc.elementList.Back(); element != nil {
+		return tlc.removeElement(element, RemovalCapacity), true
 	}
 
-	return nil
+	return evictedValue, false
 }
 
-func (lc *lruCache) get(key string) (value interface{}, found bool) {
-	element, ok := lc.elementMap[key]
+func (tlc *typedLRUCache[K, V]) get(key K) (value V, found bool) {
+	element, ok := tlc.elementMap[key]
 	if !ok {
-		return nil, false
+		return value, false
 	}
 
-	entry := lc.unwrap(element)
+	entry := tlc.unwrap(element)
 	if entry.expired(0) {
-		return nil, false
+		return value, false
 	}
 
-	lc.elementList.MoveToFront(element)
+	entry.slide()
+	tlc.elementList.MoveToFront(element)
+
 	return entry.value, true
 }
 
-func (lc *lruCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
-	element, ok := lc.elementMap[key]
+// evictByCost evicts entries until cost can be added without exceeding maxCost or size reaches minSize.
+func (tlc *typedLRUCache[K, V]) evictByCost(cost int64, minSize int) (evictedValue V, evicted bool) {
+	for tlc.maxCost > 0 && tlc.size() > minSize && tlc.cost+cost > tlc.maxCost {
+		evictedValue, evicted = tlc.evict()
+	}
+
+	return evictedValue, evicted
+}
+
+func (tlc *typedLRUCache[K, V]) set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	cost := weighTyped(tlc.config, key, value)
+
+	element, ok := tlc.elementMap[key]
 	if ok {
-		entry := lc.unwrap(element)
+		entry := tlc.unwrap(element)
+		notifyTypedEvicted(tlc.config, key, entry.value, replacedCause(entry))
 		entry.setup(key, value, ttl)
+		tlc.setupSliding(entry, ttl)
+		tlc.updateExpiration(entry)
 
-		lc.elementList.MoveToFront(element)
-		return nil
+		tlc.cost += cost - entry.cost
+		entry.cost = cost
+
+		tlc.elementList.MoveToFront(element)
+		return tlc.evictByCost(0, 1)
+	}
+
+	if tlc.maxEntries > 0 && tlc.elementList.Len() >= tlc.maxEntries {
+		evictedValue, evicted = tlc.evict()
 	}
 
-	if lc.maxEntries > 0 && lc.elementList.Len() >= lc.maxEntries {
-		evictedValue = lc.evict()
+	if value, ok := tlc.evictByCost(cost, 0); ok {
+		evictedValue, evicted = value, true
 	}
 
-	element = lc.elementList.PushFront(newEntry(key, value, ttl, lc.now))
-	lc.elementMap[key] = element
+	entry := newTypedEntry(key, value, ttl, tlc.now)
+	tlc.setupSliding(entry, ttl)
+	entry.cost = cost
 
-	return evictedValue
+	element = tlc.elementList.PushFront(entry)
+	tlc.elementMap[key] = element
+	tlc.cost += cost
+	tlc.updateExpiration(entry)
+
+	return evictedValue, evicted
 }
 
-func (lc *lruCache) removeElement(element *list.Element) (removedValue interface{}) {
-	entry := lc.unwrap(element)
+func (tlc *typedLRUCache[K, V]) updateExpiration(entry *typedEntry[K, V]) {
+	if tlc.expirations != nil {
+		tlc.expirations.update(entry.key, entry.expiration)
+	}
+}
+
+func (tlc *typedLRUCache[K, V]) removeElement(element *list.Element, cause RemovalCause) (removedValue V) {
+	entry := tlc.unwrap(element)
 
-	delete(lc.elementMap, entry.key)
-	lc.elementList.Remove(element)
+	delete(tlc.elementMap, entry.key)
+	tlc.elementList.Remove(element)
+	tlc.cost -= entry.cost
+
+	if tlc.expirations != nil {
+		tlc.expirations.remove(entry.key)
+	}
 
+	notifyTypedEvicted(tlc.config, entry.key, entry.value, cause)
 	return entry.value
 }
 
-func (lc *lruCache) remove(key string) (removedValue interface{}) {
-	if element, ok := lc.elementMap[key]; ok {
-		return lc.removeElement(element)
+func (tlc *typedLRUCache[K, V]) remove(key K) (removedValue V, removed bool) {
+	if element, ok := tlc.elementMap[key]; ok {
+		return tlc.removeElement(element, RemovalExplicit), true
 	}
 
-	return nil
+	return removedValue, false
 }
 
-func (lc *lruCache) size() (size int) {
-	return len(lc.elementMap)
+func (tlc *typedLRUCache[K, V]) size() (size int) {
+	return len(tlc.elementMap)
 }
 
-func (lc *lruCache) gc() (cleans int) {
-	now := lc.now()
+func (tlc *typedLRUCache[K, V]) gc() (cleans int) {
+	now := tlc.now()
+
+	if tlc.expirations != nil {
+		return tlc.expirations.clean(now, func(key K) bool {
+			element := tlc.elementMap[key]
+			if entry := tlc.unwrap(element); !entry.expired(now) {
+				// The expiration of entry may slide after indexing.
+				tlc.updateExpiration(entry)
+				return false
+			}
+
+			tlc.removeElement(element, RemovalExpired)
+			return true
+		})
+	}
+
 	scans := 0
 
-	for _, element := range lc.elementMap {
+	for _, element := range tlc.elementMap {
 		scans++
 
-		if entry := lc.unwrap(element); entry.expired(now) {
-			lc.removeElement(element)
+		if entry := tlc.unwrap(element); entry.expired(now) {
+			tlc.removeElement(element, RemovalExpired)
 			cleans++
 		}
 
-		if lc.maxScans > 0 && scans >= lc.maxScans {
+		if tlc.maxScans > 0 && scans >= tlc.maxScans {
 			break
 		}
 	}
@@ -138,20 +212,101 @@ func (lc *lruCache) gc() (cleans int) {
 	return cleans
 }
 
-func (lc *lruCache) reset() {
-	lc.elementMap = make(map[string]*list.Element, mapInitialCap)
-	lc.elementList = list.New()
+func (tlc *typedLRUCache[K, V]) reset() {
+	if tlc.onEvicted != nil {
+		for _, element := range tlc.elementMap {
+			entry := tlc.unwrap(element)
+			notifyTypedEvicted(tlc.config, entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	tlc.elementMap = make(map[K]*list.Element, mapInitialCap)
+	tlc.elementList = list.New()
+	tlc.cost = 0
+
+	if tlc.expirations != nil {
+		tlc.expirations.reset()
+	}
 
-	lc.loader.Reset()
+	tlc.loader.Reset()
 }
 
-// Get gets the value of key from cache and returns value if found.
-// See Cache interface.
-func (lc *lruCache) Get(key string) (value interface{}, found bool) {
-	lc.lock.Lock()
-	defer lc.lock.Unlock()
+func (tlc *typedLRUCache[K, V]) loaderOf() *typedLoader[K, V] {
+	return tlc.loader
+}
 
-	return lc.get(key)
+// entryOf returns the unexpired entry of key or nil if not found.
+func (tlc *typedLRUCache[K, V]) entryOf(key K) *typedEntry[K, V] {
+	element, ok := tlc.elementMap[key]
+	if !ok {
+		return nil
+	}
+
+	if entry := tlc.unwrap(element); !entry.expired(0) {
+		return entry
+	}
+
+	return nil
+}
+
+func (tlc *typedLRUCache[K, V]) expire(key K, ttl time.Duration) (found bool) {
+	entry := tlc.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	tlc.updateExpiration(entry)
+	return true
+}
+
+func (lc *lruCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	evictedValue, _ = lc.typedLRUCache.set(key, value, ttl)
+	return evictedValue
+}
+
+func (lc *lruCache) remove(key string) (removedValue interface{}) {
+	removedValue, _ = lc.typedLRUCache.remove(key)
+	return removedValue
+}
+
+// snapshot returns all unexpired entries from the most recently used one to the least recently used one.
+// The order will be reversed if evictionOrder is true.
+func (lc *lruCache) snapshot(evictionOrder bool) []DumpEntry {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+
+	now := lc.now()
+	entries := make([]DumpEntry, 0, lc.size())
+
+	element, next := lc.elementList.Front(), (*list.Element).Next
+	if evictionOrder {
+		element, next = lc.elementList.Back(), (*list.Element).Prev
+	}
+
+	for ; element != nil; element = next(element) {
+		if dumpEntry, ok := newDumpEntry(lc.unwrap(element), now); ok {
+			entries = append(entries, dumpEntry)
+		}
+	}
+
+	return entries
+}
+
+func (lc *lruCache) dump() []DumpEntry {
+	// The least recently used entries should be restored first.
+	return lc.snapshot(true)
+}
+
+func (lc *lruCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
+
+	if entry := lc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(lc.now()), true
+	}
+
+	return nil, 0, false
 }
 
 // Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
@@ -172,41 +327,163 @@ func (lc *lruCache) Remove(key string) (removedValue interface{}) {
 	return lc.remove(key)
 }
 
-// Size returns the count of keys in cache.
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
 // See Cache interface.
-func (lc *lruCache) Size() (size int) {
+func (lc *lruCache) TTL(key string) (ttl time.Duration, found bool) {
 	lc.lock.RLock()
 	defer lc.lock.RUnlock()
 
-	return lc.size()
+	if entry := lc.entryOf(key); entry != nil {
+		return entry.ttl(lc.now()), true
+	}
+
+	return 0, false
 }
 
-// GC cleans the expired keys in cache and returns the exact count cleaned.
+// Touch resets the ttl of key and accesses key like Get.
 // See Cache interface.
-func (lc *lruCache) GC() (cleans int) {
+func (lc *lruCache) Touch(key string, ttl time.Duration) (found bool) {
 	lc.lock.Lock()
 	defer lc.lock.Unlock()
 
-	return lc.gc()
+	if _, found = lc.get(key); !found {
+		return false
+	}
+
+	return lc.expire(key, ttl)
 }
 
-// Reset resets cache to initial status which is like a new cache.
+// Expire resets the ttl of key without accessing key.
 // See Cache interface.
-func (lc *lruCache) Reset() {
+func (lc *lruCache) Expire(key string, ttl time.Duration) (found bool) {
 	lc.lock.Lock()
 	defer lc.lock.Unlock()
 
-	lc.reset()
+	return lc.expire(key, ttl)
 }
 
-// Load loads a value by load function and sets it to cache.
-// Returns an error if load failed.
-func (lc *lruCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
-	value, err = lc.loader.Load(key, ttl, load)
-	if err != nil {
-		return value, err
-	}
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (lc *lruCache) Persist(key string) (found bool) {
+	return lc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (lc *lruCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getOrSet(lc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (lc *lruCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return setIfAbsent(lc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (lc *lruCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return compareAndSwap(lc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (lc *lruCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return update(lc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (lc *lruCache) GetAndRemove(key string) (value interface{}, found bool) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getAndRemove(lc, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (lc *lruCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return incr(lc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (lc *lruCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return lc.Incr(key, -delta, ttl)
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (lc *lruCache) Cost() (cost int64) {
+	lc.lock.RLock()
+	defer lc.lock.RUnlock()
 
-	lc.Set(key, value, ttl)
-	return value, nil
+	return lc.cost
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (lc *lruCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(lc.snapshot(lc.rangeInEvictionOrder), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (lc *lruCache) Keys() (keys []string) {
+	return keysOf(lc.snapshot(lc.rangeInEvictionOrder))
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (lc *lruCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, lc, lc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (lc *lruCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return getMulti(keys, lc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (lc *lruCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	setMulti(entries, ttl, lc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (lc *lruCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	lc.lock.Lock()
+	defer lc.lock.Unlock()
+
+	return removeMulti(keys, lc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (lc *lruCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(lc, lc.loader, keys, ttl, load)
 }
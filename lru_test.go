@@ -15,9 +15,11 @@
 package cachego
 
 import (
+	"fmt"
 	"math/rand"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -34,6 +36,40 @@ func TestLRUCache(t *testing.T) {
 	testCacheImplement(t, cache)
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheWithExpirationIndex$
+func TestLRUCacheWithExpirationIndex(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+	conf.expirationIndex = true
+
+	cache := newLRUCache(conf).(*lruCache)
+	testCacheImplement(t, cache)
+
+	// All entries with ttl are expired, so gc should clean up the index.
+	cache.GC()
+
+	if cache.expirations.itemHeap.Size() != 0 {
+		t.Fatalf("cache.expirations.itemHeap.Size() %d != 0", cache.expirations.itemHeap.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheSlidingExpiration$
+func TestLRUCacheSlidingExpiration(t *testing.T) {
+	for _, expirationIndex := range []bool{false, true} {
+		var clock atomic.Int64
+
+		conf := newDefaultConfig()
+		conf.maxEntries = maxTestEntries
+		conf.expirationIndex = expirationIndex
+		conf.slidingExpiration = true
+		conf.maxLifetime = 25
+		conf.now = clock.Load
+
+		cache := newLRUCache(conf)
+		testCacheSlidingExpiration(t, cache, &clock)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheEvict$
 func TestLRUCacheEvict(t *testing.T) {
 	cache := newTestLRUCache()
@@ -136,3 +172,153 @@ func TestLRUCacheEvictSimulate(t *testing.T) {
 		}
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheRangeOrder$
+func TestLRUCacheRangeOrder(t *testing.T) {
+	cache := newTestLRUCache()
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	cache.Get("0")
+
+	expect := []string{"0", "9", "8", "7", "6", "5", "4", "3", "2", "1"}
+	if keys := cache.Keys(); fmt.Sprint(keys) != fmt.Sprint(expect) {
+		t.Fatalf("keys %+v != expect %+v", keys, expect)
+	}
+
+	cache.rangeInEvictionOrder = true
+
+	expect = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "0"}
+	if keys := cache.Keys(); fmt.Sprint(keys) != fmt.Sprint(expect) {
+		t.Fatalf("keys %+v != expect %+v", keys, expect)
+	}
+
+	var keys []string
+	cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	if fmt.Sprint(keys) != fmt.Sprint(expect) {
+		t.Fatalf("keys %+v != expect %+v", keys, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheMaxCost$
+func TestLRUCacheMaxCost(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxCost = 10
+	conf.weigher = func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := newLRUCache(conf).(*lruCache)
+	cache.Set("1", "1234", NoTTL)
+	cache.Set("2", "1234", NoTTL)
+
+	if cache.Cost() != 8 {
+		t.Fatalf("cache.Cost() %d != 8", cache.Cost())
+	}
+
+	// The oldest entry should be evicted to make room for the new one.
+	evictedValue := cache.Set("3", "1234", NoTTL)
+	if evictedValue == nil || cache.Cost() != 8 || cache.Size() != 2 {
+		t.Fatalf("evictedValue %+v, cache.Cost() %d, cache.Size() %d is wrong", evictedValue, cache.Cost(), cache.Size())
+	}
+
+	// Replacing a value changes the cost, and entries are evicted if it exceeds.
+	cache.Set("3", "1", NoTTL)
+	if cache.Cost() != 5 {
+		t.Fatalf("cache.Cost() %d != 5", cache.Cost())
+	}
+
+	cache.Set("3", "12345678", NoTTL)
+	if cache.Cost() != 8 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Remove("3")
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+
+	// An entry costing more than maxCost still can be set after evicting all others.
+	cache.Set("1", "1", NoTTL)
+	cache.Set("big", "12345678901", NoTTL)
+
+	if cache.Cost() != 11 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Reset()
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheTouch$
+func TestLRUCacheTouch(t *testing.T) {
+	cache := newTestLRUCache()
+
+	for i := 0; i < cache.maxEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	// Touch should update the recency, but Expire shouldn't.
+	cache.Touch("0", time.Minute)
+	cache.Expire("1", time.Minute)
+
+	cache.Set("new", "new", NoTTL)
+
+	if _, found := cache.Get("0"); !found {
+		t.Fatal("touched key 0 is evicted")
+	}
+
+	if _, found := cache.Get("1"); found {
+		t.Fatal("key 1 should be evicted")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestLRUCacheMaxCostOnly$
+func TestLRUCacheMaxCostOnly(t *testing.T) {
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := NewCache(WithLRU(0), WithMaxCost(10), WithWeigher(weigher))
+	defer cache.Close()
+
+	for i := 0; i < 10; i++ {
+		cache.Set(strconv.Itoa(i), "1234", NoTTL)
+	}
+
+	if cache.Cost() != 8 || cache.Size() != 2 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	sharding := NewCache(WithLRU(0), WithMaxCost(40), WithWeigher(weigher), WithShardings(4))
+	defer sharding.Close()
+
+	for i := 0; i < 100; i++ {
+		sharding.Set(strconv.Itoa(i), "1234", NoTTL)
+	}
+
+	if sharding.Cost() > 40 {
+		t.Fatalf("sharding.Cost() %d > 40", sharding.Cost())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewLRUCacheWithoutLimit$
+func TestNewLRUCacheWithoutLimit(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("new lru cache without limit should panic")
+		}
+	}()
+
+	NewCache(WithLRU(0))
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"time"
+)
+
+// getMulti gets the values of keys by get and returns the missed keys.
+func getMulti(keys []string, get func(key string) (value interface{}, found bool)) (values map[string]interface{}, missedKeys []string) {
+	values = make(map[string]interface{}, len(keys))
+
+	for _, key := range keys {
+		if value, found := get(key); found {
+			values[key] = value
+		} else {
+			missedKeys = append(missedKeys, key)
+		}
+	}
+
+	return values, missedKeys
+}
+
+// setMulti sets entries with ttl by set.
+func setMulti(entries map[string]interface{}, ttl time.Duration, set func(key string, value interface{}, ttl time.Duration) (evictedValue interface{})) {
+	for key, value := range entries {
+		set(key, value, ttl)
+	}
+}
+
+// removeMulti removes keys by remove and returns the removed values.
+func removeMulti(keys []string, remove func(key string) (removedValue interface{})) (removedValues map[string]interface{}) {
+	removedValues = make(map[string]interface{}, len(keys))
+
+	for _, key := range keys {
+		if removedValue := remove(key); removedValue != nil {
+			removedValues[key] = removedValue
+		}
+	}
+
+	return removedValues
+}
+
+// loadMulti gets keys from cache and loads the missed keys by loader with ttl to cache.
+func loadMulti(cache Cache, loader *loader, keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	values, missedKeys := cache.GetMulti(keys)
+	if len(missedKeys) == 0 {
+		return values, nil
+	}
+
+	loadedValues, err := loader.LoadMulti(missedKeys, load)
+	cache.SetMulti(loadedValues, ttl)
+
+	for key, value := range loadedValues {
+		values[key] = value
+	}
+
+	return values, err
+}
@@ -0,0 +1,458 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// negativeCost is the cost of a negative entry, which won't be weighed by weigher.
+const negativeCost = 1
+
+// negativeEntry is the sentinel value of a negative entry storing a load error.
+type negativeEntry struct {
+	err error
+}
+
+// negativeError is the error returned by loading a negative entry.
+// It unwraps to the cached error, so errors.Is works with it.
+type negativeError struct {
+	err error
+}
+
+func (ne *negativeError) Error() string {
+	return ne.err.Error()
+}
+
+func (ne *negativeError) Unwrap() error {
+	return ne.err
+}
+
+// isNegativeHit returns if err is returned by loading a negative entry.
+func isNegativeHit(err error) bool {
+	var ne *negativeError
+	return errors.As(err, &ne)
+}
+
+// negativeCache stores negative entries of load errors for negativeTTL,
+// so loading the same key again returns the cached error without calling load function.
+type negativeCache struct {
+	*config
+
+	cache Cache
+}
+
+func newNegativeCache(conf *config, cache Cache) Cache {
+	nc := &negativeCache{
+		config: conf,
+		cache:  cache,
+	}
+
+	return nc
+}
+
+// negativeOf returns the negative entry of key without accessing key, or nil if key doesn't have one.
+// It uses a compare function which never swaps, because CompareAndSwap doesn't access key.
+func (nc *negativeCache) negativeOf(key string) (entry *negativeEntry) {
+	nc.cache.CompareAndSwap(key, nil, nil, NoTTL, func(old interface{}, current interface{}) bool {
+		entry, _ = current.(*negativeEntry)
+		return false
+	})
+
+	return entry
+}
+
+// negative returns the cached error of key or nil if key doesn't have a negative entry.
+// Key isn't accessed, so a negative entry won't slide its expiration or be promoted by loading it.
+func (nc *negativeCache) negative(key string) error {
+	entry := nc.negativeOf(key)
+	if entry == nil {
+		return nil
+	}
+
+	return &negativeError{err: entry.err}
+}
+
+// hasNegative returns if key has a negative entry without accessing key.
+func (nc *negativeCache) hasNegative(key string) bool {
+	return nc.negativeOf(key) != nil
+}
+
+// storeNegative stores a negative entry of key if err is negative and key doesn't have a value.
+// The value of key is kept, so a failed refresh won't replace the stale value.
+func (nc *negativeCache) storeNegative(key string, err error) {
+	if nc.isNegative != nil && !nc.isNegative(err) {
+		return
+	}
+
+	nc.cache.SetIfAbsent(key, &negativeEntry{err: err}, nc.negativeTTL)
+}
+
+func (nc *negativeCache) loaderOfKey(key string) *loader {
+	return loaderOfKey(nc.cache, key)
+}
+
+func (nc *negativeCache) dump() []DumpEntry {
+	dc, ok := nc.cache.(dumpableCache)
+	if !ok {
+		return nil
+	}
+
+	entries := dc.dump()
+	dumpEntries := entries[:0]
+
+	for _, entry := range entries {
+		if _, ok := entry.Value.(*negativeEntry); !ok {
+			dumpEntries = append(dumpEntries, entry)
+		}
+	}
+
+	return dumpEntries
+}
+
+func (nc *negativeCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	value, ttl, found = Peek(nc.cache, key)
+	if _, ok := value.(*negativeEntry); ok {
+		return nil, 0, false
+	}
+
+	return value, ttl, found
+}
+
+func (nc *negativeCache) restore(entry *DumpEntry) {
+	if restorable, ok := nc.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	nc.cache.Set(entry.Key, entry.Value, entry.TTL)
+}
+
+// Get gets the value of key from cache and returns value if found.
+// A negative entry is treated as not found.
+// See Cache interface.
+func (nc *negativeCache) Get(key string) (value interface{}, found bool) {
+	value, found = nc.cache.Get(key)
+
+	if _, ok := value.(*negativeEntry); ok {
+		return nil, false
+	}
+
+	return value, found
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See Cache interface.
+func (nc *negativeCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	evictedValue = nc.cache.Set(key, value, ttl)
+
+	if _, ok := evictedValue.(*negativeEntry); ok {
+		return nil
+	}
+
+	return evictedValue
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (nc *negativeCache) Remove(key string) (removedValue interface{}) {
+	removedValue = nc.cache.Remove(key)
+
+	if _, ok := removedValue.(*negativeEntry); ok {
+		return nil
+	}
+
+	return removedValue
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// A negative entry is treated as not found.
+// See Cache interface.
+func (nc *negativeCache) TTL(key string) (ttl time.Duration, found bool) {
+	if nc.hasNegative(key) {
+		return 0, false
+	}
+
+	return nc.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// A negative entry is treated as not found and its ttl won't be reset.
+// See Cache interface.
+func (nc *negativeCache) Touch(key string, ttl time.Duration) (found bool) {
+	if nc.hasNegative(key) {
+		return false
+	}
+
+	return nc.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// A negative entry is treated as not found and its ttl won't be reset.
+// See Cache interface.
+func (nc *negativeCache) Expire(key string, ttl time.Duration) (found bool) {
+	if nc.hasNegative(key) {
+		return false
+	}
+
+	return nc.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// A negative entry is treated as not found and its ttl won't be removed.
+// See Cache interface.
+func (nc *negativeCache) Persist(key string) (found bool) {
+	if nc.hasNegative(key) {
+		return false
+	}
+
+	return nc.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// A negative entry is treated as absent and will be replaced by value.
+// See Cache interface.
+func (nc *negativeCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	for {
+		actual, found = nc.cache.GetOrSet(key, value, ttl)
+
+		entry, ok := actual.(*negativeEntry)
+		if !found || !ok {
+			return actual, found
+		}
+
+		// Replace the negative entry only if it isn't changed by others.
+		if nc.cache.CompareAndSwap(key, entry, value, ttl, nil) {
+			return value, false
+		}
+	}
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// A negative entry is treated as absent and will be replaced by value.
+// See Cache interface.
+func (nc *negativeCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	for {
+		actual, stored = nc.cache.SetIfAbsent(key, value, ttl)
+
+		entry, ok := actual.(*negativeEntry)
+		if stored || !ok {
+			return actual, stored
+		}
+
+		// Replace the negative entry only if it isn't changed by others.
+		if nc.cache.CompareAndSwap(key, entry, value, ttl, nil) {
+			return value, true
+		}
+	}
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// A negative entry never equals old.
+// See Cache interface.
+func (nc *negativeCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	if equal == nil {
+		equal = equals
+	}
+
+	return nc.cache.CompareAndSwap(key, old, new, ttl, func(old interface{}, current interface{}) bool {
+		if _, ok := current.(*negativeEntry); ok {
+			return false
+		}
+
+		return equal(old, current)
+	})
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// A negative entry is passed to fn as absent.
+// See Cache interface.
+func (nc *negativeCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	return nc.cache.Update(key, func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool) {
+		if _, ok := old.(*negativeEntry); ok {
+			return fn(nil, false)
+		}
+
+		return fn(old, found)
+	})
+}
+
+// GetAndRemove removes key and returns its value if found.
+// A negative entry is removed and treated as not found.
+// See Cache interface.
+func (nc *negativeCache) GetAndRemove(key string) (value interface{}, found bool) {
+	value, found = nc.cache.GetAndRemove(key)
+
+	if _, ok := value.(*negativeEntry); ok {
+		return nil, false
+	}
+
+	return value, found
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// A negative entry is treated as absent and will be replaced by delta.
+// See Cache interface.
+func (nc *negativeCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	return nc.cache.Incr(key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// A negative entry is treated as absent and will be replaced by delta.
+// See Cache interface.
+func (nc *negativeCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return nc.cache.Decr(key, delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// Notice that negative entries are counted, too.
+// See Cache interface.
+func (nc *negativeCache) Size() (size int) {
+	return nc.cache.Size()
+}
+
+// Cost returns the total cost of entries in cache.
+// Notice that negative entries are counted, too.
+// See Cache interface.
+func (nc *negativeCache) Cost() (cost int64) {
+	return nc.cache.Cost()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (nc *negativeCache) GC() (cleans int) {
+	return nc.cache.GC()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (nc *negativeCache) Reset() {
+	nc.cache.Reset()
+}
+
+// Close closes cache.
+// See Cache interface.
+func (nc *negativeCache) Close() error {
+	return nc.cache.Close()
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// Negative entries are skipped.
+// See Cache interface.
+func (nc *negativeCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	nc.cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		if _, ok := value.(*negativeEntry); ok {
+			return true
+		}
+
+		return fn(key, value, ttl)
+	})
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (nc *negativeCache) Keys() (keys []string) {
+	nc.Range(func(key string, value interface{}, ttl time.Duration) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	return keys
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// It returns the cached error if key has a negative entry, and the error can be checked by errors.Is.
+// See Cache interface.
+func (nc *negativeCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	if err = nc.negative(key); err != nil {
+		return nil, err
+	}
+
+	value, err = nc.cache.Load(key, ttl, load)
+	if err != nil {
+		nc.storeNegative(key, err)
+	}
+
+	return value, err
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// It returns the cached error if key has a negative entry, and the error can be checked by errors.Is.
+// See Cache interface.
+func (nc *negativeCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	if err = nc.negative(key); err != nil {
+		return nil, err
+	}
+
+	value, err = nc.cache.LoadContext(ctx, key, ttl, load)
+	if err != nil && ctx.Err() == nil {
+		nc.storeNegative(key, err)
+	}
+
+	return value, err
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// Negative entries are treated as missed.
+// See Cache interface.
+func (nc *negativeCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	values, missedKeys = nc.cache.GetMulti(keys)
+
+	for key, value := range values {
+		if _, ok := value.(*negativeEntry); ok {
+			delete(values, key)
+			missedKeys = append(missedKeys, key)
+		}
+	}
+
+	return values, missedKeys
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (nc *negativeCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	nc.cache.SetMulti(entries, ttl)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (nc *negativeCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	removedValues = nc.cache.RemoveMulti(keys)
+
+	for key, value := range removedValues {
+		if _, ok := value.(*negativeEntry); ok {
+			delete(removedValues, key)
+		}
+	}
+
+	return removedValues
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// Keys having negative entries won't be loaded or returned.
+// See Cache interface.
+func (nc *negativeCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	values, err = nc.cache.LoadMulti(keys, ttl, load)
+
+	for key, value := range values {
+		if _, ok := value.(*negativeEntry); ok {
+			delete(values, key)
+		}
+	}
+
+	return values, err
+}
@@ -0,0 +1,297 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTestNotFound = errors.New("not found")
+
+func newTestNegativeCache() *negativeCache {
+	conf := newDefaultConfig()
+	conf.negativeTTL = 50 * time.Millisecond
+	conf.isNegative = func(err error) bool {
+		return errors.Is(err, errTestNotFound)
+	}
+
+	return newNegativeCache(conf, newStandardCache(conf)).(*negativeCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCache$
+func TestNegativeCache(t *testing.T) {
+	cache := newTestNegativeCache()
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheLoad$
+func TestNegativeCacheLoad(t *testing.T) {
+	cache := newTestNegativeCache()
+
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return nil, errTestNotFound
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := cache.Load("key", time.Minute, load); !errors.Is(err, errTestNotFound) {
+			t.Fatalf("err %+v isn't errTestNotFound", err)
+		}
+	}
+
+	if loads != 1 {
+		t.Fatalf("loads %d != 1", loads)
+	}
+
+	// Negative entries are not found in Get, Range and Keys.
+	if value, ok := cache.Get("key"); ok {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	if keys := cache.Keys(); len(keys) != 0 {
+		t.Fatalf("keys %+v is wrong", keys)
+	}
+
+	if values, missedKeys := cache.GetMulti([]string{"key"}); len(values) != 0 || len(missedKeys) != 1 {
+		t.Fatalf("values %+v, missedKeys %+v is wrong", values, missedKeys)
+	}
+
+	_, err := cache.LoadContext(context.Background(), "key", time.Minute, func(ctx context.Context) (interface{}, error) {
+		loads++
+		return nil, errTestNotFound
+	})
+
+	if !errors.Is(err, errTestNotFound) || loads != 1 {
+		t.Fatalf("err %+v, loads %d is wrong", err, loads)
+	}
+
+	// Other errors won't be cached.
+	otherErr := errors.New("other")
+	for i := 0; i < 10; i++ {
+		cache.Load("other", time.Minute, func() (interface{}, error) {
+			loads++
+			return nil, otherErr
+		})
+	}
+
+	if loads != 11 {
+		t.Fatalf("loads %d != 11", loads)
+	}
+
+	// Negative entries are expired after negativeTTL.
+	time.Sleep(60 * time.Millisecond)
+
+	value, err := cache.Load("key", time.Minute, func() (interface{}, error) {
+		return "value", nil
+	})
+
+	if err != nil || value.(string) != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	// A load error won't replace the existing value.
+	cache.Load("key", time.Minute, load)
+
+	if value, ok := cache.Get("key"); !ok || value.(string) != "value" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheAtomic$
+func TestNegativeCacheAtomic(t *testing.T) {
+	cache := newTestNegativeCache()
+
+	load := func() (interface{}, error) {
+		return nil, errTestNotFound
+	}
+
+	cache.Load("key", time.Minute, load)
+	if actual, found := cache.GetOrSet("key", 1, NoTTL); found || actual != 1 {
+		t.Fatalf("actual %+v, found %+v is wrong", actual, found)
+	}
+
+	cache.Load("absent", time.Minute, load)
+	if actual, stored := cache.SetIfAbsent("absent", 1, NoTTL); !stored || actual != 1 {
+		t.Fatalf("actual %+v, stored %+v is wrong", actual, stored)
+	}
+
+	cache.Load("missing", time.Minute, load)
+	if cache.CompareAndSwap("missing", nil, 1, NoTTL, func(old interface{}, current interface{}) bool { return true }) {
+		t.Fatal("negative entry shouldn't be swapped")
+	}
+
+	update := func(old interface{}, found bool) (interface{}, time.Duration, bool) {
+		if found || old != nil {
+			t.Fatalf("old %+v, found %+v is wrong", old, found)
+		}
+
+		return 1, NoTTL, true
+	}
+
+	if value, kept := cache.Update("missing", update); !kept || value != 1 {
+		t.Fatalf("value %+v, kept %+v is wrong", value, kept)
+	}
+
+	cache.Load("removed", time.Minute, load)
+	if value, found := cache.GetAndRemove("removed"); found || value != nil {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if _, err := cache.Load("removed", time.Minute, load); !errors.Is(err, errTestNotFound) {
+		t.Fatalf("err %+v isn't errTestNotFound", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheHidden$
+func TestNegativeCacheHidden(t *testing.T) {
+	var evicted []interface{}
+	onEvicted := func(key string, value interface{}, cause RemovalCause) {
+		evicted = append(evicted, value)
+	}
+
+	cache := NewCache(WithGC(0), WithNegativeTTL(time.Millisecond, nil), WithOnEvicted(onEvicted))
+	defer cache.Close()
+
+	load := func() (interface{}, error) {
+		return nil, errTestNotFound
+	}
+
+	cache.Load("key", time.Minute, load)
+
+	if ttl, found := cache.TTL("key"); found {
+		t.Fatalf("ttl %d of negative entry is found", ttl)
+	}
+
+	if cache.Touch("key", time.Minute) || cache.Expire("key", time.Minute) || cache.Persist("key") {
+		t.Fatal("ttl of negative entry is reset")
+	}
+
+	if keys := cache.Keys(); len(keys) != 0 {
+		t.Fatalf("keys %+v contain negative entry", keys)
+	}
+
+	// Negative entries shouldn't be notified when they are expired, replaced or reset.
+	time.Sleep(2 * time.Millisecond)
+	cache.GC()
+
+	cache.Load("key", time.Minute, load)
+	cache.Set("key", "value", NoTTL)
+
+	cache.Load("reset", time.Minute, load)
+	cache.Reset()
+
+	if len(evicted) != 1 || evicted[0] != "value" {
+		t.Fatalf("evicted %+v is wrong", evicted)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheReport$
+func TestNegativeCacheReport(t *testing.T) {
+	cache, reporter := NewCacheWithReport(WithGC(0), WithNegativeTTL(time.Minute, nil))
+
+	for i := 0; i < 10; i++ {
+		cache.Load("key", time.Minute, func() (interface{}, error) {
+			return nil, errTestNotFound
+		})
+	}
+
+	cache.Set("hit", "hit", time.Minute)
+	cache.Get("hit")
+	cache.Get("key")
+
+	if reporter.CountLoad() != 1 {
+		t.Fatalf("reporter.CountLoad() %d != 1", reporter.CountLoad())
+	}
+
+	if reporter.CountNegativeHit() != 9 {
+		t.Fatalf("reporter.CountNegativeHit() %d != 9", reporter.CountNegativeHit())
+	}
+
+	if reporter.CountHit() != 1 || reporter.CountMissed() != 1 {
+		t.Fatalf("reporter.CountHit() %d, reporter.CountMissed() %d is wrong", reporter.CountHit(), reporter.CountMissed())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheWeigh$
+func TestNegativeCacheWeigh(t *testing.T) {
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	isNegative := func(err error) bool {
+		return errors.Is(err, errTestNotFound)
+	}
+
+	for _, opts := range [][]Option{{}, {WithLRU(16)}, {WithShardings(4)}} {
+		opts = append(opts, WithMaxCost(100), WithWeigher(weigher), WithNegativeTTL(time.Minute, isNegative))
+
+		cache := NewCache(opts...)
+		defer cache.Close()
+
+		// A negative entry shouldn't be passed to weigher.
+		cache.Load("key", time.Minute, func() (interface{}, error) {
+			return nil, errTestNotFound
+		})
+
+		if cost := cache.Cost(); cost != negativeCost {
+			t.Fatalf("cost %d != negativeCost %d", cost, negativeCost)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheSliding$
+func TestNegativeCacheSliding(t *testing.T) {
+	isNegative := func(err error) bool {
+		return errors.Is(err, errTestNotFound)
+	}
+
+	cache := NewCache(WithSlidingExpiration(time.Minute), WithNegativeTTL(50*time.Millisecond, isNegative))
+	defer cache.Close()
+
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return nil, errTestNotFound
+	}
+
+	// Loading a negative entry shouldn't slide its expiration.
+	for i := 0; i < 8; i++ {
+		cache.Load("key", time.Minute, load)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if loads < 2 {
+		t.Fatalf("loads %d < 2", loads)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNegativeCacheStoreNegative$
+func TestNegativeCacheStoreNegative(t *testing.T) {
+	cache := newTestNegativeCache()
+
+	// A value set concurrently shouldn't be replaced by a negative entry.
+	cache.Load("key", time.Minute, func() (interface{}, error) {
+		cache.cache.Set("key", "value", NoTTL)
+		return nil, errTestNotFound
+	})
+
+	if value, ok := cache.Get("key"); !ok || value.(string) != "value" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+}
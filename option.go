@@ -31,6 +31,32 @@ func applyOptions(conf *config, opts []Option) {
 	}
 }
 
+// TypedOption applies to typed config and sets some values to typed config.
+type TypedOption[K comparable] func(conf *typedConfig[K])
+
+func (o TypedOption[K]) applyTo(conf *typedConfig[K]) {
+	o(conf)
+}
+
+func applyTypedOptions[K comparable](conf *typedConfig[K], opts []TypedOption[K]) {
+	for _, opt := range opts {
+		opt.applyTo(conf)
+	}
+}
+
+// TieredOption applies to tiered config and sets some values to tiered config.
+type TieredOption func(conf *tieredConfig)
+
+func (o TieredOption) applyTo(conf *tieredConfig) {
+	o(conf)
+}
+
+func applyTieredOptions(conf *tieredConfig, opts []TieredOption) {
+	for _, opt := range opts {
+		opt.applyTo(conf)
+	}
+}
+
 // WithCacheName returns an option setting the cacheName of config.
 func WithCacheName(cacheName string) Option {
 	return func(conf *config) {
@@ -39,7 +65,7 @@ func WithCacheName(cacheName string) Option {
 }
 
 // WithLRU returns an option setting the type of cache to lru.
-// Notice that lru cache must have max entries limit, so you have to specify a maxEntries.
+// Notice that lru cache must have max entries limit or max cost limit, so you have to specify a maxEntries or use WithMaxCost.
 func WithLRU(maxEntries int) Option {
 	return func(conf *config) {
 		conf.cacheType = lru
@@ -48,7 +74,7 @@ func WithLRU(maxEntries int) Option {
 }
 
 // WithLFU returns an option setting the type of cache to lfu.
-// Notice that lfu cache must have max entries limit, so you have to specify a maxEntries.
+// Notice that lfu cache must have max entries limit or max cost limit, so you have to specify a maxEntries or use WithMaxCost.
 func WithLFU(maxEntries int) Option {
 	return func(conf *config) {
 		conf.cacheType = lfu
@@ -56,6 +82,46 @@ func WithLFU(maxEntries int) Option {
 	}
 }
 
+// WithTinyLFU returns an option setting the type of cache to tinylfu.
+// Notice that tinylfu cache must have max entries limit even if max cost is specified, so you have to specify a maxEntries.
+// It doesn't support sliding expiration and expiration index, and creating it with them panics.
+func WithTinyLFU(maxEntries int) Option {
+	return func(conf *config) {
+		conf.cacheType = tinylfu
+		conf.maxEntries = maxEntries
+	}
+}
+
+// WithARC returns an option setting the type of cache to arc.
+// Notice that arc cache must have max entries limit even if max cost is specified, so you have to specify a maxEntries.
+// It doesn't support sliding expiration and expiration index, and creating it with them panics.
+func WithARC(maxEntries int) Option {
+	return func(conf *config) {
+		conf.cacheType = arc
+		conf.maxEntries = maxEntries
+	}
+}
+
+// WithSIEVE returns an option setting the type of cache to sieve.
+// Notice that sieve cache must have max entries limit even if max cost is specified, so you have to specify a maxEntries.
+// It doesn't support sliding expiration and expiration index, and creating it with them panics.
+func WithSIEVE(maxEntries int) Option {
+	return func(conf *config) {
+		conf.cacheType = sieve
+		conf.maxEntries = maxEntries
+	}
+}
+
+// WithS3FIFO returns an option setting the type of cache to s3fifo.
+// Notice that s3fifo cache must have max entries limit even if max cost is specified, so you have to specify a maxEntries.
+// It doesn't support sliding expiration and expiration index, and creating it with them panics.
+func WithS3FIFO(maxEntries int) Option {
+	return func(conf *config) {
+		conf.cacheType = s3fifo
+		conf.maxEntries = maxEntries
+	}
+}
+
 // WithShardings returns an option setting the sharding count of cache.
 // Negative value means no sharding.
 func WithShardings(shardings int) Option {
@@ -95,6 +161,201 @@ func WithMaxEntries(maxEntries int) Option {
 	}
 }
 
+// WithMaxCost returns an option setting the maxCost of config.
+// Standard, lru and lfu caches will evict entries until the total cost fits maxCost, and a value <= 0 means no cost limit.
+// Sharding caches give each sharding a proportional share of maxCost.
+// See WithWeigher.
+func WithMaxCost(maxCost int64) Option {
+	return func(conf *config) {
+		conf.maxCost = maxCost
+	}
+}
+
+// WithWeigher returns an option setting the weigher of config.
+// The weigher computes the cost of an entry, such as the bytes of value, and the cost is 1 if weigher is nil.
+// Notice that the weigher is called with the cache locked, so keep it fast.
+func WithWeigher(weigher func(key string, value interface{}) int64) Option {
+	return func(conf *config) {
+		conf.weigher = weigher
+	}
+}
+
+// WithRangeInEvictionOrder returns an option setting the rangeInEvictionOrder of config.
+// Range and Keys of lru and lfu caches will iterate entries in eviction order, which means the first one will be evicted first.
+// Notice that sharding caches only keep this order in each sharding.
+func WithRangeInEvictionOrder() Option {
+	return func(conf *config) {
+		conf.rangeInEvictionOrder = true
+	}
+}
+
+// WithExpirationIndex returns an option setting the expirationIndex of config.
+// Standard, lru and lfu caches will index entries by their expirations in a min-heap, so gc cleans exactly the expired entries
+// without scanning and max scans is ignored. It costs more memory and a little more time in setting entries with ttl.
+// Tinylfu, arc, sieve and s3fifo caches don't support it, and creating them with it panics.
+func WithExpirationIndex() Option {
+	return func(conf *config) {
+		conf.expirationIndex = true
+	}
+}
+
+// WithSlidingExpiration returns an option setting the slidingExpiration and maxLifetime of config.
+// Standard, lru and lfu caches will extend the expiration of an entry by its original ttl after each successful get.
+// The expiration won't exceed maxLifetime since the entry is set if maxLifetime > 0, even if it's reset by Expire or Touch.
+// Entries set with NoTTL are never expired. Notice that the read lock of standard cache is kept because sliding is atomic.
+// Tinylfu, arc, sieve and s3fifo caches don't support it, and creating them with it panics.
+func WithSlidingExpiration(maxLifetime time.Duration) Option {
+	return func(conf *config) {
+		conf.slidingExpiration = true
+		conf.maxLifetime = maxLifetime
+	}
+}
+
+// WithRefreshAhead returns an option setting the refreshWindow of config.
+// Keys loaded by Load will be reloaded in background when they are got in refreshWindow before expired.
+// A failed reload keeps the old value in cache, and it will be reported as a load if you use NewCacheWithReport.
+func WithRefreshAhead(refreshWindow time.Duration) Option {
+	return func(conf *config) {
+		conf.refreshWindow = refreshWindow
+	}
+}
+
+// WithMaxStale returns an option setting the maxStale of config.
+// Keys loaded by Load will be served up to maxStale after expired, and they will be reloaded in background when got.
+func WithMaxStale(maxStale time.Duration) Option {
+	return func(conf *config) {
+		conf.maxStale = maxStale
+	}
+}
+
+// WithLoadTimeout returns an option setting the loadTimeout of config.
+// Both callers and load functions of LoadContext will be timeout after loadTimeout.
+func WithLoadTimeout(loadTimeout time.Duration) Option {
+	return func(conf *config) {
+		conf.loadTimeout = loadTimeout
+	}
+}
+
+// WithCancelAbandonedLoad returns an option setting the cancelAbandonedLoad of config.
+// By default, a load function called by LoadContext keeps going after all its callers leave, so its value can be set to cache.
+// Use this option if you want to cancel the context of load function after all its callers leave.
+// Notice that it only works with singleflight.
+func WithCancelAbandonedLoad() Option {
+	return func(conf *config) {
+		conf.cancelAbandonedLoad = true
+	}
+}
+
+// WithNegativeTTL returns an option setting the negativeTTL and isNegative of config.
+// A load error will be cached for negativeTTL if isNegative returns true, and all load errors will be cached if isNegative is nil.
+// Loading a key which has a cached error returns the error without calling load function, and errors.Is works with it.
+// Entries storing errors are treated as not found by Get, and the reporter counts them as negative hits in Load.
+func WithNegativeTTL(negativeTTL time.Duration, isNegative func(err error) bool) Option {
+	return func(conf *config) {
+		conf.negativeTTL = negativeTTL
+		conf.isNegative = isNegative
+	}
+}
+
+// WithStore returns an option setting the store and storeMode of config.
+// In ReadThrough mode, keys missed by Get and GetMulti will be loaded from store with singleflight, see WithStoreTTL.
+// In WriteThrough mode, keys written to cache will be written to store synchronously, and keys failing to be written are removed from cache.
+// In WriteBehind mode, keys written to cache will be written to store in background, see WithWriteBehind and WithWriteBehindRetry.
+// Pending writes will be flushed when closing cache, so remember to close it.
+func WithStore(store Store, storeMode StoreMode) Option {
+	return func(conf *config) {
+		conf.store = store
+		conf.storeMode = storeMode
+	}
+}
+
+// WithStoreTTL returns an option setting the storeTTL of config.
+// Keys loaded from store in ReadThrough mode will be set to cache with storeTTL, and NoTTL by default.
+func WithStoreTTL(storeTTL time.Duration) Option {
+	return func(conf *config) {
+		conf.storeTTL = storeTTL
+	}
+}
+
+// WithWriteBehind returns an option setting the writeBehindInterval and writeBehindBatch of config.
+// Pending writes will be flushed every writeBehindInterval, or right away if there are writeBehindBatch pending writes.
+// Each flush writes at most writeBehindBatch keys to store in a batch, see BatchStore.
+func WithWriteBehind(writeBehindInterval time.Duration, writeBehindBatch int) Option {
+	return func(conf *config) {
+		if writeBehindInterval > 0 {
+			conf.writeBehindInterval = writeBehindInterval
+		}
+
+		if writeBehindBatch > 0 {
+			conf.writeBehindBatch = writeBehindBatch
+		}
+	}
+}
+
+// WithWriteBehindRetry returns an option setting the writeBehindRetries and writeBehindBackoff of config.
+// A failed write will be retried at most writeBehindRetries times, and the backoff doubles after each retry.
+// A write is discarded after all retries fail, see WithOnStoreError.
+func WithWriteBehindRetry(writeBehindRetries int, writeBehindBackoff time.Duration) Option {
+	return func(conf *config) {
+		conf.writeBehindRetries = writeBehindRetries
+		conf.writeBehindBackoff = writeBehindBackoff
+	}
+}
+
+// WithOnStoreError returns an option setting the onStoreError of config.
+// It will be called with the key and error of every write failing in WriteThrough mode or being discarded in WriteBehind mode,
+// and every read failing in ReadThrough mode.
+func WithOnStoreError(onStoreError func(key string, err error)) Option {
+	return func(conf *config) {
+		conf.onStoreError = onStoreError
+	}
+}
+
+// WithLocalTTL returns a tiered option setting the localTTL of tiered config.
+// Keys in the local cache of tiered cache won't live longer than localTTL, so they won't be too stale after changing in remote tier.
+func WithLocalTTL(localTTL time.Duration) TieredOption {
+	return func(conf *tieredConfig) {
+		conf.localTTL = localTTL
+	}
+}
+
+// WithOnRemoteError returns a tiered option setting the onRemoteError of tiered config.
+// It will be called with the key and error of every remote tier failure ignored by the Load of tiered cache.
+func WithOnRemoteError(onRemoteError func(key string, err error)) TieredOption {
+	return func(conf *tieredConfig) {
+		conf.onRemoteError = onRemoteError
+	}
+}
+
+// WithRemoteCodec returns a tiered option setting the remoteCodec of tiered config.
+// Values will be encoded by remoteCodec before setting to the remote tier of tiered cache, and it's GobCodec by default.
+func WithRemoteCodec(remoteCodec Codec) TieredOption {
+	return func(conf *tieredConfig) {
+		if remoteCodec != nil {
+			conf.remoteCodec = remoteCodec
+		}
+	}
+}
+
+// WithInvalidator returns an option setting the invalidator of config.
+// Cache publishes an invalidation after writing a key, such as Set and Remove, and removes keys invalidated by other caches.
+// Keys loaded by Load or read from store won't be published, and invalidations won't remove keys from store.
+// Publish an invalidation without source by invalidator if you want to remove keys from all caches, including prefixes.
+// Invalidations are unsubscribed when closing cache.
+func WithInvalidator(invalidator Invalidator) Option {
+	return func(conf *config) {
+		conf.invalidator = invalidator
+	}
+}
+
+// WithOnInvalidateError returns an option setting the onInvalidateError of config.
+// It will be called with the key and error of every invalidation failing to publish, such as a dropped one.
+func WithOnInvalidateError(onInvalidateError func(key string, err error)) Option {
+	return func(conf *config) {
+		conf.onInvalidateError = onInvalidateError
+	}
+}
+
 // WithNow returns an option setting the now function of cache.
 // A now function should return a nanosecond unix time.
 func WithNow(now func() int64) Option {
@@ -115,6 +376,32 @@ func WithHash(hash func(key string) int) Option {
 	}
 }
 
+// WithOptions returns a typed option applying opts to the config of typed cache.
+// It's the way to use options like WithLRU in typed caches whose keys are in type K.
+func WithOptions[K comparable](opts ...Option) TypedOption[K] {
+	return func(conf *typedConfig[K]) {
+		applyOptions(conf.config, opts)
+	}
+}
+
+// WithTieredOptions returns a tiered option applying opts to the config of tiered cache.
+// It's the way to use options like WithLoadTimeout and WithDisableSingleflight in tiered caches.
+func WithTieredOptions(opts ...Option) TieredOption {
+	return func(conf *tieredConfig) {
+		applyOptions(conf.config, opts)
+	}
+}
+
+// WithHasher returns a typed option setting the hasher of typed config.
+// A hasher should return the hash code of key, and it replaces the hash function in typed caches whose keys are in type K.
+func WithHasher[K comparable](hasher func(key K) int) TypedOption[K] {
+	return func(conf *typedConfig[K]) {
+		if hasher != nil {
+			conf.hasher = hasher
+		}
+	}
+}
+
 // WithRecordMissed returns an option setting the recordMissed of config.
 func WithRecordMissed(recordMissed bool) Option {
 	return func(conf *config) {
@@ -170,3 +457,14 @@ func WithReportLoad(reportLoad func(reporter *Reporter, key string, value interf
 		conf.reportLoad = reportLoad
 	}
 }
+
+// WithOnEvicted returns an option setting the onEvicted of config.
+// It will be called with the key, value and cause of every entry removed from cache, including expired entries cleaned by GC.
+// Notice that it's called with cache locked, so don't call methods of the same cache in it.
+// It works in caches created by NewCache, NewCacheWithReport, NewBytesCache and typed caches with WithOptions.
+// Keys of typed caches are passed in string form, and values of bytes cache are copies of their []byte values.
+func WithOnEvicted(onEvicted func(key string, value interface{}, cause RemovalCause)) Option {
+	return func(conf *config) {
+		conf.onEvicted = onEvicted
+	}
+}
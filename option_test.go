@@ -15,6 +15,7 @@
 package cachego
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -52,6 +53,50 @@ func TestWithLFU(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithTinyLFU$
+func TestWithTinyLFU(t *testing.T) {
+	got := &config{cacheType: standard, maxEntries: 0}
+	expect := &config{cacheType: tinylfu, maxEntries: 333}
+
+	WithTinyLFU(333).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithARC$
+func TestWithARC(t *testing.T) {
+	got := &config{cacheType: standard, maxEntries: 0}
+	expect := &config{cacheType: arc, maxEntries: 777}
+
+	WithARC(777).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithSIEVE$
+func TestWithSIEVE(t *testing.T) {
+	got := &config{cacheType: standard, maxEntries: 0}
+	expect := &config{cacheType: sieve, maxEntries: 555}
+
+	WithSIEVE(555).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithS3FIFO$
+func TestWithS3FIFO(t *testing.T) {
+	got := &config{cacheType: standard, maxEntries: 0}
+	expect := &config{cacheType: s3fifo, maxEntries: 888}
+
+	WithS3FIFO(888).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestWithShardings$
 func TestWithShardings(t *testing.T) {
 	got := &config{shardings: 0}
@@ -107,6 +152,258 @@ func TestWithMaxEntries(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithMaxCost$
+func TestWithMaxCost(t *testing.T) {
+	got := &config{maxCost: 0}
+	expect := &config{maxCost: 1024}
+
+	WithMaxCost(1024).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithWeigher$
+func TestWithWeigher(t *testing.T) {
+	weigher := func(key string, value interface{}) int64 { return 1 }
+
+	got := &config{weigher: nil}
+	expect := &config{weigher: weigher}
+
+	WithWeigher(weigher).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithRangeInEvictionOrder$
+func TestWithRangeInEvictionOrder(t *testing.T) {
+	got := &config{rangeInEvictionOrder: false}
+	expect := &config{rangeInEvictionOrder: true}
+
+	WithRangeInEvictionOrder().applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithExpirationIndex$
+func TestWithExpirationIndex(t *testing.T) {
+	got := &config{expirationIndex: false}
+	expect := &config{expirationIndex: true}
+
+	WithExpirationIndex().applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithSlidingExpiration$
+func TestWithSlidingExpiration(t *testing.T) {
+	got := &config{slidingExpiration: false, maxLifetime: 0}
+	expect := &config{slidingExpiration: true, maxLifetime: time.Hour}
+
+	WithSlidingExpiration(time.Hour).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithRefreshAhead$
+func TestWithRefreshAhead(t *testing.T) {
+	got := &config{refreshWindow: 0}
+	expect := &config{refreshWindow: time.Second}
+
+	WithRefreshAhead(time.Second).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithMaxStale$
+func TestWithMaxStale(t *testing.T) {
+	got := &config{maxStale: 0}
+	expect := &config{maxStale: time.Second}
+
+	WithMaxStale(time.Second).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithLoadTimeout$
+func TestWithLoadTimeout(t *testing.T) {
+	got := &config{loadTimeout: 0}
+	expect := &config{loadTimeout: time.Second}
+
+	WithLoadTimeout(time.Second).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithCancelAbandonedLoad$
+func TestWithCancelAbandonedLoad(t *testing.T) {
+	got := &config{cancelAbandonedLoad: false}
+	expect := &config{cancelAbandonedLoad: true}
+
+	WithCancelAbandonedLoad().applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithNegativeTTL$
+func TestWithNegativeTTL(t *testing.T) {
+	isNegative := func(err error) bool { return true }
+
+	got := &config{negativeTTL: 0, isNegative: nil}
+	expect := &config{negativeTTL: time.Second, isNegative: isNegative}
+
+	WithNegativeTTL(time.Second, isNegative).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithStore$
+func TestWithStore(t *testing.T) {
+	store := newTestStore()
+
+	got := &config{store: nil, storeMode: 0}
+	expect := &config{store: store, storeMode: ReadThrough | WriteBehind}
+
+	WithStore(store, ReadThrough|WriteBehind).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithStoreTTL$
+func TestWithStoreTTL(t *testing.T) {
+	got := &config{storeTTL: 0}
+	expect := &config{storeTTL: time.Minute}
+
+	WithStoreTTL(time.Minute).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithWriteBehind$
+func TestWithWriteBehind(t *testing.T) {
+	got := &config{writeBehindInterval: time.Second, writeBehindBatch: 100}
+	expect := &config{writeBehindInterval: time.Minute, writeBehindBatch: 10}
+
+	WithWriteBehind(time.Minute, 10).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+
+	WithWriteBehind(0, 0).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithWriteBehindRetry$
+func TestWithWriteBehindRetry(t *testing.T) {
+	got := &config{writeBehindRetries: 3, writeBehindBackoff: 100 * time.Millisecond}
+	expect := &config{writeBehindRetries: 5, writeBehindBackoff: time.Second}
+
+	WithWriteBehindRetry(5, time.Second).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithOnStoreError$
+func TestWithOnStoreError(t *testing.T) {
+	onStoreError := func(key string, err error) {}
+
+	got := &config{onStoreError: nil}
+	expect := &config{onStoreError: onStoreError}
+
+	WithOnStoreError(onStoreError).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithLocalTTL$
+func TestWithLocalTTL(t *testing.T) {
+	got := &tieredConfig{localTTL: 0}
+	WithLocalTTL(time.Minute).applyTo(got)
+
+	if got.localTTL != time.Minute {
+		t.Fatalf("got.localTTL %d != %d", got.localTTL, time.Minute)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithOnRemoteError$
+func TestWithOnRemoteError(t *testing.T) {
+	onRemoteError := func(key string, err error) {}
+
+	got := &tieredConfig{onRemoteError: nil}
+	WithOnRemoteError(onRemoteError).applyTo(got)
+
+	if fmt.Sprintf("%p", got.onRemoteError) != fmt.Sprintf("%p", onRemoteError) {
+		t.Fatalf("got.onRemoteError %p != onRemoteError %p", got.onRemoteError, onRemoteError)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithRemoteCodec$
+func TestWithRemoteCodec(t *testing.T) {
+	got := &tieredConfig{remoteCodec: GobCodec{}}
+
+	WithRemoteCodec(JSONCodec{}).applyTo(got)
+	if _, ok := got.remoteCodec.(JSONCodec); !ok {
+		t.Fatalf("got.remoteCodec %T isn't JSONCodec", got.remoteCodec)
+	}
+
+	WithRemoteCodec(nil).applyTo(got)
+	if _, ok := got.remoteCodec.(JSONCodec); !ok {
+		t.Fatalf("got.remoteCodec %T isn't JSONCodec", got.remoteCodec)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithTieredOptions$
+func TestWithTieredOptions(t *testing.T) {
+	got := &tieredConfig{config: &config{loadTimeout: 0, singleflight: true}}
+	expect := &config{loadTimeout: time.Second, singleflight: false}
+
+	WithTieredOptions(WithLoadTimeout(time.Second), WithDisableSingleflight()).applyTo(got)
+	if !isConfigEquals(got.config, expect) {
+		t.Fatalf("got %+v != expect %+v", got.config, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithInvalidator$
+func TestWithInvalidator(t *testing.T) {
+	invalidator := NewChannelInvalidator()
+
+	got := &config{invalidator: nil}
+	expect := &config{invalidator: invalidator}
+
+	WithInvalidator(invalidator).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithOnInvalidateError$
+func TestWithOnInvalidateError(t *testing.T) {
+	onInvalidateError := func(key string, err error) {}
+
+	got := &config{onInvalidateError: nil}
+	expect := &config{onInvalidateError: onInvalidateError}
+
+	WithOnInvalidateError(onInvalidateError).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestWithNow$
 func TestWithNow(t *testing.T) {
 	now := func() int64 {
@@ -137,6 +434,31 @@ func TestWithHash(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithHasher$
+func TestWithHasher(t *testing.T) {
+	hasher := func(key int64) int {
+		return 0
+	}
+
+	got := &typedConfig[int64]{hasher: nil}
+	WithHasher(hasher).applyTo(got)
+
+	if fmt.Sprintf("%p", got.hasher) != fmt.Sprintf("%p", hasher) {
+		t.Fatalf("got.hasher %p != hasher %p", got.hasher, hasher)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithOptions$
+func TestWithOptions(t *testing.T) {
+	got := &typedConfig[int64]{config: &config{shardings: 0, maxEntries: 0}}
+	expect := &config{shardings: 1, maxEntries: 2}
+
+	WithOptions[int64](WithShardings(1), WithMaxEntries(2)).applyTo(got)
+	if !isConfigEquals(got.config, expect) {
+		t.Fatalf("got %+v != expect %+v", got.config, expect)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestWithRecordMissed$
 func TestWithRecordMissed(t *testing.T) {
 	got := &config{recordMissed: false}
@@ -232,3 +554,16 @@ func TestWithReportLoad(t *testing.T) {
 		t.Fatalf("got %+v != expect %+v", got, expect)
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWithOnEvicted$
+func TestWithOnEvicted(t *testing.T) {
+	onEvicted := func(key string, value interface{}, cause RemovalCause) {}
+
+	got := &config{onEvicted: nil}
+	expect := &config{onEvicted: onEvicted}
+
+	WithOnEvicted(onEvicted).applyTo(got)
+	if !isConfigEquals(got, expect) {
+		t.Fatalf("got %+v != expect %+v", got, expect)
+	}
+}
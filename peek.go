@@ -0,0 +1,40 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import "time"
+
+// peekableCache is a cache which can look up a key without accessing it.
+type peekableCache interface {
+	// peek returns the value and remaining ttl of key if found.
+	// It doesn't access key, so it won't change stats, eviction order, sliding ttl or trigger loading and refreshing.
+	peek(key string) (value interface{}, ttl time.Duration, found bool)
+}
+
+// Peek returns the value and remaining ttl of key without accessing it, and NoTTL means key is never expired.
+// It won't change stats, eviction order, sliding ttl or trigger loading and refreshing like Get does.
+// Caches which don't support peeking are looked up by Get and TTL.
+func Peek(cache Cache, key string) (value interface{}, ttl time.Duration, found bool) {
+	if pc, ok := cache.(peekableCache); ok {
+		return pc.peek(key)
+	}
+
+	if value, found = cache.Get(key); !found {
+		return nil, 0, false
+	}
+
+	ttl, _ = cache.TTL(key)
+	return value, ttl, true
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestPeek$
+func TestPeek(t *testing.T) {
+	for cacheType := range newCaches {
+		cacheType := cacheType
+		withCacheType := func(conf *config) {
+			conf.cacheType = cacheType
+		}
+
+		cache, reporter := NewCacheWithReport(withCacheType, WithMaxEntries(maxTestEntries), WithShardings(testShardings), WithGC(0))
+		cache.Set("key", "value", time.Minute)
+		cache.Set("never", "value", NoTTL)
+
+		value, ttl, found := Peek(cache, "key")
+		if !found || value.(string) != "value" || ttl <= 0 || ttl > time.Minute {
+			t.Fatalf("%s: value %+v, ttl %s, found %+v is wrong", cacheType, value, ttl, found)
+		}
+
+		if _, ttl, found = Peek(cache, "never"); !found || ttl != NoTTL {
+			t.Fatalf("%s: ttl %s, found %+v is wrong", cacheType, ttl, found)
+		}
+
+		if value, ttl, found = Peek(cache, "missed"); found {
+			t.Fatalf("%s: value %+v, ttl %s, found %+v is wrong", cacheType, value, ttl, found)
+		}
+
+		if reporter.CountHit() != 0 || reporter.CountMissed() != 0 {
+			t.Fatalf("%s: hit %d, missed %d should be 0", cacheType, reporter.CountHit(), reporter.CountMissed())
+		}
+
+		cache.Close()
+
+		if value, ttl, found = Peek(cache, "key"); found {
+			t.Fatalf("%s: value %+v, ttl %s, found %+v is wrong", cacheType, value, ttl, found)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestPeekWithoutAccess$
+func TestPeekWithoutAccess(t *testing.T) {
+	cache := NewCache(WithLRU(maxTestEntries), WithSlidingExpiration(0), WithGC(0))
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, time.Minute)
+	}
+
+	// Peeking 0 doesn't make it the most recently used entry.
+	Peek(cache, "0")
+
+	evictedValue := cache.Set("new", "new", NoTTL)
+	if evictedValue.(string) != "0" {
+		t.Fatalf("evictedValue %+v != 0", evictedValue)
+	}
+
+	cache.Set("sliding", "sliding", 100*time.Millisecond)
+	time.Sleep(60 * time.Millisecond)
+
+	// Peeking doesn't slide the expiration.
+	Peek(cache, "sliding")
+	time.Sleep(60 * time.Millisecond)
+
+	if value, ttl, found := Peek(cache, "sliding"); found {
+		t.Fatalf("value %+v, ttl %s, found %+v is wrong", value, ttl, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestPeekNegative$
+func TestPeekNegative(t *testing.T) {
+	cache := newTestNegativeCache()
+	cache.Load("key", time.Minute, func() (interface{}, error) {
+		return nil, errTestNotFound
+	})
+
+	if value, ttl, found := Peek(cache, "key"); found {
+		t.Fatalf("value %+v, ttl %s, found %+v is wrong", value, ttl, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestPeekUnpeekable$
+func TestPeekUnpeekable(t *testing.T) {
+	cache := testUndumpableCache{Cache: NewCache()}
+	cache.Set("key", "value", time.Minute)
+
+	value, ttl, found := Peek(cache, "key")
+	if !found || value.(string) != "value" || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("value %+v, ttl %s, found %+v is wrong", value, ttl, found)
+	}
+}
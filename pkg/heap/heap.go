@@ -127,6 +127,15 @@ func (h *Heap) Pop() *Item {
 	return nil
 }
 
+// Peek returns the min item without popping it, and returns nil if heap is empty.
+func (h *Heap) Peek() *Item {
+	if len(*h.items) <= 0 {
+		return nil
+	}
+
+	return (*h.items)[0]
+}
+
 // Remove removes item from heap and returns its value.
 func (h *Heap) Remove(item *Item) interface{} {
 	if item.heap == h && item.index != poppedIndex {
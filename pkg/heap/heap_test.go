@@ -172,3 +172,37 @@ func TestHeap(t *testing.T) {
 		t.Fatalf("value.(int) %d is wrong", value.(int))
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHeapPeek$
+func TestHeapPeek(t *testing.T) {
+	data := newTestData(10)
+
+	heap := New(64)
+	if item := heap.Peek(); item != nil {
+		t.Fatalf("heap.Peek() %+v should be nil", item)
+	}
+
+	for _, num := range data {
+		heap.Push(uint64(num), num)
+	}
+
+	sort.Ints(data)
+
+	index := 0
+	for heap.Size() > 0 {
+		peek := heap.Peek()
+		if num := peek.Value.(int); num != data[index] {
+			t.Fatalf("num %d != data[%d] %d", num, index, data[index])
+		}
+
+		if item := heap.Pop(); item != peek {
+			t.Fatalf("item %+v != peek %+v", item, peek)
+		}
+
+		index++
+	}
+
+	if item := heap.Peek(); item != nil {
+		t.Fatalf("heap.Peek() %+v should be nil", item)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+// Limiter limits the count of requests of each key in a window.
+type Limiter interface {
+	// Allow reports whether a request of key is allowed.
+	Allow(key string) bool
+
+	// AllowN reports whether n requests of key are allowed at the same time.
+	// Rejected requests aren't counted.
+	AllowN(key string, n int64) bool
+}
+
+func now() int64 {
+	return time.Now().UnixNano()
+}
+
+// rollback subtracts n from the counter of key only if the counter still exists.
+// The counter may be expired after adding, and decreasing it directly will create a new counter of -n.
+// Decr keeps the expiration of counter, and the remaining ttl is only used if the counter expires after checking.
+func rollback(cache cachego.Cache, key string, n int64) {
+	ttl, found := cache.TTL(key)
+	if !found {
+		return
+	}
+
+	cache.Decr(key, n, ttl)
+}
+
+// FixedWindow is a limiter counting requests in fixed windows.
+// A window starts at the first request of key and the counter of key expires after the window.
+// It's simple and cheap, but it allows at most 2*limit requests around the boundary of two windows.
+type FixedWindow struct {
+	cache  cachego.Cache
+	limit  int64
+	window time.Duration
+}
+
+// NewFixedWindow returns a fixed window limiter allowing limit requests of each key in window.
+// Counters are stored in cache, so a sharding cache is recommended for high concurrency.
+func NewFixedWindow(cache cachego.Cache, limit int64, window time.Duration) *FixedWindow {
+	if window <= 0 {
+		panic("cachego: limiter window <= 0")
+	}
+
+	fw := &FixedWindow{
+		cache:  cache,
+		limit:  limit,
+		window: window,
+	}
+
+	return fw
+}
+
+// Allow reports whether a request of key is allowed.
+func (fw *FixedWindow) Allow(key string) bool {
+	return fw.AllowN(key, 1)
+}
+
+// AllowN reports whether n requests of key are allowed at the same time.
+func (fw *FixedWindow) AllowN(key string, n int64) bool {
+	count := fw.cache.Incr(key, n, fw.window)
+	if count <= fw.limit {
+		return true
+	}
+
+	rollback(fw.cache, key, n)
+	return false
+}
+
+// SlidingWindow is a limiter estimating requests in a sliding window.
+// It counts requests in aligned windows and weights the count of previous window by its overlap with the sliding window.
+// It smooths the burst around the boundary of two windows at the cost of two counters for each key.
+type SlidingWindow struct {
+	cache  cachego.Cache
+	limit  int64
+	window time.Duration
+	now    func() int64
+}
+
+// NewSlidingWindow returns a sliding window limiter allowing limit requests of each key in window.
+// Counters are stored in cache, so a sharding cache is recommended for high concurrency.
+func NewSlidingWindow(cache cachego.Cache, limit int64, window time.Duration) *SlidingWindow {
+	if window <= 0 {
+		panic("cachego: limiter window <= 0")
+	}
+
+	sw := &SlidingWindow{
+		cache:  cache,
+		limit:  limit,
+		window: window,
+		now:    now,
+	}
+
+	return sw
+}
+
+func (sw *SlidingWindow) counterKey(key string, index int64) string {
+	return key + ":" + strconv.FormatInt(index, 10)
+}
+
+// Allow reports whether a request of key is allowed.
+func (sw *SlidingWindow) Allow(key string) bool {
+	return sw.AllowN(key, 1)
+}
+
+// AllowN reports whether n requests of key are allowed at the same time.
+func (sw *SlidingWindow) AllowN(key string, n int64) bool {
+	now := sw.now()
+	window := sw.window.Nanoseconds()
+	index := now / window
+
+	var previous int64
+	if value, ok := sw.cache.Get(sw.counterKey(key, index-1)); ok {
+		previous, _ = value.(int64)
+	}
+
+	// The counter of current window will be used as the previous one in next window, so it lives for two windows.
+	currentKey := sw.counterKey(key, index)
+	current := sw.cache.Incr(currentKey, n, 2*sw.window)
+
+	overlap := float64(window-now%window) / float64(window)
+	if float64(previous)*overlap+float64(current) <= float64(sw.limit) {
+		return true
+	}
+
+	rollback(sw.cache, currentKey, n)
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limiter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRollback$
+func TestRollback(t *testing.T) {
+	cache := cachego.NewCache()
+
+	// An expired counter shouldn't be created again with a negative value.
+	rollback(cache, "key", 2)
+
+	if value, found := cache.Get("key"); found {
+		t.Fatalf("value %+v is found", value)
+	}
+
+	// The counter should be rolled back by its current value and keep its ttl.
+	cache.Set("key", int64(7), 100*time.Millisecond)
+	time.Sleep(60 * time.Millisecond)
+	rollback(cache, "key", 2)
+
+	if value, found := cache.Get("key"); !found || value != int64(5) {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if ttl, found := cache.TTL("key"); !found || ttl <= 0 || ttl > 40*time.Millisecond {
+		t.Fatalf("ttl %+v, found %+v is wrong", ttl, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestFixedWindow$
+func TestFixedWindow(t *testing.T) {
+	limiter := NewFixedWindow(cachego.NewCache(), 3, 20*time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		if !limiter.Allow("key") {
+			t.Fatalf("request %d isn't allowed", i)
+		}
+	}
+
+	if limiter.Allow("key") {
+		t.Fatal("request over limit is allowed")
+	}
+
+	if !limiter.Allow("other") {
+		t.Fatal("request of other key isn't allowed")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if limiter.AllowN("key", 4) {
+		t.Fatal("requests over limit are allowed")
+	}
+
+	// Rejected requests shouldn't be counted.
+	if !limiter.AllowN("key", 3) {
+		t.Fatal("requests in limit aren't allowed")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestFixedWindowConcurrently$
+func TestFixedWindowConcurrently(t *testing.T) {
+	limiter := NewFixedWindow(cachego.NewCache(cachego.WithShardings(4)), 100, time.Minute)
+
+	var allows atomic.Int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				if limiter.Allow("key") {
+					allows.Add(1)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if allows.Load() != 100 {
+		t.Fatalf("allows %d != 100", allows.Load())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSlidingWindow$
+func TestSlidingWindow(t *testing.T) {
+	clock := int64(1000)
+
+	limiter := NewSlidingWindow(cachego.NewCache(), 4, time.Second)
+	limiter.now = func() int64 {
+		return clock
+	}
+
+	for i := 0; i < 4; i++ {
+		if !limiter.Allow("key") {
+			t.Fatalf("request %d isn't allowed", i)
+		}
+	}
+
+	if limiter.Allow("key") {
+		t.Fatal("request over limit is allowed")
+	}
+
+	// A quarter of next window passed, so the previous 4 requests weight 3.
+	clock = time.Second.Nanoseconds() + time.Second.Nanoseconds()/4
+
+	if !limiter.Allow("key") {
+		t.Fatal("request in limit isn't allowed")
+	}
+
+	if limiter.Allow("key") {
+		t.Fatal("request over limit is allowed")
+	}
+
+	// Three quarters of next window passed, so the previous 4 requests weight 1.
+	clock = time.Second.Nanoseconds() + time.Second.Nanoseconds()*3/4
+
+	if !limiter.AllowN("key", 2) {
+		t.Fatal("requests in limit aren't allowed")
+	}
+
+	if limiter.Allow("key") {
+		t.Fatal("request over limit is allowed")
+	}
+}
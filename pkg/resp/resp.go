@@ -0,0 +1,188 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+)
+
+const (
+	// maxArrayLength is the max count of arguments in a command.
+	maxArrayLength = 64 * 1024
+
+	// maxBulkLength is the max length of an argument in a command.
+	maxBulkLength = 16 * 1024 * 1024
+
+	// maxCommandSize is the max total length of arguments in a command.
+	maxCommandSize = 64 * 1024 * 1024
+
+	// maxArgsCap is the max capacity of arguments allocated before reading them.
+	maxArgsCap = 64
+)
+
+var (
+	errProtocol = errors.New("resp: protocol error")
+)
+
+// readLine reads a line ending with \r\n and returns it without \r\n.
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull {
+		return nil, errProtocol
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errProtocol
+	}
+
+	return line[:len(line)-2], nil
+}
+
+// readLength reads a line of length after prefix, such as *3 and $5.
+func readLength(reader *bufio.Reader, prefix byte, max int) (int, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(line) < 2 || line[0] != prefix {
+		return 0, errProtocol
+	}
+
+	n, err := strconv.Atoi(string(line[1:]))
+	if err != nil || n < 0 || n > max {
+		return 0, errProtocol
+	}
+
+	return n, nil
+}
+
+// readBulk reads a bulk string of length ending with \r\n and returns it without \r\n.
+// The buffer grows with the data actually read, so a fake length won't allocate a huge buffer up front.
+func readBulk(reader *bufio.Reader, length int) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	_, err := io.CopyN(&buffer, reader, int64(length)+2)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	bulk := buffer.Bytes()
+	if bulk[length] != '\r' || bulk[length+1] != '\n' {
+		return nil, errProtocol
+	}
+
+	return bulk[:length], nil
+}
+
+// readCommand reads a command in the format of resp array of bulk strings, or an inline command split by spaces.
+// It returns an empty command if the line is empty, and a protocol error if the command is larger than maxCommandSize.
+func readCommand(reader *bufio.Reader) (args [][]byte, err error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if first[0] != '*' {
+		line, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, arg := range bytes.Fields(line) {
+			args = append(args, append([]byte(nil), arg...))
+		}
+
+		return args, nil
+	}
+
+	n, err := readLength(reader, '*', maxArrayLength)
+	if err != nil {
+		return nil, err
+	}
+
+	args = make([][]byte, 0, min(n, maxArgsCap))
+	remaining := maxCommandSize
+
+	for i := 0; i < n; i++ {
+		length, err := readLength(reader, '$', min(maxBulkLength, remaining))
+		if err != nil {
+			return nil, err
+		}
+
+		arg, err := readBulk(reader, length)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+		remaining -= length
+	}
+
+	return args, nil
+}
+
+// writer writes replies in the format of resp2.
+type writer struct {
+	*bufio.Writer
+}
+
+func (w writer) writeSimple(s string) {
+	w.WriteByte('+')
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func (w writer) writeError(s string) {
+	w.WriteByte('-')
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func (w writer) writeInteger(n int64) {
+	w.WriteByte(':')
+	w.WriteString(strconv.FormatInt(n, 10))
+	w.WriteString("\r\n")
+}
+
+func (w writer) writeBulk(data []byte) {
+	w.WriteByte('$')
+	w.WriteString(strconv.Itoa(len(data)))
+	w.WriteString("\r\n")
+	w.Write(data)
+	w.WriteString("\r\n")
+}
+
+func (w writer) writeNull() {
+	w.WriteString("$-1\r\n")
+}
+
+func (w writer) writeArray(n int) {
+	w.WriteByte('*')
+	w.WriteString(strconv.Itoa(n))
+	w.WriteString("\r\n")
+}
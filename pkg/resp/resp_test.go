@@ -0,0 +1,105 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestReadCommand$
+func TestReadCommand(t *testing.T) {
+	testCases := []struct {
+		input string
+		args  []string
+	}{
+		{input: "*1\r\n$4\r\nPING\r\n", args: []string{"PING"}},
+		{input: "*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$0\r\n\r\n", args: []string{"SET", "key", ""}},
+		{input: "*2\r\n$3\r\nGET\r\n$4\r\na\r\nb\r\n", args: []string{"GET", "a\r\nb"}},
+		{input: "GET  key\r\n", args: []string{"GET", "key"}},
+		{input: "\r\n", args: nil},
+	}
+
+	for _, testCase := range testCases {
+		args, err := readCommand(bufio.NewReader(strings.NewReader(testCase.input)))
+		if err != nil {
+			t.Fatalf("input %q returns err %+v", testCase.input, err)
+		}
+
+		if len(args) != len(testCase.args) {
+			t.Fatalf("input %q: len(args) %d != %d", testCase.input, len(args), len(testCase.args))
+		}
+
+		for i, arg := range args {
+			if string(arg) != testCase.args[i] {
+				t.Fatalf("input %q: args[%d] %q != %q", testCase.input, i, arg, testCase.args[i])
+			}
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestReadCommandError$
+func TestReadCommandError(t *testing.T) {
+	inputs := []string{
+		"GET key\n",
+		"*x\r\n",
+		"*-1\r\n",
+		"*1\r\n+PING\r\n",
+		"*1\r\n$4\r\nPINGXX",
+		"*1\r\n$99999999999\r\n",
+		"*1\r\n$16777217\r\n",
+		"*65537\r\n",
+	}
+
+	for _, input := range inputs {
+		_, err := readCommand(bufio.NewReader(strings.NewReader(input)))
+		if !errors.Is(err, errProtocol) {
+			t.Fatalf("input %q returns err %+v", input, err)
+		}
+	}
+
+	_, err := readCommand(bufio.NewReader(strings.NewReader("*2\r\n$3\r\nGET\r\n")))
+	if err != io.EOF {
+		t.Fatalf("err %+v != io.EOF", err)
+	}
+
+	_, err = readCommand(bufio.NewReader(strings.NewReader("*1\r\n$16777216\r\nGET\r\n")))
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("err %+v != io.ErrUnexpectedEOF", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWriter$
+func TestWriter(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	w := writer{Writer: bufio.NewWriter(buffer)}
+
+	w.writeArray(5)
+	w.writeSimple("OK")
+	w.writeError("ERR wrong")
+	w.writeInteger(-2)
+	w.writeBulk([]byte("value"))
+	w.writeNull()
+	w.Flush()
+
+	want := "*5\r\n+OK\r\n-ERR wrong\r\n:-2\r\n$5\r\nvalue\r\n$-1\r\n"
+	if buffer.String() != want {
+		t.Fatalf("buffer %q != %q", buffer.String(), want)
+	}
+}
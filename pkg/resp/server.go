@@ -0,0 +1,560 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+// ErrServerClosed is returned by Serve after closing server.
+var ErrServerClosed = errors.New("resp: server is closed")
+
+const (
+	errSyntax     = "ERR syntax error"
+	errNotInteger = "ERR value is not an integer or out of range"
+	errOverflow   = "ERR increment or decrement would overflow"
+)
+
+// handler handles a command with its arguments excluding the name.
+type handler struct {
+	// minArgs and maxArgs are the range of argument count, and a negative maxArgs means no limit.
+	minArgs int
+	maxArgs int
+	handle  func(s *Server, w writer, args [][]byte) (quit bool)
+}
+
+var handlers = map[string]handler{
+	"PING":     {minArgs: 0, maxArgs: 1, handle: (*Server).ping},
+	"QUIT":     {minArgs: 0, maxArgs: 0, handle: (*Server).quit},
+	"COMMAND":  {minArgs: 0, maxArgs: -1, handle: (*Server).command},
+	"SELECT":   {minArgs: 1, maxArgs: 1, handle: (*Server).selectDB},
+	"GET":      {minArgs: 1, maxArgs: 1, handle: (*Server).get},
+	"SET":      {minArgs: 2, maxArgs: -1, handle: (*Server).set},
+	"DEL":      {minArgs: 1, maxArgs: -1, handle: (*Server).del},
+	"EXISTS":   {minArgs: 1, maxArgs: -1, handle: (*Server).exists},
+	"TTL":      {minArgs: 1, maxArgs: 1, handle: (*Server).ttl},
+	"EXPIRE":   {minArgs: 2, maxArgs: 2, handle: (*Server).expire},
+	"INCR":     {minArgs: 1, maxArgs: 1, handle: (*Server).incr},
+	"DBSIZE":   {minArgs: 0, maxArgs: 0, handle: (*Server).dbSize},
+	"FLUSHDB":  {minArgs: 0, maxArgs: 1, handle: (*Server).flushDB},
+	"FLUSHALL": {minArgs: 0, maxArgs: 1, handle: (*Server).flushDB},
+	"SCAN":     {minArgs: 1, maxArgs: -1, handle: (*Server).scan},
+	"INFO":     {minArgs: 0, maxArgs: -1, handle: (*Server).info},
+}
+
+// Server serves a cache over the redis resp2 protocol, which is useful for debugging and non-Go clients.
+// Values set by SET are stored as []byte, and values of other types are replied in their string formats.
+type Server struct {
+	cache    cachego.Cache
+	reporter *cachego.Reporter
+
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+	lock      sync.Mutex
+	wg        sync.WaitGroup
+}
+
+// NewServer creates a server of cache.
+// The reporter is used by INFO and can be nil, see cachego.NewCacheWithReport.
+func NewServer(cache cachego.Cache, reporter *cachego.Reporter) *Server {
+	s := &Server{
+		cache:     cache,
+		reporter:  reporter,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+
+	return s
+}
+
+// ListenAndServe listens on address by tcp and serves connections until server is closed.
+func (s *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(listener)
+}
+
+// Serve serves connections accepted by listener until server is closed, and it always returns a non-nil error.
+func (s *Server) Serve(listener net.Listener) error {
+	if !s.track(listener, nil) {
+		listener.Close()
+		return ErrServerClosed
+	}
+
+	defer s.untrack(listener, nil)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+
+			return err
+		}
+
+		if !s.track(nil, conn) {
+			conn.Close()
+			return ErrServerClosed
+		}
+
+		s.wg.Add(1)
+		go s.serveConn(conn)
+	}
+}
+
+// Close closes all listeners and connections, and waits for connections to finish.
+func (s *Server) Close() error {
+	s.lock.Lock()
+	s.closed = true
+
+	var err error
+	for listener := range s.listeners {
+		if closeErr := listener.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	for conn := range s.conns {
+		conn.Close()
+	}
+
+	s.lock.Unlock()
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *Server) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.closed
+}
+
+func (s *Server) track(listener net.Listener, conn net.Conn) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	if listener != nil {
+		s.listeners[listener] = struct{}{}
+	}
+
+	if conn != nil {
+		s.conns[conn] = struct{}{}
+	}
+
+	return true
+}
+
+func (s *Server) untrack(listener net.Listener, conn net.Conn) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.listeners, listener)
+	delete(s.conns, conn)
+}
+
+// serveConn reads commands from conn and writes replies until conn is closed or QUIT.
+// Replies are flushed when there are no more buffered commands, so pipelined commands are replied in batch.
+func (s *Server) serveConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer s.untrack(nil, conn)
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	w := writer{Writer: bufio.NewWriter(conn)}
+
+	for {
+		args, err := readCommand(reader)
+		if errors.Is(err, errProtocol) {
+			w.writeError("ERR Protocol error")
+			w.Flush()
+			return
+		}
+
+		if err != nil {
+			return
+		}
+
+		if len(args) > 0 && s.handle(w, args) {
+			w.Flush()
+			return
+		}
+
+		if reader.Buffered() <= 0 {
+			if err = w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handle handles a command and returns true if conn should be closed.
+func (s *Server) handle(w writer, args [][]byte) (quit bool) {
+	name := strings.ToUpper(string(args[0]))
+	args = args[1:]
+
+	h, ok := handlers[name]
+	if !ok {
+		w.writeError(fmt.Sprintf("ERR unknown command '%s'", truncate(name)))
+		return false
+	}
+
+	if len(args) < h.minArgs || (h.maxArgs >= 0 && len(args) > h.maxArgs) {
+		w.writeError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+		return false
+	}
+
+	return h.handle(s, w, args)
+}
+
+// truncate limits the length of an unknown command name in error replies.
+func truncate(name string) string {
+	if len(name) > 64 {
+		return name[:64]
+	}
+
+	return name
+}
+
+// format returns the bytes of value in its string format.
+func format(value interface{}) []byte {
+	switch v := value.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	case int64:
+		return strconv.AppendInt(nil, v, 10)
+	default:
+		return []byte(fmt.Sprint(v))
+	}
+}
+
+func (s *Server) ping(w writer, args [][]byte) bool {
+	if len(args) > 0 {
+		w.writeBulk(args[0])
+	} else {
+		w.writeSimple("PONG")
+	}
+
+	return false
+}
+
+func (s *Server) quit(w writer, args [][]byte) bool {
+	w.writeSimple("OK")
+	return true
+}
+
+// command replies an empty array, so clients like redis-cli can connect without command docs.
+func (s *Server) command(w writer, args [][]byte) bool {
+	w.writeArray(0)
+	return false
+}
+
+func (s *Server) selectDB(w writer, args [][]byte) bool {
+	if string(args[0]) != "0" {
+		w.writeError("ERR DB index is out of range")
+		return false
+	}
+
+	w.writeSimple("OK")
+	return false
+}
+
+func (s *Server) get(w writer, args [][]byte) bool {
+	value, found := s.cache.Get(string(args[0]))
+	if !found {
+		w.writeNull()
+		return false
+	}
+
+	w.writeBulk(format(value))
+	return false
+}
+
+// set handles SET key value [EX seconds | PX milliseconds].
+func (s *Server) set(w writer, args [][]byte) bool {
+	ttl := time.Duration(cachego.NoTTL)
+
+	for i := 2; i < len(args); i += 2 {
+		option := strings.ToUpper(string(args[i]))
+		if (option != "EX" && option != "PX") || i+1 >= len(args) || ttl != cachego.NoTTL {
+			w.writeError(errSyntax)
+			return false
+		}
+
+		unit := time.Millisecond
+		if option == "EX" {
+			unit = time.Second
+		}
+
+		// Expire times which overflow time.Duration are rejected.
+		n, err := strconv.ParseInt(string(args[i+1]), 10, 64)
+		if err != nil || n <= 0 || n > math.MaxInt64/int64(unit) {
+			w.writeError("ERR invalid expire time in 'set' command")
+			return false
+		}
+
+		ttl = time.Duration(n) * unit
+	}
+
+	s.cache.Set(string(args[0]), args[1], ttl)
+	w.writeSimple("OK")
+
+	return false
+}
+
+func (s *Server) del(w writer, args [][]byte) bool {
+	var removed int64
+	for _, arg := range args {
+		if _, found := s.cache.GetAndRemove(string(arg)); found {
+			removed++
+		}
+	}
+
+	w.writeInteger(removed)
+	return false
+}
+
+func (s *Server) exists(w writer, args [][]byte) bool {
+	var exists int64
+	for _, arg := range args {
+		if _, found := s.cache.TTL(string(arg)); found {
+			exists++
+		}
+	}
+
+	w.writeInteger(exists)
+	return false
+}
+
+// ttl replies the remaining seconds of key, -1 if key is never expired and -2 if key doesn't exist.
+func (s *Server) ttl(w writer, args [][]byte) bool {
+	ttl, found := s.cache.TTL(string(args[0]))
+	if !found {
+		w.writeInteger(-2)
+		return false
+	}
+
+	if ttl == cachego.NoTTL {
+		w.writeInteger(-1)
+		return false
+	}
+
+	w.writeInteger(int64((ttl + time.Second/2) / time.Second))
+	return false
+}
+
+// expire handles EXPIRE key seconds, and key will be removed if seconds <= 0 like redis.
+func (s *Server) expire(w writer, args [][]byte) bool {
+	key := string(args[0])
+
+	seconds, err := strconv.ParseInt(string(args[1]), 10, 64)
+	if err != nil {
+		w.writeError(errNotInteger)
+		return false
+	}
+
+	var found bool
+	if seconds <= 0 {
+		_, found = s.cache.GetAndRemove(key)
+	} else {
+		found = s.cache.Expire(key, time.Duration(seconds)*time.Second)
+	}
+
+	if found {
+		w.writeInteger(1)
+	} else {
+		w.writeInteger(0)
+	}
+
+	return false
+}
+
+// incr handles INCR key, and values set by SET are converted to int64 with their ttl kept before increasing by cache.Incr.
+func (s *Server) incr(w writer, args [][]byte) bool {
+	key := string(args[0])
+
+	for {
+		value, ttl, found := cachego.Peek(s.cache, key)
+
+		var n int64
+		switch value := value.(type) {
+		case int64:
+			n = value
+		case []byte:
+			var err error
+			if n, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+				w.writeError(errNotInteger)
+				return false
+			}
+
+			// Values are only converted if they aren't changed by others, so a concurrent SET won't be overwritten.
+			equal := func(old interface{}, current interface{}) bool {
+				data, ok := current.([]byte)
+				return ok && bytes.Equal(value, data)
+			}
+
+			if !s.cache.CompareAndSwap(key, value, n, ttl, equal) {
+				continue
+			}
+		default:
+			if found {
+				w.writeError(errNotInteger)
+				return false
+			}
+		}
+
+		if n == math.MaxInt64 {
+			w.writeError(errOverflow)
+			return false
+		}
+
+		// The value may be increased by others after checking, and only MaxInt64 + 1 wraps to MinInt64.
+		if n = s.cache.Incr(key, 1, cachego.NoTTL); n == math.MinInt64 {
+			s.cache.Decr(key, 1, cachego.NoTTL)
+			w.writeError(errOverflow)
+			return false
+		}
+
+		w.writeInteger(n)
+		return false
+	}
+}
+
+func (s *Server) dbSize(w writer, args [][]byte) bool {
+	w.writeInteger(int64(s.cache.Size()))
+	return false
+}
+
+func (s *Server) flushDB(w writer, args [][]byte) bool {
+	s.cache.Reset()
+	w.writeSimple("OK")
+
+	return false
+}
+
+// scan handles SCAN cursor [MATCH pattern] [COUNT count].
+// The cursor is the offset of sorted keys, so keys added or removed during scanning may be missed or returned twice.
+func (s *Server) scan(w writer, args [][]byte) bool {
+	cursor, err := strconv.Atoi(string(args[0]))
+	if err != nil || cursor < 0 {
+		w.writeError("ERR invalid cursor")
+		return false
+	}
+
+	pattern := ""
+	count := 10
+
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			w.writeError(errSyntax)
+			return false
+		}
+
+		switch strings.ToUpper(string(args[i])) {
+		case "MATCH":
+			pattern = string(args[i+1])
+		case "COUNT":
+			if count, err = strconv.Atoi(string(args[i+1])); err != nil || count <= 0 {
+				w.writeError(errSyntax)
+				return false
+			}
+		default:
+			w.writeError(errSyntax)
+			return false
+		}
+	}
+
+	keys := s.cache.Keys()
+	sort.Strings(keys)
+
+	var matched []string
+	next := cursor
+	for ; next < len(keys) && next < cursor+count; next++ {
+		if pattern == "" {
+			matched = append(matched, keys[next])
+			continue
+		}
+
+		if ok, _ := path.Match(pattern, keys[next]); ok {
+			matched = append(matched, keys[next])
+		}
+	}
+
+	if next >= len(keys) {
+		next = 0
+	}
+
+	w.writeArray(2)
+	w.writeBulk([]byte(strconv.Itoa(next)))
+	w.writeArray(len(matched))
+
+	for _, key := range matched {
+		w.writeBulk([]byte(key))
+	}
+
+	return false
+}
+
+// info replies the stats of cache reported by reporter and the count of keys.
+func (s *Server) info(w writer, args [][]byte) bool {
+	var buffer bytes.Buffer
+
+	if r := s.reporter; r != nil {
+		fmt.Fprintf(&buffer, "# Server\r\n")
+		fmt.Fprintf(&buffer, "cache_name:%s\r\n", r.CacheName())
+		fmt.Fprintf(&buffer, "cache_type:%s\r\n", r.CacheType())
+		fmt.Fprintf(&buffer, "cache_shardings:%d\r\n", r.CacheShardings())
+		fmt.Fprintf(&buffer, "cache_gc:%s\r\n", r.CacheGC())
+		fmt.Fprintf(&buffer, "\r\n# Stats\r\n")
+		fmt.Fprintf(&buffer, "keyspace_hits:%d\r\n", r.CountHit())
+		fmt.Fprintf(&buffer, "keyspace_misses:%d\r\n", r.CountMissed())
+		fmt.Fprintf(&buffer, "keyspace_negative_hits:%d\r\n", r.CountNegativeHit())
+		fmt.Fprintf(&buffer, "hit_rate:%.4f\r\n", r.HitRate())
+		fmt.Fprintf(&buffer, "gc_runs:%d\r\n", r.CountGC())
+		fmt.Fprintf(&buffer, "loads:%d\r\n", r.CountLoad())
+		fmt.Fprintf(&buffer, "\r\n")
+	}
+
+	fmt.Fprintf(&buffer, "# Keyspace\r\n")
+	fmt.Fprintf(&buffer, "db0:keys=%d,cost=%d\r\n", s.cache.Size(), s.cache.Cost())
+
+	w.writeBulk(buffer.Bytes())
+	return false
+}
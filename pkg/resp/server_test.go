@@ -0,0 +1,382 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FishGoddess/cachego"
+)
+
+// testClient is a resp2 client for tests.
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func newTestServer(t *testing.T) (cachego.Cache, *testClient) {
+	cache, reporter := cachego.NewCacheWithReport(cachego.WithCacheName("test"))
+	server := NewServer(cache, reporter)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go server.Serve(listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Close()
+		cache.Close()
+	})
+
+	return cache, &testClient{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// send sends a command in the format of resp array without reading its reply.
+func (tc *testClient) send(args ...string) error {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "*%d\r\n", len(args))
+
+	for _, arg := range args {
+		fmt.Fprintf(&builder, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	_, err := tc.conn.Write([]byte(builder.String()))
+	return err
+}
+
+// receive reads a reply, which is a string, an int64, nil, a []interface{} or an error.
+func (tc *testClient) receive() (interface{}, error) {
+	line, err := readLine(tc.reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 1 {
+		return nil, errProtocol
+	}
+
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return errors.New(string(line[1:])), nil
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil || n < 0 {
+			return nil, err
+		}
+
+		data := make([]byte, n+2)
+		if _, err = io.ReadFull(tc.reader, data); err != nil {
+			return nil, err
+		}
+
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		replies := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			reply, err := tc.receive()
+			if err != nil {
+				return nil, err
+			}
+
+			replies = append(replies, reply)
+		}
+
+		return replies, nil
+	default:
+		return nil, errProtocol
+	}
+}
+
+func (tc *testClient) do(t *testing.T, args ...string) interface{} {
+	t.Helper()
+
+	if err := tc.send(args...); err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := tc.receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return reply
+}
+
+func (tc *testClient) expect(t *testing.T, want interface{}, args ...string) {
+	t.Helper()
+
+	reply := tc.do(t, args...)
+	if fmt.Sprint(reply) != fmt.Sprint(want) {
+		t.Fatalf("%v: reply %v != %v", args, reply, want)
+	}
+}
+
+func (tc *testClient) expectError(t *testing.T, prefix string, args ...string) {
+	t.Helper()
+
+	reply := tc.do(t, args...)
+	if err, ok := reply.(error); !ok || !strings.HasPrefix(err.Error(), prefix) {
+		t.Fatalf("%v: reply %v isn't an error with prefix %s", args, reply, prefix)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServer$
+func TestServer(t *testing.T) {
+	cache, client := newTestServer(t)
+
+	client.expect(t, "PONG", "PING")
+	client.expect(t, "hello", "ping", "hello")
+	client.expect(t, "OK", "SELECT", "0")
+	client.expectError(t, "ERR DB index", "SELECT", "1")
+	client.expectError(t, "ERR unknown command", "UNKNOWN")
+	client.expectError(t, "ERR wrong number of arguments", "GET")
+
+	client.expect(t, nil, "GET", "key")
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expect(t, "value", "GET", "key")
+
+	value, found := cache.Get("key")
+	if data, ok := value.([]byte); !found || !ok || string(data) != "value" {
+		t.Fatalf("value %v isn't []byte value", value)
+	}
+
+	cache.Set("int", int64(123), cachego.NoTTL)
+	client.expect(t, "123", "GET", "int")
+
+	client.expect(t, 2, "EXISTS", "key", "int", "missing")
+	client.expect(t, 2, "DEL", "key", "int", "missing")
+	client.expect(t, 0, "EXISTS", "key", "int")
+	client.expect(t, 0, "DBSIZE")
+
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expect(t, 1, "DBSIZE")
+	client.expect(t, "OK", "FLUSHDB")
+	client.expect(t, 0, "DBSIZE")
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerTTL$
+func TestServerTTL(t *testing.T) {
+	_, client := newTestServer(t)
+
+	client.expect(t, -2, "TTL", "key")
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expect(t, -1, "TTL", "key")
+
+	client.expect(t, "OK", "SET", "key", "value", "EX", "10")
+	client.expect(t, 10, "TTL", "key")
+
+	client.expect(t, 1, "EXPIRE", "key", "100")
+	client.expect(t, 100, "TTL", "key")
+	client.expect(t, 0, "EXPIRE", "missing", "100")
+
+	client.expect(t, "OK", "SET", "key", "value", "PX", "10")
+	time.Sleep(20 * time.Millisecond)
+	client.expect(t, nil, "GET", "key")
+
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expect(t, 1, "EXPIRE", "key", "0")
+	client.expect(t, nil, "GET", "key")
+
+	client.expectError(t, "ERR syntax error", "SET", "key", "value", "XX", "10")
+	client.expectError(t, "ERR syntax error", "SET", "key", "value", "EX")
+	client.expectError(t, "ERR syntax error", "SET", "key", "value", "EX", "10", "PX", "10")
+	client.expectError(t, "ERR invalid expire time", "SET", "key", "value", "EX", "0")
+	client.expectError(t, "ERR invalid expire time", "SET", "key", "value", "EX", strconv.FormatInt(math.MaxInt64/int64(time.Second)+1, 10))
+	client.expectError(t, "ERR invalid expire time", "SET", "key", "value", "PX", strconv.FormatInt(math.MaxInt64, 10))
+	client.expectError(t, "ERR value is not an integer", "EXPIRE", "key", "x")
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerIncr$
+func TestServerIncr(t *testing.T) {
+	cache, client := newTestServer(t)
+
+	client.expect(t, 1, "INCR", "counter")
+	client.expect(t, 2, "INCR", "counter")
+
+	client.expect(t, "OK", "SET", "counter", "10", "EX", "100")
+	client.expect(t, 11, "INCR", "counter")
+	client.expect(t, "11", "GET", "counter")
+	client.expect(t, 100, "TTL", "counter")
+
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expectError(t, "ERR value is not an integer", "INCR", "key")
+	client.expect(t, "value", "GET", "key")
+
+	cache.Set("struct", struct{}{}, cachego.NoTTL)
+	client.expectError(t, "ERR value is not an integer", "INCR", "struct")
+
+	client.expect(t, "OK", "SET", "max", strconv.FormatInt(math.MaxInt64-1, 10))
+	client.expect(t, int64(math.MaxInt64), "INCR", "max")
+	client.expectError(t, "ERR increment or decrement would overflow", "INCR", "max")
+	client.expect(t, strconv.FormatInt(math.MaxInt64, 10), "GET", "max")
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerScan$
+func TestServerScan(t *testing.T) {
+	_, client := newTestServer(t)
+
+	for i := 0; i < 25; i++ {
+		client.expect(t, "OK", "SET", fmt.Sprintf("key%02d", i), "value")
+	}
+
+	client.expect(t, "OK", "SET", "other", "value")
+
+	var keys []interface{}
+	cursor := "0"
+
+	for {
+		reply := client.do(t, "SCAN", cursor, "MATCH", "key*", "COUNT", "7").([]interface{})
+		cursor = reply[0].(string)
+		keys = append(keys, reply[1].([]interface{})...)
+
+		if cursor == "0" {
+			break
+		}
+	}
+
+	if len(keys) != 25 {
+		t.Fatalf("len(keys) %d != 25", len(keys))
+	}
+
+	for i, key := range keys {
+		if key != fmt.Sprintf("key%02d", i) {
+			t.Fatalf("key %v != key%02d", key, i)
+		}
+	}
+
+	client.expect(t, []interface{}{"0", []interface{}{"other"}}, "SCAN", "0", "MATCH", "o*", "COUNT", "100")
+	client.expectError(t, "ERR invalid cursor", "SCAN", "x")
+	client.expectError(t, "ERR syntax error", "SCAN", "0", "COUNT", "0")
+	client.expectError(t, "ERR syntax error", "SCAN", "0", "MATCH")
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerInfo$
+func TestServerInfo(t *testing.T) {
+	_, client := newTestServer(t)
+
+	client.expect(t, "OK", "SET", "key", "value")
+	client.expect(t, "value", "GET", "key")
+	client.expect(t, nil, "GET", "missing")
+
+	info, ok := client.do(t, "INFO").(string)
+	if !ok {
+		t.Fatalf("info %v isn't a string", info)
+	}
+
+	lines := []string{"cache_name:test", "keyspace_hits:1", "keyspace_misses:1", "hit_rate:0.5000", "db0:keys=1,"}
+	for _, line := range lines {
+		if !strings.Contains(info, line) {
+			t.Fatalf("info %q doesn't contain %q", info, line)
+		}
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerPipeline$
+func TestServerPipeline(t *testing.T) {
+	_, client := newTestServer(t)
+
+	// Inline commands and resp arrays can be mixed in a pipeline.
+	if _, err := client.conn.Write([]byte("SET key value\r\nGET key\r\n\r\n*1\r\n$6\r\nDBSIZE\r\nQUIT\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []interface{}{"OK", "value", int64(1), "OK"}
+	for _, w := range want {
+		reply, err := client.receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if reply != w {
+			t.Fatalf("reply %v != %v", reply, w)
+		}
+	}
+
+	if _, err := client.receive(); err == nil {
+		t.Fatal("conn isn't closed after QUIT")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestServerClose$
+func TestServerClose(t *testing.T) {
+	server := NewServer(cachego.NewCache(), nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(listener)
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer conn.Close()
+
+	client := &testClient{conn: conn, reader: bufio.NewReader(conn)}
+	client.expect(t, "PONG", "PING")
+
+	info, _ := client.do(t, "INFO").(string)
+	if strings.Contains(info, "cache_name") || !strings.Contains(info, "db0:keys=0,") {
+		t.Fatalf("info %q without reporter is wrong", info)
+	}
+
+	if err = server.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = <-served; err != ErrServerClosed {
+		t.Fatalf("err %+v != ErrServerClosed", err)
+	}
+
+	if _, err = client.receive(); err == nil {
+		t.Fatal("conn isn't closed after closing server")
+	}
+
+	if err = server.Serve(listener); err != ErrServerClosed {
+		t.Fatalf("err %+v != ErrServerClosed", err)
+	}
+}
@@ -15,77 +15,354 @@
 package singleflight
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
 )
 
-type call struct {
-	fn     func() (result interface{}, err error)
-	result interface{}
+var (
+	// ErrResultNotFound is returned when the result of key isn't returned by the function of CallMulti.
+	ErrResultNotFound = errors.New("singleflight: result not found")
+
+	// ErrGoexit is returned to waiters when runtime.Goexit is called in fn.
+	ErrGoexit = errors.New("singleflight: runtime.Goexit was called in fn")
+)
+
+// PanicError is a panic happening in fn with its stack.
+// All callers waiting for the same call will panic with it.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func newPanicError(value interface{}) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+}
+
+// Error returns the panic value and the stack.
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: panic in fn: %v\n\n%s", pe.Value, pe.Stack)
+}
+
+// Unwrap returns the panic value if it's an error.
+func (pe *PanicError) Unwrap() error {
+	err, _ := pe.Value.(error)
+	return err
+}
+
+type typedCall[V any] struct {
+	fn     func() (result V, err error)
+	result V
 	err    error
 
 	// deleted is a flag checking if this call has been deleted from Group.
 	deleted bool
 
-	wg sync.WaitGroup
+	// waiters is the count of callers waiting for this call, including the one calling it.
+	waiters int
+
+	// cancel cancels the context of this call if it's called with a context.
+	cancel context.CancelFunc
+
+	done chan struct{}
 }
 
-func newCall(fn func() (result interface{}, err error)) *call {
-	return &call{
+type call = typedCall[interface{}]
+
+func newTypedCall[V any](fn func() (result V, err error)) *typedCall[V] {
+	return &typedCall[V]{
 		fn:      fn,
 		deleted: false,
+		waiters: 1,
+		done:    make(chan struct{}),
 	}
 }
 
-func (c *call) do() {
-	defer c.wg.Done()
+func (c *typedCall[V]) do() {
+	normalReturn := false
+	recovered := false
+
+	defer func() {
+		// Neither returning nor panicking means runtime.Goexit was called in fn.
+		if !normalReturn && !recovered {
+			c.err = ErrGoexit
+		}
+
+		close(c.done)
+	}()
+
+	func() {
+		defer func() {
+			if normalReturn {
+				return
+			}
+
+			if r := recover(); r != nil {
+				c.err = newPanicError(r)
+			}
+		}()
+
+		c.result, c.err = c.fn()
+		normalReturn = true
+	}()
 
-	// Notice: Any panics or runtime.Goexit() happening in fn() will be ignored.
-	c.result, c.err = c.fn()
+	if !normalReturn {
+		recovered = true
+	}
 }
 
-// Group stores all function calls in it.
-type Group struct {
-	calls map[string]*call
+// get returns the result and error of c, and panics if fn panicked.
+func (c *typedCall[V]) get() (V, error) {
+	if pe, ok := c.err.(*PanicError); ok {
+		panic(pe)
+	}
+
+	return c.result, c.err
+}
+
+// TypedGroup is the generic version of Group.
+// It stores all function calls of keys in type K which return results in type V.
+type TypedGroup[K comparable, V any] struct {
+	calls map[K]*typedCall[V]
 	lock  sync.Mutex
+
+	// cancelAbandoned is a flag checking if a call should be cancelled after all its waiters leave.
+	cancelAbandoned bool
+}
+
+// Group stores all function calls in it.
+type Group = TypedGroup[string, interface{}]
+
+// NewTypedGroup returns a new TypedGroup with initialCap.
+func NewTypedGroup[K comparable, V any](initialCap int) *TypedGroup[K, V] {
+	return &TypedGroup[K, V]{
+		calls: make(map[K]*typedCall[V], initialCap),
+	}
 }
 
 // NewGroup returns a new Group with initialCap.
 func NewGroup(initialCap int) *Group {
-	return &Group{
-		calls: make(map[string]*call, initialCap),
+	return NewTypedGroup[string, interface{}](initialCap)
+}
+
+// SetCancelAbandoned sets if a call started by CallContext should be cancelled after all its waiters leave.
+// By default, the call keeps going even if nobody waits for it, so its result can be used by others.
+func (g *TypedGroup[K, V]) SetCancelAbandoned(cancelAbandoned bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.cancelAbandoned = cancelAbandoned
+}
+
+// doCall calls c and deletes it from group after it's done.
+// The key is always deleted even if fn panics or calls runtime.Goexit.
+func (g *TypedGroup[K, V]) doCall(key K, c *typedCall[V]) {
+	defer func() {
+		g.lock.Lock()
+
+		if !c.deleted {
+			delete(g.calls, key)
+		}
+
+		g.lock.Unlock()
+
+		if c.cancel != nil {
+			c.cancel()
+		}
+	}()
+
+	c.do()
+}
+
+// leave leaves the call of key and cancels it if it's abandoned.
+func (g *TypedGroup[K, V]) leave(key K, c *typedCall[V]) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	c.waiters--
+
+	if c.waiters > 0 || !g.cancelAbandoned || c.cancel == nil {
+		return
 	}
+
+	// The call is going to be cancelled, so new callers shouldn't wait for it.
+	if !c.deleted {
+		delete(g.calls, key)
+		c.deleted = true
+	}
+
+	c.cancel()
 }
 
 // Call calls fn in singleflight mode and returns its result and error.
-func (g *Group) Call(key string, fn func() (interface{}, error)) (interface{}, error) {
+func (g *TypedGroup[K, V]) Call(key K, fn func() (V, error)) (V, error) {
 	g.lock.Lock()
 
 	if c, ok := g.calls[key]; ok {
+		c.waiters++
 		g.lock.Unlock()
 
-		c.wg.Wait()
-		return c.result, c.err
+		<-c.done
+		return c.get()
 	}
 
-	c := newCall(fn)
-	c.wg.Add(1)
-
+	c := newTypedCall(fn)
 	g.calls[key] = c
 	g.lock.Unlock()
 
-	c.do()
+	g.doCall(key, c)
+	return c.get()
+}
+
+// CallContext calls fn with a context in singleflight mode and returns its result and error.
+// The caller returns ctx.Err() right away if ctx is done, but the call keeps going for other waiters.
+// The context passed to fn keeps the values of ctx and won't be cancelled by ctx.
+// See SetCancelAbandoned if you want to cancel the call after all waiters leave.
+func (g *TypedGroup[K, V]) CallContext(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (result V, err error) {
+	if err = ctx.Err(); err != nil {
+		return result, err
+	}
+
 	g.lock.Lock()
 
-	if !c.deleted {
-		delete(g.calls, key)
+	c, ok := g.calls[key]
+	if ok {
+		c.waiters++
+	} else {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+		c = newTypedCall(func() (V, error) {
+			return fn(callCtx)
+		})
+
+		c.cancel = cancel
+		g.calls[key] = c
+
+		go g.doCall(key, c)
 	}
 
 	g.lock.Unlock()
-	return c.result, c.err
+
+	select {
+	case <-c.done:
+		return c.get()
+	case <-ctx.Done():
+		g.leave(key, c)
+		return result, ctx.Err()
+	}
+}
+
+// CallMulti calls fn once with keys which aren't being called, and waits for other keys being called in singleflight mode.
+// It returns results of all keys found and the first error happened.
+// Keys not found in the results of fn will return ErrResultNotFound to the callers of them.
+func (g *TypedGroup[K, V]) CallMulti(keys []K, fn func(keys []K) (map[K]V, error)) (map[K]V, error) {
+	g.lock.Lock()
+
+	calls := make(map[K]*typedCall[V], len(keys))
+	waits := make(map[K]*typedCall[V], len(keys))
+	missing := make([]K, 0, len(keys))
+
+	for _, key := range keys {
+		if _, ok := calls[key]; ok {
+			continue
+		}
+
+		if _, ok := waits[key]; ok {
+			continue
+		}
+
+		if c, ok := g.calls[key]; ok {
+			c.waiters++
+			waits[key] = c
+			continue
+		}
+
+		// Results are not found until fn returns them.
+		c := newTypedCall[V](nil)
+		c.err = ErrResultNotFound
+
+		g.calls[key] = c
+		calls[key] = c
+		missing = append(missing, key)
+	}
+
+	g.lock.Unlock()
+
+	if len(missing) > 0 {
+		g.doMulti(missing, calls, fn)
+	}
+
+	var err error
+	results := make(map[K]V, len(keys))
+
+	for _, cs := range []map[K]*typedCall[V]{calls, waits} {
+		for key, c := range cs {
+			<-c.done
+
+			result, callErr := c.get()
+			if callErr == nil {
+				results[key] = result
+				continue
+			}
+
+			if err == nil && !errors.Is(callErr, ErrResultNotFound) {
+				err = callErr
+			}
+		}
+	}
+
+	return results, err
+}
+
+func (g *TypedGroup[K, V]) doMulti(keys []K, calls map[K]*typedCall[V], fn func(keys []K) (map[K]V, error)) {
+	normalReturn := false
+
+	defer func() {
+		if !normalReturn {
+			err := ErrGoexit
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+
+			for _, c := range calls {
+				c.err = err
+			}
+		}
+
+		g.lock.Lock()
+		defer g.lock.Unlock()
+
+		for key, c := range calls {
+			if !c.deleted {
+				delete(g.calls, key)
+			}
+
+			close(c.done)
+		}
+	}()
+
+	results, err := fn(keys)
+	normalReturn = true
+
+	for key, c := range calls {
+		if err != nil {
+			c.err = err
+			continue
+		}
+
+		if result, ok := results[key]; ok {
+			c.result, c.err = result, nil
+		}
+	}
 }
 
 // Delete deletes the call of key so a new call can be called.
-func (g *Group) Delete(key string) {
+func (g *TypedGroup[K, V]) Delete(key K) {
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
@@ -96,7 +373,7 @@ func (g *Group) Delete(key string) {
 }
 
 // Reset resets group to initial status.
-func (g *Group) Reset() {
+func (g *TypedGroup[K, V]) Reset() {
 	g.lock.Lock()
 	defer g.lock.Unlock()
 
@@ -15,7 +15,11 @@
 package singleflight
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math/rand"
+	"runtime"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -42,12 +46,13 @@ func testGroupCall(t *testing.T, group *Group, concurrency int) {
 			})
 
 			if err != nil {
-				t.Fatal(err)
+				t.Error(err)
+				return
 			}
 
 			r := atomic.LoadInt64(&rightResult)
 			if result != r {
-				t.Fatalf("result %d != rightResult %d", result, r)
+				t.Errorf("result %d != rightResult %d", result, r)
 			}
 		}(int64(i))
 	}
@@ -162,3 +167,336 @@ func TestGroupReset(t *testing.T) {
 		t.Fatalf("len(group.calls) %d is wrong", len(group.calls))
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedGroupCall$
+func TestTypedGroupCall(t *testing.T) {
+	group := NewTypedGroup[int64, int64](128)
+
+	var wg sync.WaitGroup
+	var calls int64
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result, err := group.Call(1, func() (int64, error) {
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt64(&calls, 1)
+				return 666, nil
+			})
+
+			if err != nil || result != 666 {
+				t.Errorf("result %d is wrong with err %+v", result, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("calls %d != 1", calls)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallMulti$
+func TestGroupCallMulti(t *testing.T) {
+	group := NewGroup(128)
+
+	var calls int64
+	var wg sync.WaitGroup
+
+	// Key "1" is being called, so CallMulti should wait for it instead of loading it again.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		result, err := group.Call("1", func() (interface{}, error) {
+			time.Sleep(100 * time.Millisecond)
+			return "call", nil
+		})
+
+		if err != nil || result.(string) != "call" {
+			t.Errorf("result %+v, err %+v is wrong", result, err)
+		}
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results, err := group.CallMulti([]string{"1", "2", "3", "3", "4"}, func(keys []string) (map[string]interface{}, error) {
+				atomic.AddInt64(&calls, 1)
+				time.Sleep(50 * time.Millisecond)
+
+				results := make(map[string]interface{}, len(keys))
+				for _, key := range keys {
+					if key == "1" {
+						t.Errorf("key %s shouldn't be called", key)
+					}
+
+					// Key 4 is missing in results.
+					if key != "4" {
+						results[key] = key
+					}
+				}
+
+				return results, nil
+			})
+
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			expect := map[string]interface{}{"1": "call", "2": "2", "3": "3"}
+			if fmt.Sprint(results) != fmt.Sprint(expect) {
+				t.Errorf("results %+v != expect %+v", results, expect)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("calls %d != 1", calls)
+	}
+
+	if len(group.calls) != 0 {
+		t.Fatalf("len(group.calls) %d != 0", len(group.calls))
+	}
+
+	callErr := errors.New("call failed")
+
+	_, err := group.CallMulti([]string{"1"}, func(keys []string) (map[string]interface{}, error) {
+		return nil, callErr
+	})
+
+	if err != callErr {
+		t.Fatalf("err %+v != callErr %+v", err, callErr)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallContext$
+func TestGroupCallContext(t *testing.T) {
+	group := NewGroup(128)
+
+	var calls int64
+	fn := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt64(&calls, 1)
+
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return "result", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// The waiter returns right away when its context is done.
+	begin := time.Now()
+	if _, err := group.CallContext(ctx, "key", fn); err != context.DeadlineExceeded {
+		t.Fatalf("err %+v != context.DeadlineExceeded", err)
+	}
+
+	if cost := time.Since(begin); cost > 50*time.Millisecond {
+		t.Fatalf("cost %s is too long", cost)
+	}
+
+	// The call keeps going, so others can wait for its result.
+	result, err := group.CallContext(context.Background(), "key", fn)
+	if err != nil || result.(string) != "result" {
+		t.Fatalf("result %+v, err %+v is wrong", result, err)
+	}
+
+	if atomic.LoadInt64(&calls) != 1 {
+		t.Fatalf("calls %d != 1", calls)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := group.CallContext(ctx, "key", fn); err != context.Canceled {
+		t.Fatalf("err %+v != context.Canceled", err)
+	}
+
+	if atomic.LoadInt64(&calls) != 1 {
+		t.Fatalf("calls %d != 1", calls)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallContextCancelAbandoned$
+func TestGroupCallContextCancelAbandoned(t *testing.T) {
+	group := NewGroup(128)
+	group.SetCancelAbandoned(true)
+
+	cancelled := make(chan struct{})
+	fn := func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if _, err := group.CallContext(ctx, "key", fn); err != context.Canceled {
+				t.Errorf("err %+v != context.Canceled", err)
+			}
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("abandoned call should be cancelled")
+	}
+
+	// The abandoned call is deleted, so a new call can be called.
+	result, err := group.CallContext(context.Background(), "key", func(ctx context.Context) (interface{}, error) {
+		return "result", nil
+	})
+
+	if err != nil || result.(string) != "result" {
+		t.Fatalf("result %+v, err %+v is wrong", result, err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallPanic$
+func TestGroupCallPanic(t *testing.T) {
+	group := NewGroup(128)
+	panicErr := errors.New("panic")
+
+	var wg sync.WaitGroup
+	var panics int64
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() {
+				r := recover()
+
+				pe, ok := r.(*PanicError)
+				if !ok {
+					t.Errorf("r %+v isn't a *PanicError", r)
+					return
+				}
+
+				if !errors.Is(pe, panicErr) || len(pe.Stack) == 0 {
+					t.Errorf("pe %+v is wrong", pe)
+					return
+				}
+
+				atomic.AddInt64(&panics, 1)
+			}()
+
+			group.Call("key", func() (interface{}, error) {
+				time.Sleep(10 * time.Millisecond)
+				panic(panicErr)
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	if panics != 10 {
+		t.Fatalf("panics %d != 10", panics)
+	}
+
+	// The key should be deleted, so later calls to the same key work.
+	if len(group.calls) != 0 {
+		t.Fatalf("len(group.calls) %d != 0", len(group.calls))
+	}
+
+	result, err := group.Call("key", func() (interface{}, error) {
+		return "result", nil
+	})
+
+	if err != nil || result.(string) != "result" {
+		t.Fatalf("result %+v, err %+v is wrong", result, err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallGoexit$
+func TestGroupCallGoexit(t *testing.T) {
+	group := NewGroup(128)
+	started := make(chan struct{})
+
+	go func() {
+		group.Call("key", func() (interface{}, error) {
+			close(started)
+			time.Sleep(10 * time.Millisecond)
+			runtime.Goexit()
+			return nil, nil
+		})
+
+		t.Error("Goexit should exit the calling goroutine")
+	}()
+
+	<-started
+
+	_, err := group.Call("key", func() (interface{}, error) {
+		return "result", nil
+	})
+
+	if err != ErrGoexit {
+		t.Fatalf("err %+v != ErrGoexit", err)
+	}
+
+	result, err := group.Call("key", func() (interface{}, error) {
+		return "result", nil
+	})
+
+	if err != nil || result.(string) != "result" {
+		t.Fatalf("result %+v, err %+v is wrong", result, err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestGroupCallMultiPanic$
+func TestGroupCallMultiPanic(t *testing.T) {
+	group := NewGroup(128)
+
+	func() {
+		defer func() {
+			if _, ok := recover().(*PanicError); !ok {
+				t.Fatal("CallMulti should panic with a *PanicError")
+			}
+		}()
+
+		group.CallMulti([]string{"key1", "key2"}, func(keys []string) (map[string]interface{}, error) {
+			panic("panic")
+		})
+	}()
+
+	if len(group.calls) != 0 {
+		t.Fatalf("len(group.calls) %d != 0", len(group.calls))
+	}
+
+	results, err := group.CallMulti([]string{"key1"}, func(keys []string) (map[string]interface{}, error) {
+		return map[string]interface{}{"key1": "result"}, nil
+	})
+
+	if err != nil || results["key1"].(string) != "result" {
+		t.Fatalf("results %+v, err %+v is wrong", results, err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+const (
+	depth      = 4
+	minWidth   = 64
+	maxCounter = 15
+)
+
+var (
+	seeds = [depth]uint64{0xc3a5c85c97cb3127, 0xb492b66fbe98f273, 0x9ae16a3b2f90404f, 0xcbf29ce484222325}
+)
+
+func nextPowOf2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+
+	return p
+}
+
+// Sketch is a count-min sketch which estimates the frequencies of hashes in a small memory.
+// Each counter is limited to 15, and all counters will be halved after resetAt increments,
+// so the frequencies of old hashes decay over time.
+// More details see https://en.wikipedia.org/wiki/Count%E2%80%93min_sketch.
+type Sketch struct {
+	counters [depth][]uint8
+	mask     uint64
+
+	increments int
+	resetAt    int
+}
+
+// New creates a sketch with width counters in each row.
+// The width will be adjusted to a power of 2 and it's at least 64.
+// All counters will be halved after resetAt increments, and zero resetAt means never.
+func New(width int, resetAt int) *Sketch {
+	if width < minWidth {
+		width = minWidth
+	}
+
+	width = nextPowOf2(width)
+
+	sketch := &Sketch{
+		mask:    uint64(width - 1),
+		resetAt: resetAt,
+	}
+
+	for i := range sketch.counters {
+		sketch.counters[i] = make([]uint8, width)
+	}
+
+	return sketch
+}
+
+func (s *Sketch) indexOf(hash uint64, row int) uint64 {
+	// See fmix64 in murmur3.
+	h := hash ^ seeds[row]
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+
+	return h & s.mask
+}
+
+// Increment increases the frequency of hash.
+func (s *Sketch) Increment(hash uint64) {
+	for row := range s.counters {
+		index := s.indexOf(hash, row)
+
+		if s.counters[row][index] < maxCounter {
+			s.counters[row][index]++
+		}
+	}
+
+	s.increments++
+
+	if s.resetAt > 0 && s.increments >= s.resetAt {
+		s.age()
+	}
+}
+
+// Estimate returns the estimated frequency of hash.
+func (s *Sketch) Estimate(hash uint64) uint8 {
+	estimate := uint8(maxCounter)
+
+	for row := range s.counters {
+		index := s.indexOf(hash, row)
+
+		if counter := s.counters[row][index]; counter < estimate {
+			estimate = counter
+		}
+	}
+
+	return estimate
+}
+
+// age halves all counters in sketch.
+func (s *Sketch) age() {
+	for row := range s.counters {
+		for i := range s.counters[row] {
+			s.counters[row][i] >>= 1
+		}
+	}
+
+	s.increments /= 2
+}
+
+// Reset resets sketch to initial status which is like a new sketch.
+func (s *Sketch) Reset() {
+	for row := range s.counters {
+		for i := range s.counters[row] {
+			s.counters[row][i] = 0
+		}
+	}
+
+	s.increments = 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sketch
+
+import "testing"
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSketch$
+func TestSketch(t *testing.T) {
+	sketch := New(1, 0)
+
+	if len(sketch.counters[0]) != minWidth {
+		t.Fatalf("len(sketch.counters[0]) %d != minWidth %d", len(sketch.counters[0]), minWidth)
+	}
+
+	sketch = New(100, 0)
+
+	if len(sketch.counters[0]) != 128 {
+		t.Fatalf("len(sketch.counters[0]) %d != 128", len(sketch.counters[0]))
+	}
+
+	for i := 0; i < 10; i++ {
+		sketch.Increment(1)
+	}
+
+	sketch.Increment(2)
+
+	if estimate := sketch.Estimate(1); estimate < 10 {
+		t.Fatalf("estimate %d < 10", estimate)
+	}
+
+	if estimate := sketch.Estimate(2); estimate < 1 || estimate >= 10 {
+		t.Fatalf("estimate %d is wrong", estimate)
+	}
+
+	for i := 0; i < 100; i++ {
+		sketch.Increment(3)
+	}
+
+	if estimate := sketch.Estimate(3); estimate != maxCounter {
+		t.Fatalf("estimate %d != maxCounter %d", estimate, maxCounter)
+	}
+
+	sketch.Reset()
+
+	if estimate := sketch.Estimate(1); estimate != 0 {
+		t.Fatalf("estimate %d != 0", estimate)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSketchAge$
+func TestSketchAge(t *testing.T) {
+	sketch := New(16, 10)
+
+	for i := 0; i < 9; i++ {
+		sketch.Increment(1)
+	}
+
+	if estimate := sketch.Estimate(1); estimate != 9 {
+		t.Fatalf("estimate %d != 9", estimate)
+	}
+
+	sketch.Increment(1)
+
+	if estimate := sketch.Estimate(1); estimate != 5 {
+		t.Fatalf("estimate %d != 5", estimate)
+	}
+
+	if sketch.increments != 5 {
+		t.Fatalf("sketch.increments %d != 5", sketch.increments)
+	}
+}
@@ -0,0 +1,516 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// refresher stores the load function of a key and refreshes it.
+type refresher struct {
+	load func(ctx context.Context) (value interface{}, err error)
+	ttl  time.Duration
+
+	// expiration is the time when the loaded value becomes stale.
+	expiration atomic.Int64
+	refreshing atomic.Bool
+}
+
+// loadReportableCache is a cache which reports loads.
+type loadReportableCache interface {
+	// reportLoaded records and reports a load of key.
+	reportLoaded(key string, value interface{}, ttl time.Duration, err error)
+}
+
+// refreshableCache remembers the load functions of keys loaded by Load, and reloads them in background
+// before they are expired. Also, it serves stale values up to maxStale after they are expired.
+type refreshableCache struct {
+	*config
+
+	cache      Cache
+	loader     *loader
+	refreshers map[string]*refresher
+	lock       sync.RWMutex
+
+	// writeLock is held by writes of keys in read mode and by refreshes in write mode,
+	// so a refresh won't overwrite a key which is written or removed after its load function returns.
+	writeLock sync.RWMutex
+
+	// ctx is canceled when cache is closed, so refreshes won't set their values any more.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newRefreshableCache(conf *config, cache Cache) Cache {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rc := &refreshableCache{
+		config:     conf,
+		cache:      cache,
+		loader:     newLoader(conf),
+		refreshers: make(map[string]*refresher, mapInitialCap),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	return rc
+}
+
+func (rc *refreshableCache) refresherOf(key string) *refresher {
+	rc.lock.RLock()
+	defer rc.lock.RUnlock()
+
+	return rc.refreshers[key]
+}
+
+func (rc *refreshableCache) remember(key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) {
+	r := &refresher{load: load, ttl: ttl}
+	r.expiration.Store(rc.now() + ttl.Nanoseconds())
+
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	rc.refreshers[key] = r
+}
+
+func (rc *refreshableCache) forget(keys ...string) {
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	for _, key := range keys {
+		delete(rc.refreshers, key)
+	}
+}
+
+// refreshAhead starts a background reload of key if key is in refresh window.
+// There is at most one reload of the same key at a time.
+func (rc *refreshableCache) refreshAhead(key string) {
+	r := rc.refresherOf(key)
+	if r == nil {
+		return
+	}
+
+	if rc.now() < r.expiration.Load()-rc.refreshWindow.Nanoseconds() {
+		return
+	}
+
+	if rc.ctx.Err() != nil {
+		return
+	}
+
+	if !r.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+
+	go rc.refresh(key, r)
+}
+
+// load calls the load function of r by the loader of key, so it shares the singleflight group and load timeout
+// with Load of key. A panic of the load function is returned as an error instead of crashing the process.
+func (rc *refreshableCache) load(key string, r *refresher) (value interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("cachego: refresh of %s panics: %v", key, p)
+		}
+	}()
+
+	loader := loaderOfKey(rc.cache, key)
+	if loader == nil {
+		loader = rc.loader
+	}
+
+	load := r.load
+
+	// The context passed to load by singleflight isn't canceled by rc.ctx, so cancel it after closing.
+	return loader.LoadContext(rc.ctx, key, func(ctx context.Context) (value interface{}, err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stop := context.AfterFunc(rc.ctx, cancel)
+		defer stop()
+
+		return load(ctx)
+	})
+}
+
+// refresh reloads key by the load function of r.
+// The loaded value is set only if r is still the refresher of key, so it won't overwrite a newer value or
+// bring a removed key back. A failed refresh keeps the stale value in cache, and reports the error as a load
+// if cache has a reporter.
+func (rc *refreshableCache) refresh(key string, r *refresher) {
+	defer r.refreshing.Store(false)
+
+	value, err := rc.load(key, r)
+	if rc.ctx.Err() != nil {
+		return
+	}
+
+	rc.writeLock.Lock()
+	defer rc.writeLock.Unlock()
+
+	if rc.refresherOf(key) != r {
+		return
+	}
+
+	ttl := r.ttl + rc.maxStale
+	if lrc, ok := rc.cache.(loadReportableCache); ok {
+		lrc.reportLoaded(key, value, ttl, err)
+	}
+
+	if err != nil {
+		return
+	}
+
+	rc.cache.Set(key, value, ttl)
+	r.expiration.Store(rc.now() + r.ttl.Nanoseconds())
+}
+
+func (rc *refreshableCache) dump() []DumpEntry {
+	if dc, ok := rc.cache.(dumpableCache); ok {
+		return dc.dump()
+	}
+
+	return nil
+}
+
+func (rc *refreshableCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	return Peek(rc.cache, key)
+}
+
+func (rc *refreshableCache) restore(entry *DumpEntry) {
+	if restorable, ok := rc.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	rc.cache.Set(entry.Key, entry.Value, entry.TTL)
+}
+
+// Get gets the value of key from cache and returns value if found.
+// It starts a background reload if key is loaded by Load and is in refresh window.
+// See Cache interface.
+func (rc *refreshableCache) Get(key string) (value interface{}, found bool) {
+	value, found = rc.cache.Get(key)
+	if found {
+		rc.refreshAhead(key)
+	}
+
+	return value, found
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// Key won't be refreshed any more after setting.
+// See Cache interface.
+func (rc *refreshableCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (rc *refreshableCache) Remove(key string) (removedValue interface{}) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// Notice that the ttl of a loaded entry includes its max staleness.
+// See Cache interface.
+func (rc *refreshableCache) TTL(key string) (ttl time.Duration, found bool) {
+	return rc.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// Key won't be refreshed any more after resetting its ttl.
+// See Cache interface.
+func (rc *refreshableCache) Touch(key string, ttl time.Duration) (found bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// Key won't be refreshed any more after resetting its ttl.
+// See Cache interface.
+func (rc *refreshableCache) Expire(key string, ttl time.Duration) (found bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// Key won't be refreshed any more after resetting its ttl.
+// See Cache interface.
+func (rc *refreshableCache) Persist(key string) (found bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// It starts a background reload like Get if key is found, or key won't be refreshed any more after setting.
+// See Cache interface.
+func (rc *refreshableCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	actual, found = rc.cache.GetOrSet(key, value, ttl)
+	if found {
+		rc.refreshAhead(key)
+	} else {
+		rc.forget(key)
+	}
+
+	return actual, found
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// Key won't be refreshed any more after setting.
+// See Cache interface.
+func (rc *refreshableCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	actual, stored = rc.cache.SetIfAbsent(key, value, ttl)
+	if stored {
+		rc.forget(key)
+	}
+
+	return actual, stored
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// Key won't be refreshed any more after swapping.
+// See Cache interface.
+func (rc *refreshableCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	swapped = rc.cache.CompareAndSwap(key, old, new, ttl, equal)
+	if swapped {
+		rc.forget(key)
+	}
+
+	return swapped
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// Key won't be refreshed any more after updating.
+// See Cache interface.
+func (rc *refreshableCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Update(key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (rc *refreshableCache) GetAndRemove(key string) (value interface{}, found bool) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.GetAndRemove(key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// Key won't be refreshed any more after adding.
+// See Cache interface.
+func (rc *refreshableCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Incr(key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// Key won't be refreshed any more after adding.
+// See Cache interface.
+func (rc *refreshableCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(key)
+	return rc.cache.Decr(key, delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (rc *refreshableCache) Size() (size int) {
+	return rc.cache.Size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (rc *refreshableCache) Cost() (cost int64) {
+	return rc.cache.Cost()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// It also cleans the load functions of keys which are too stale to serve.
+// See Cache interface.
+func (rc *refreshableCache) GC() (cleans int) {
+	cleans = rc.cache.GC()
+
+	rc.lock.Lock()
+	defer rc.lock.Unlock()
+
+	now := rc.now()
+	for key, r := range rc.refreshers {
+		if now > r.expiration.Load()+rc.maxStale.Nanoseconds() {
+			delete(rc.refreshers, key)
+		}
+	}
+
+	return cleans
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (rc *refreshableCache) Reset() {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.lock.Lock()
+	rc.refreshers = make(map[string]*refresher, mapInitialCap)
+	rc.lock.Unlock()
+
+	rc.cache.Reset()
+}
+
+// Close closes cache.
+// Refreshes in background are canceled, and the context passed to load functions of LoadContext is canceled.
+// See Cache interface.
+func (rc *refreshableCache) Close() error {
+	rc.cancel()
+	return rc.cache.Close()
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// Notice that the ttl of a loaded entry includes its max staleness.
+// See Cache interface.
+func (rc *refreshableCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rc.cache.Range(fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (rc *refreshableCache) Keys() (keys []string) {
+	return rc.cache.Keys()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// The load function will be remembered, so key will be refreshed in background before it's expired.
+// See Cache interface.
+func (rc *refreshableCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	if ttl <= NoTTL {
+		rc.forget(key)
+		return rc.cache.Load(key, ttl, load)
+	}
+
+	value, err = rc.cache.Load(key, ttl+rc.maxStale, load)
+	if err != nil {
+		return value, err
+	}
+
+	rc.remember(key, ttl, func(ctx context.Context) (value interface{}, err error) {
+		return load()
+	})
+
+	return value, nil
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// The load function will be remembered like Load, and it will be called with a context canceled by Close and
+// timeout by the load timeout when refreshing.
+// See Cache interface.
+func (rc *refreshableCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	if ttl <= NoTTL {
+		rc.forget(key)
+		return rc.cache.LoadContext(ctx, key, ttl, load)
+	}
+
+	value, err = rc.cache.LoadContext(ctx, key, ttl+rc.maxStale, load)
+	if err != nil {
+		return value, err
+	}
+
+	// Refreshes run in background, so they don't have the context of caller and are canceled after closing.
+	rc.remember(key, ttl, load)
+	return value, nil
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (rc *refreshableCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	values, missedKeys = rc.cache.GetMulti(keys)
+
+	for key := range values {
+		rc.refreshAhead(key)
+	}
+
+	return values, missedKeys
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (rc *refreshableCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.lock.Lock()
+	for key := range entries {
+		delete(rc.refreshers, key)
+	}
+	rc.lock.Unlock()
+
+	rc.cache.SetMulti(entries, ttl)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (rc *refreshableCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	rc.writeLock.RLock()
+	defer rc.writeLock.RUnlock()
+
+	rc.forget(keys...)
+	return rc.cache.RemoveMulti(keys)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// Notice that keys loaded by LoadMulti won't be refreshed.
+// See Cache interface.
+func (rc *refreshableCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return rc.cache.LoadMulti(keys, ttl, load)
+}
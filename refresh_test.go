@@ -0,0 +1,374 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRefreshableCache(opts ...Option) *refreshableCache {
+	conf := newDefaultConfig()
+	applyOptions(conf, opts)
+
+	return newRefreshableCache(conf, newStandardCache(conf)).(*refreshableCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCache$
+func TestRefreshableCache(t *testing.T) {
+	cache := newTestRefreshableCache(WithRefreshAhead(time.Millisecond), WithMaxStale(time.Millisecond))
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheRefreshAhead$
+func TestRefreshableCacheRefreshAhead(t *testing.T) {
+	cache := newTestRefreshableCache(WithRefreshAhead(100 * time.Millisecond))
+
+	var loads int64
+	load := func() (interface{}, error) {
+		time.Sleep(10 * time.Millisecond)
+		return atomic.AddInt64(&loads, 1), nil
+	}
+
+	value, err := cache.Load("key", 200*time.Millisecond, load)
+	if err != nil || value.(int64) != 1 {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	// Not in refresh window yet.
+	cache.Get("key")
+	time.Sleep(20 * time.Millisecond)
+
+	if atomic.LoadInt64(&loads) != 1 {
+		t.Fatalf("loads %d != 1", loads)
+	}
+
+	time.Sleep(90 * time.Millisecond)
+
+	// In refresh window, so the old value is returned and only one reload happens.
+	for i := 0; i < 10; i++ {
+		value, ok := cache.Get("key")
+		if !ok || value.(int64) != 1 {
+			t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+		}
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if atomic.LoadInt64(&loads) != 2 {
+		t.Fatalf("loads %d != 2", loads)
+	}
+
+	value, ok := cache.Get("key")
+	if !ok || value.(int64) != 2 {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	// Key set by Set won't be refreshed.
+	cache.Set("key", int64(0), 50*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("key")
+	time.Sleep(20 * time.Millisecond)
+
+	if atomic.LoadInt64(&loads) != 2 {
+		t.Fatalf("loads %d != 2", loads)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheRefreshWritten$
+func TestRefreshableCacheRefreshWritten(t *testing.T) {
+	cache := newTestRefreshableCache(WithRefreshAhead(time.Hour))
+
+	for _, key := range []string{"set", "removed"} {
+		cache.Load(key, time.Minute, func() (interface{}, error) {
+			return "value", nil
+		})
+	}
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	refreshed := make(chan struct{}, 2)
+
+	for _, key := range []string{"set", "removed"} {
+		cache.refresherOf(key).load = func(ctx context.Context) (interface{}, error) {
+			started <- struct{}{}
+			<-release
+
+			refreshed <- struct{}{}
+			return "refreshed", nil
+		}
+
+		cache.Get(key)
+	}
+
+	<-started
+	<-started
+
+	// Keys written or removed while refreshing shouldn't be overwritten by the refreshes.
+	cache.Set("set", "new", NoTTL)
+	cache.Remove("removed")
+	close(release)
+
+	<-refreshed
+	<-refreshed
+
+	// Wait for the refreshes to finish setting.
+	cache.writeLock.Lock()
+	cache.writeLock.Unlock()
+
+	if value, ok := cache.Get("set"); !ok || value.(string) != "new" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	if value, ok := cache.Get("removed"); ok {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheClose$
+func TestRefreshableCacheClose(t *testing.T) {
+	cache := newTestRefreshableCache(WithRefreshAhead(time.Hour))
+
+	started := make(chan struct{})
+	canceled := make(chan error, 1)
+	loaded := false
+
+	cache.LoadContext(context.Background(), "key", time.Minute, func(ctx context.Context) (interface{}, error) {
+		if !loaded {
+			loaded = true
+			return "value", nil
+		}
+
+		close(started)
+		<-ctx.Done()
+
+		canceled <- ctx.Err()
+		return "refreshed", nil
+	})
+
+	cache.Get("key")
+	<-started
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-canceled:
+		if err != context.Canceled {
+			t.Fatalf("err %+v != context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("refresh isn't canceled")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheMaxStale$
+func TestRefreshableCacheMaxStale(t *testing.T) {
+	cache := newTestRefreshableCache(WithMaxStale(100 * time.Millisecond))
+
+	refreshErr := errors.New("refresh failed")
+	cache.Load("key", 20*time.Millisecond, func() (interface{}, error) {
+		return "value", nil
+	})
+
+	cache.Load("failed", 20*time.Millisecond, func() (interface{}, error) {
+		return "value", nil
+	})
+
+	cache.refresherOf("failed").load = func(ctx context.Context) (interface{}, error) {
+		return nil, refreshErr
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	// Stale values are served and reloaded in background.
+	for _, key := range []string{"key", "failed"} {
+		value, ok := cache.Get(key)
+		if !ok || value.(string) != "value" {
+			t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// A failed refresh keeps the stale value.
+	value, ok := cache.Get("failed")
+	if !ok || value.(string) != "value" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+
+	if value, ok := cache.Get("failed"); ok {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	if value, ok := cache.Get("key"); !ok || value.(string) != "value" {
+		t.Fatalf("value %+v, ok %+v is wrong", value, ok)
+	}
+
+	cache.GC()
+
+	if cache.refresherOf("failed") != nil {
+		t.Fatal("refresher of failed should be cleaned")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheReport$
+func TestRefreshableCacheReport(t *testing.T) {
+	refreshErr := errors.New("refresh failed")
+	errs := make(chan error, 1)
+
+	cache, reporter := NewCacheWithReport(
+		WithGC(0),
+		WithRefreshAhead(time.Second),
+		WithReportLoad(func(reporter *Reporter, key string, value interface{}, ttl time.Duration, err error) {
+			if err != nil {
+				errs <- err
+			}
+		}),
+	)
+
+	cache.Load("key", time.Second, func() (interface{}, error) {
+		return "value", nil
+	})
+
+	cache.(*closableCache).cache.(*refreshableCache).refresherOf("key").load = func(ctx context.Context) (interface{}, error) {
+		return nil, refreshErr
+	}
+
+	cache.Get("key")
+
+	select {
+	case err := <-errs:
+		if err != refreshErr {
+			t.Fatalf("err %+v != refreshErr %+v", err, refreshErr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("refresh error isn't reported")
+	}
+
+	if reporter.CountLoad() != 2 {
+		t.Fatalf("reporter.CountLoad() %d != 2", reporter.CountLoad())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRefreshableCacheRefreshLoader$
+func TestRefreshableCacheRefreshLoader(t *testing.T) {
+	errs := make(chan error, 1)
+
+	cache, _ := NewCacheWithReport(
+		WithGC(0),
+		WithShardings(4),
+		WithRefreshAhead(time.Hour),
+		WithLoadTimeout(10*time.Millisecond),
+		WithReportLoad(func(reporter *Reporter, key string, value interface{}, ttl time.Duration, err error) {
+			if err == nil {
+				return
+			}
+
+			select {
+			case errs <- err:
+			default:
+			}
+		}),
+	)
+
+	defer cache.Close()
+
+	rc := cache.(*closableCache).cache.(*refreshableCache)
+	waitErr := func() error {
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("refresh error isn't reported")
+			return nil
+		}
+	}
+
+	// A panic in refresh should be reported instead of crashing.
+	cache.Load("key", time.Minute, func() (interface{}, error) {
+		return "value", nil
+	})
+
+	rc.refresherOf("key").load = func(ctx context.Context) (interface{}, error) {
+		panic("refresh panics")
+	}
+
+	cache.Get("key")
+	if err := waitErr(); err == nil {
+		t.Fatal("err is nil")
+	}
+
+	// A refresh should be timeout by load timeout.
+	waitFor(t, func() bool {
+		return !rc.refresherOf("key").refreshing.Load()
+	})
+
+	rc.refresherOf("key").load = func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	cache.Get("key")
+	if err := waitErr(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err %+v isn't context.DeadlineExceeded", err)
+	}
+
+	// A refresh should share the singleflight call with Load of the same key.
+	waitFor(t, func() bool {
+		return !rc.refresherOf("key").refreshing.Load()
+	})
+
+	var loads int64
+	unblock := make(chan struct{})
+	rc.refresherOf("key").load = func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt64(&loads, 1)
+		<-unblock
+		return "refreshed", nil
+	}
+
+	// The timeout call may be still in singleflight group, so keep getting until the refresh calls load.
+	waitFor(t, func() bool {
+		cache.Get("key")
+		return atomic.LoadInt64(&loads) == 1
+	})
+
+	loaded := make(chan interface{}, 1)
+	go func() {
+		value, _ := cache.Load("key", time.Minute, func() (interface{}, error) {
+			atomic.AddInt64(&loads, 1)
+			return "loaded", nil
+		})
+
+		loaded <- value
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	close(unblock)
+
+	if value := <-loaded; value != "refreshed" {
+		t.Fatalf("value %+v != refreshed", value)
+	}
+
+	if loads := atomic.LoadInt64(&loads); loads != 1 {
+		t.Fatalf("loads %d != 1", loads)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+// RemovalCause is the cause of removing an entry from cache.
+type RemovalCause int
+
+const (
+	// RemovalExpired means the entry is removed because it's expired.
+	RemovalExpired RemovalCause = iota + 1
+
+	// RemovalCapacity means the entry is evicted because cache reaches to its max entries.
+	RemovalCapacity
+
+	// RemovalExplicit means the entry is removed by Remove.
+	RemovalExplicit
+
+	// RemovalReplaced means the value of entry is replaced by Set.
+	RemovalReplaced
+
+	// RemovalReset means the entry is removed by Reset.
+	RemovalReset
+)
+
+// String returns the removal cause in string form.
+func (rc RemovalCause) String() string {
+	switch rc {
+	case RemovalExpired:
+		return "expired"
+	case RemovalCapacity:
+		return "capacity"
+	case RemovalExplicit:
+		return "explicit"
+	case RemovalReplaced:
+		return "replaced"
+	case RemovalReset:
+		return "reset"
+	default:
+		return "unknown"
+	}
+}
+
+// replacedCause returns the cause of replacing an entry's value.
+// An expired entry is treated as expired rather than replaced.
+func replacedCause[K comparable, V any](entry *typedEntry[K, V]) RemovalCause {
+	if entry.expired(0) {
+		return RemovalExpired
+	}
+
+	return RemovalReplaced
+}
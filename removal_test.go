@@ -0,0 +1,132 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+type testRemoval struct {
+	key   string
+	value interface{}
+	cause RemovalCause
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestRemovalCause$
+func TestRemovalCause(t *testing.T) {
+	causes := map[RemovalCause]string{
+		RemovalExpired:  "expired",
+		RemovalCapacity: "capacity",
+		RemovalExplicit: "explicit",
+		RemovalReplaced: "replaced",
+		RemovalReset:    "reset",
+		0:               "unknown",
+	}
+
+	for cause, expect := range causes {
+		if cause.String() != expect {
+			t.Fatalf("cause.String() %s != expect %s", cause.String(), expect)
+		}
+	}
+}
+
+func testCacheOnEvicted(t *testing.T, cacheType CacheType, shardings int) {
+	var removals []testRemoval
+
+	onEvicted := func(key string, value interface{}, cause RemovalCause) {
+		removals = append(removals, testRemoval{key: key, value: value, cause: cause})
+	}
+
+	expect := func(removal testRemoval) {
+		t.Helper()
+
+		if len(removals) != 1 {
+			t.Fatalf("%s: len(removals) %d != 1", cacheType, len(removals))
+		}
+
+		if removals[0] != removal {
+			t.Fatalf("%s: removals[0] %+v != removal %+v", cacheType, removals[0], removal)
+		}
+
+		removals = removals[:0]
+	}
+
+	cache := NewCache(WithGC(0), WithMaxEntries(maxTestEntries), WithShardings(shardings), WithOnEvicted(onEvicted), func(conf *config) {
+		conf.cacheType = cacheType
+	})
+
+	cache.Set("key", "value", NoTTL)
+	cache.Set("key", "new", NoTTL)
+	expect(testRemoval{key: "key", value: "value", cause: RemovalReplaced})
+
+	cache.Remove("key")
+	expect(testRemoval{key: "key", value: "new", cause: RemovalExplicit})
+
+	cache.Remove("key")
+	if len(removals) != 0 {
+		t.Fatalf("%s: len(removals) %d != 0", cacheType, len(removals))
+	}
+
+	cache.Set("key", "value", time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	cache.GC()
+	expect(testRemoval{key: "key", value: "value", cause: RemovalExpired})
+
+	cache.Set("key", "value", NoTTL)
+	cache.Reset()
+	expect(testRemoval{key: "key", value: "value", cause: RemovalReset})
+
+	if shardings > 0 {
+		return
+	}
+
+	for i := 0; i < maxTestEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	evictedValue := cache.Set("new", "new", NoTTL)
+
+	// Some caches may evict entries later, so only check if entries are evicted by capacity.
+	for i := 0; i < maxTestEntries; i++ {
+		data := "more" + strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	if len(removals) == 0 {
+		t.Fatalf("%s: len(removals) == 0", cacheType)
+	}
+
+	for _, removal := range removals {
+		if removal.cause != RemovalCapacity {
+			t.Fatalf("%s: removal %+v cause != RemovalCapacity", cacheType, removal)
+		}
+	}
+
+	if evictedValue != nil && removals[0].value != evictedValue {
+		t.Fatalf("%s: removals[0].value %+v != evictedValue %+v", cacheType, removals[0].value, evictedValue)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestCacheOnEvicted$
+func TestCacheOnEvicted(t *testing.T) {
+	for cacheType := range newCaches {
+		testCacheOnEvicted(t, cacheType, 0)
+		testCacheOnEvicted(t, cacheType, testShardings)
+	}
+}
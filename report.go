@@ -15,19 +15,32 @@
 package cachego
 
 import (
+	"context"
 	"sync/atomic"
 	"time"
 )
 
+// sizedCache is a cache which can return its size.
+type sizedCache interface {
+	Size() (size int)
+}
+
+// costedCache is a cache which can return its cost.
+type costedCache interface {
+	Cost() (cost int64)
+}
+
 // Reporter stores some values for reporting.
 type Reporter struct {
 	conf  *config
-	cache Cache
+	cache sizedCache
 
 	missedCount uint64
 	hitCount    uint64
 	gcCount     uint64
 	loadCount   uint64
+
+	negativeHitCount uint64
 }
 
 func (r *Reporter) increaseMissedCount() {
@@ -38,6 +51,10 @@ func (r *Reporter) increaseHitCount() {
 	atomic.AddUint64(&r.hitCount, 1)
 }
 
+func (r *Reporter) increaseNegativeHitCount() {
+	atomic.AddUint64(&r.negativeHitCount, 1)
+}
+
 func (r *Reporter) increaseGCCount() {
 	atomic.AddUint64(&r.gcCount, 1)
 }
@@ -77,6 +94,16 @@ func (r *Reporter) CacheSize() int {
 	return r.cache.Size()
 }
 
+// CacheCost returns the cost of cache.
+// It returns 0 if cache can't return its cost.
+func (r *Reporter) CacheCost() int64 {
+	if cc, ok := r.cache.(costedCache); ok {
+		return cc.Cost()
+	}
+
+	return 0
+}
+
 // CountMissed returns the missed count.
 func (r *Reporter) CountMissed() uint64 {
 	return atomic.LoadUint64(&r.missedCount)
@@ -97,6 +124,12 @@ func (r *Reporter) CountLoad() uint64 {
 	return atomic.LoadUint64(&r.loadCount)
 }
 
+// CountNegativeHit returns the negative hit count which means loading keys having cached errors.
+// See WithNegativeTTL.
+func (r *Reporter) CountNegativeHit() uint64 {
+	return atomic.LoadUint64(&r.negativeHitCount)
+}
+
 // MissedRate returns the missed rate.
 func (r *Reporter) MissedRate() float64 {
 	hit := r.CountHit()
@@ -126,6 +159,8 @@ func (r *Reporter) HitRate() float64 {
 type reportableCache struct {
 	*config
 	*Reporter
+
+	cache Cache
 }
 
 func report(conf *config, cache Cache) (Cache, *Reporter) {
@@ -136,14 +171,42 @@ func report(conf *config, cache Cache) (Cache, *Reporter) {
 		missedCount: 0,
 		gcCount:     0,
 		loadCount:   0,
+
+		negativeHitCount: 0,
 	}
 
-	cache = &reportableCache{
+	reportable := &reportableCache{
 		config:   conf,
 		Reporter: reporter,
+		cache:    cache,
 	}
 
-	return cache, reporter
+	return reportable, reporter
+}
+
+func (rc *reportableCache) loaderOfKey(key string) *loader {
+	return loaderOfKey(rc.cache, key)
+}
+
+func (rc *reportableCache) dump() []DumpEntry {
+	if dc, ok := rc.cache.(dumpableCache); ok {
+		return dc.dump()
+	}
+
+	return nil
+}
+
+func (rc *reportableCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	return Peek(rc.cache, key)
+}
+
+func (rc *reportableCache) restore(entry *DumpEntry) {
+	if restorable, ok := rc.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	rc.cache.Set(entry.Key, entry.Value, entry.TTL)
 }
 
 // Get gets the value of key from cache and returns value if found.
@@ -183,12 +246,84 @@ func (rc *reportableCache) Remove(key string) (removedValue interface{}) {
 	return rc.cache.Remove(key)
 }
 
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (rc *reportableCache) TTL(key string) (ttl time.Duration, found bool) {
+	return rc.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (rc *reportableCache) Touch(key string, ttl time.Duration) (found bool) {
+	return rc.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (rc *reportableCache) Expire(key string, ttl time.Duration) (found bool) {
+	return rc.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (rc *reportableCache) Persist(key string) (found bool) {
+	return rc.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (rc *reportableCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	return rc.cache.GetOrSet(key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (rc *reportableCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	return rc.cache.SetIfAbsent(key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (rc *reportableCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	return rc.cache.CompareAndSwap(key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (rc *reportableCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	return rc.cache.Update(key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (rc *reportableCache) GetAndRemove(key string) (value interface{}, found bool) {
+	return rc.cache.GetAndRemove(key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (rc *reportableCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	return rc.cache.Incr(key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (rc *reportableCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return rc.cache.Decr(key, delta, ttl)
+}
+
 // Size returns the count of keys in cache.
 // See Cache interface.
 func (rc *reportableCache) Size() (size int) {
 	return rc.cache.Size()
 }
 
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (rc *reportableCache) Cost() (cost int64) {
+	return rc.cache.Cost()
+}
+
 // GC cleans the expired keys in cache and returns the exact count cleaned.
 // See Cache interface.
 func (rc *reportableCache) GC() (cleans int) {
@@ -216,18 +351,142 @@ func (rc *reportableCache) Reset() {
 	rc.cache.Reset()
 }
 
+// Close closes cache.
+// See Cache interface.
+func (rc *reportableCache) Close() error {
+	return rc.cache.Close()
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (rc *reportableCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rc.cache.Range(fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (rc *reportableCache) Keys() (keys []string) {
+	return rc.cache.Keys()
+}
+
+// reportLoaded records and reports a load of key.
+func (rc *reportableCache) reportLoaded(key string, value interface{}, ttl time.Duration, err error) {
+	if rc.recordLoad {
+		rc.increaseLoadCount()
+	}
+
+	if rc.reportLoad != nil {
+		rc.reportLoad(rc.Reporter, key, value, ttl, err)
+	}
+}
+
 // Load loads a key with ttl to cache and returns an error if failed.
 // See Cache interface.
 func (rc *reportableCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
 	value, err = rc.cache.Load(key, ttl, load)
 
+	// A negative hit doesn't call load function, so it's not a load.
+	if isNegativeHit(err) {
+		if rc.recordHit {
+			rc.increaseNegativeHitCount()
+		}
+
+		return value, err
+	}
+
+	rc.reportLoaded(key, value, ttl, err)
+	return value, err
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// See Cache interface.
+func (rc *reportableCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	value, err = rc.cache.LoadContext(ctx, key, ttl, load)
+
+	// A negative hit doesn't call load function, so it's not a load.
+	if isNegativeHit(err) {
+		if rc.recordHit {
+			rc.increaseNegativeHitCount()
+		}
+
+		return value, err
+	}
+
+	rc.reportLoaded(key, value, ttl, err)
+	return value, err
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (rc *reportableCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	values, missedKeys = rc.cache.GetMulti(keys)
+
+	if rc.recordHit {
+		atomic.AddUint64(&rc.hitCount, uint64(len(values)))
+	}
+
+	if rc.reportHit != nil {
+		for key, value := range values {
+			rc.reportHit(rc.Reporter, key, value)
+		}
+	}
+
+	if rc.recordMissed {
+		atomic.AddUint64(&rc.missedCount, uint64(len(missedKeys)))
+	}
+
+	if rc.reportMissed != nil {
+		for _, key := range missedKeys {
+			rc.reportMissed(rc.Reporter, key)
+		}
+	}
+
+	return values, missedKeys
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (rc *reportableCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	rc.cache.SetMulti(entries, ttl)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (rc *reportableCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	return rc.cache.RemoveMulti(keys)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (rc *reportableCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	if load == nil {
+		return rc.cache.LoadMulti(keys, ttl, nil)
+	}
+
+	// Only the keys passed to load are loaded, and others are got from cache or loaded by others.
+	loaded := false
+	var loadedKeys []string
+
+	values, err = rc.cache.LoadMulti(keys, ttl, func(missedKeys []string) (map[string]interface{}, error) {
+		loaded = true
+		loadedKeys = missedKeys
+
+		return load(missedKeys)
+	})
+
+	if !loaded {
+		return values, err
+	}
+
 	if rc.recordLoad {
 		rc.increaseLoadCount()
 	}
 
 	if rc.reportLoad != nil {
-		rc.reportLoad(rc.Reporter, key, value, ttl, err)
+		for _, key := range loadedKeys {
+			rc.reportLoad(rc.Reporter, key, values[key], ttl, err)
+		}
 	}
 
-	return value, err
+	return values, err
 }
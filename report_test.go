@@ -119,6 +119,37 @@ func TestReportableCacheReportHit(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestReportableCacheGetMulti$
+func TestReportableCacheGetMulti(t *testing.T) {
+	hits := make(map[string]interface{})
+	var misses []string
+
+	cache, reporter := NewCacheWithReport(
+		WithGC(0),
+		WithReportHit(func(reporter *Reporter, key string, value interface{}) {
+			hits[key] = value
+		}),
+		WithReportMissed(func(reporter *Reporter, key string) {
+			misses = append(misses, key)
+		}),
+	)
+
+	cache.Set("key", "value", NoTTL)
+	cache.GetMulti([]string{"key", "missed1", "missed2"})
+
+	if reporter.CountHit() != 1 || reporter.CountMissed() != 2 {
+		t.Fatalf("reporter.CountHit() %d, reporter.CountMissed() %d is wrong", reporter.CountHit(), reporter.CountMissed())
+	}
+
+	if len(hits) != 1 || hits["key"].(string) != "value" {
+		t.Fatalf("hits %+v is wrong", hits)
+	}
+
+	if len(misses) != 2 {
+		t.Fatalf("misses %+v is wrong", misses)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestReportableCacheReportGC$
 func TestReportableCacheReportGC(t *testing.T) {
 	cache, reporter := newTestReportableCache()
@@ -209,6 +240,47 @@ func TestReportableCacheReportLoad(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestReportableCacheReportLoadMulti$
+func TestReportableCacheReportLoadMulti(t *testing.T) {
+	cache, reporter := newTestReportableCache()
+	cache.Set("key", "value", NoTTL)
+
+	var loadedKeys []string
+	cache.reportLoad = func(reporter *Reporter, key string, value interface{}, ttl time.Duration, err error) {
+		loadedKeys = append(loadedKeys, key)
+	}
+
+	load := func(missedKeys []string) (values map[string]interface{}, err error) {
+		values = make(map[string]interface{}, len(missedKeys))
+		for _, key := range missedKeys {
+			values[key] = key
+		}
+
+		return values, nil
+	}
+
+	// Keys got from cache shouldn't be reported as loaded.
+	if _, err := cache.LoadMulti([]string{"key", "load"}, NoTTL, load); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loadedKeys) != 1 || loadedKeys[0] != "load" {
+		t.Fatalf("loadedKeys %+v is wrong", loadedKeys)
+	}
+
+	if reporter.CountLoad() != 1 {
+		t.Fatalf("CountLoad %d is wrong", reporter.CountLoad())
+	}
+
+	if _, err := cache.LoadMulti([]string{"key", "load"}, NoTTL, load); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loadedKeys) != 1 || reporter.CountLoad() != 1 {
+		t.Fatalf("loadedKeys %+v, CountLoad %d is wrong", loadedKeys, reporter.CountLoad())
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestReporterCacheName$
 func TestReporterCacheName(t *testing.T) {
 	_, reporter := newTestReportableCache()
@@ -257,6 +329,20 @@ func TestReporterCacheGC(t *testing.T) {
 	}
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestReporterCacheCost$
+func TestReporterCacheCost(t *testing.T) {
+	cache, reporter := NewCacheWithReport(WithGC(0), WithWeigher(func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}))
+
+	cache.Set("key1", "123", NoTTL)
+	cache.Set("key2", "12345", NoTTL)
+
+	if reporter.CacheCost() != 8 {
+		t.Fatalf("CacheCost %d is wrong", reporter.CacheCost())
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestReporterCacheSize$
 func TestReporterCacheSize(t *testing.T) {
 	cache, reporter := newTestReportableCache()
@@ -0,0 +1,608 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// s3fifoSmallPercent is the percent of small fifo in max entries.
+	s3fifoSmallPercent = 10
+
+	// s3fifoMaxFrequency is the max frequency of entries.
+	s3fifoMaxFrequency = 3
+)
+
+type s3fifoSegment int
+
+const (
+	s3fifoSmall s3fifoSegment = iota
+	s3fifoMain
+)
+
+type s3fifoItem struct {
+	entry     *entry
+	segment   s3fifoSegment
+	frequency atomic.Int32
+}
+
+// increase increases the frequency of item until it reaches the max frequency.
+func (si *s3fifoItem) increase() {
+	for {
+		frequency := si.frequency.Load()
+		if frequency >= s3fifoMaxFrequency {
+			return
+		}
+
+		if si.frequency.CompareAndSwap(frequency, frequency+1) {
+			return
+		}
+	}
+}
+
+type s3fifoCache struct {
+	*config
+
+	elementMap map[string]*list.Element
+	small      *list.List
+	main       *list.List
+	cost       int64
+	lock       sync.RWMutex
+
+	// ghostMap and ghostList store keys evicted from small fifo.
+	ghostMap  map[string]*list.Element
+	ghostList *list.List
+
+	smallEntries int
+	mainEntries  int
+
+	loader *loader
+}
+
+func newS3FIFOCache(conf *config) Cache {
+	conf.checkEntriesBounded(s3fifo)
+
+	smallEntries := conf.maxEntries * s3fifoSmallPercent / 100
+	if smallEntries < 1 {
+		smallEntries = 1
+	}
+
+	cache := &s3fifoCache{
+		config:       conf,
+		elementMap:   make(map[string]*list.Element, mapInitialCap),
+		small:        list.New(),
+		main:         list.New(),
+		ghostMap:     make(map[string]*list.Element, mapInitialCap),
+		ghostList:    list.New(),
+		smallEntries: smallEntries,
+		mainEntries:  conf.maxEntries - smallEntries,
+		loader:       newLoader(conf),
+	}
+
+	return cache
+}
+
+func (sc *s3fifoCache) unwrap(element *list.Element) *s3fifoItem {
+	item, ok := element.Value.(*s3fifoItem)
+	if !ok {
+		panic("cachego: failed to unwrap s3fifo element's value to item")
+	}
+
+	return item
+}
+
+func (sc *s3fifoCache) listOf(segment s3fifoSegment) *list.List {
+	if segment == s3fifoMain {
+		return sc.main
+	}
+
+	return sc.small
+}
+
+func (sc *s3fifoCache) addGhost(key string) {
+	if element, ok := sc.ghostMap[key]; ok {
+		sc.ghostList.MoveToFront(element)
+		return
+	}
+
+	sc.ghostMap[key] = sc.ghostList.PushFront(key)
+
+	if sc.ghostList.Len() > sc.mainEntries {
+		element := sc.ghostList.Back()
+		sc.ghostList.Remove(element)
+
+		delete(sc.ghostMap, element.Value.(string))
+	}
+}
+
+func (sc *s3fifoCache) removeGhost(key string) bool {
+	element, ok := sc.ghostMap[key]
+	if ok {
+		sc.ghostList.Remove(element)
+		delete(sc.ghostMap, key)
+	}
+
+	return ok
+}
+
+// evictMain evicts the first element without frequency from main fifo.
+// Elements with frequency will be reinserted to main fifo with frequency decreased.
+func (sc *s3fifoCache) evictMain() (evictedValue interface{}, evicted bool) {
+	for element := sc.main.Back(); element != nil; element = sc.main.Back() {
+		item := sc.unwrap(element)
+
+		if item.frequency.Load() > 0 {
+			item.frequency.Add(-1)
+			sc.main.MoveToFront(element)
+
+			continue
+		}
+
+		return sc.removeElement(element, RemovalCapacity), true
+	}
+
+	return nil, false
+}
+
+// evictSmall evicts the first element never accessed after inserting from small fifo and remembers its key in ghost fifo.
+// Elements accessed after inserting will be moved to main fifo.
+func (sc *s3fifoCache) evictSmall() (evictedValue interface{}, evicted bool) {
+	for element := sc.small.Back(); element != nil; element = sc.small.Back() {
+		item := sc.unwrap(element)
+
+		if item.frequency.Load() <= 0 {
+			sc.addGhost(item.entry.key)
+			return sc.removeElement(element, RemovalCapacity), true
+		}
+
+		if sc.main.Len() >= sc.mainEntries {
+			evictedValue, evicted = sc.evictMain()
+		}
+
+		sc.small.Remove(element)
+		item.segment = s3fifoMain
+		item.frequency.Store(0)
+		sc.elementMap[item.entry.key] = sc.main.PushFront(item)
+
+		if evicted {
+			return evictedValue, true
+		}
+	}
+
+	return nil, false
+}
+
+func (sc *s3fifoCache) evict() (evictedValue interface{}) {
+	if sc.small.Len() >= sc.smallEntries || sc.main.Len() == 0 {
+		if evictedValue, evicted := sc.evictSmall(); evicted {
+			return evictedValue
+		}
+	}
+
+	evictedValue, _ = sc.evictMain()
+	return evictedValue
+}
+
+func (sc *s3fifoCache) get(key string) (value interface{}, found bool) {
+	element, ok := sc.elementMap[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := sc.unwrap(element)
+	if item.entry.expired(0) {
+		return nil, false
+	}
+
+	// Only frequency will be changed so it can be done in read lock.
+	item.increase()
+	return item.entry.value, true
+}
+
+func (sc *s3fifoCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	element, ok := sc.elementMap[key]
+	if ok {
+		item := sc.unwrap(element)
+		sc.notifyEvicted(key, item.entry.value, replacedCause(item.entry))
+		item.entry.setup(key, value, ttl)
+		item.increase()
+
+		cost := sc.weigh(key, value)
+		sc.cost += cost - item.entry.cost
+		item.entry.cost = cost
+
+		return nil
+	}
+
+	if sc.maxEntries > 0 && sc.size() >= sc.maxEntries {
+		evictedValue = sc.evict()
+	}
+
+	item := &s3fifoItem{
+		entry:   newEntry(key, value, ttl, sc.now),
+		segment: s3fifoSmall,
+	}
+
+	item.entry.cost = sc.weigh(key, value)
+	sc.cost += item.entry.cost
+
+	// Keys in ghost fifo were evicted from small fifo recently, so they should go to main fifo directly.
+	if sc.removeGhost(key) {
+		item.segment = s3fifoMain
+	}
+
+	sc.elementMap[key] = sc.listOf(item.segment).PushFront(item)
+	return evictedValue
+}
+
+func (sc *s3fifoCache) removeElement(element *list.Element, cause RemovalCause) (removedValue interface{}) {
+	item := sc.unwrap(element)
+
+	delete(sc.elementMap, item.entry.key)
+	sc.listOf(item.segment).Remove(element)
+	sc.cost -= item.entry.cost
+
+	sc.notifyEvicted(item.entry.key, item.entry.value, cause)
+	return item.entry.value
+}
+
+func (sc *s3fifoCache) remove(key string) (removedValue interface{}) {
+	sc.removeGhost(key)
+
+	if element, ok := sc.elementMap[key]; ok {
+		return sc.removeElement(element, RemovalExplicit)
+	}
+
+	return nil
+}
+
+func (sc *s3fifoCache) size() (size int) {
+	return len(sc.elementMap)
+}
+
+func (sc *s3fifoCache) gc() (cleans int) {
+	now := sc.now()
+	scans := 0
+
+	for _, element := range sc.elementMap {
+		scans++
+
+		if item := sc.unwrap(element); item.entry.expired(now) {
+			sc.removeElement(element, RemovalExpired)
+			cleans++
+		}
+
+		if sc.maxScans > 0 && scans >= sc.maxScans {
+			break
+		}
+	}
+
+	return cleans
+}
+
+func (sc *s3fifoCache) reset() {
+	if sc.onEvicted != nil {
+		for _, element := range sc.elementMap {
+			entry := sc.unwrap(element).entry
+			sc.notifyEvicted(entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	sc.elementMap = make(map[string]*list.Element, mapInitialCap)
+	sc.small = list.New()
+	sc.main = list.New()
+	sc.cost = 0
+	sc.ghostMap = make(map[string]*list.Element, mapInitialCap)
+	sc.ghostList = list.New()
+
+	sc.loader.Reset()
+}
+
+func (sc *s3fifoCache) dump() []DumpEntry {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	now := sc.now()
+	entries := make([]DumpEntry, 0, sc.size())
+
+	// Keep the fifo order of entries, and entries in small fifo are less valuable than entries in main fifo.
+	for _, segment := range []*list.List{sc.small, sc.main} {
+		for element := segment.Back(); element != nil; element = element.Prev() {
+			if dumpEntry, ok := newDumpEntry(sc.unwrap(element).entry, now); ok {
+				entries = append(entries, dumpEntry)
+			}
+		}
+	}
+
+	return entries
+}
+
+func (sc *s3fifoCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(sc.now()), true
+	}
+
+	return nil, 0, false
+}
+
+func (sc *s3fifoCache) loaderOf() *loader {
+	return sc.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (sc *s3fifoCache) entryOf(key string) *entry {
+	element, ok := sc.elementMap[key]
+	if !ok {
+		return nil
+	}
+
+	if item := sc.unwrap(element); !item.entry.expired(0) {
+		return item.entry
+	}
+
+	return nil
+}
+
+func (sc *s3fifoCache) expire(key string, ttl time.Duration) (found bool) {
+	entry := sc.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	return true
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (sc *s3fifoCache) Get(key string) (value interface{}, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See Cache interface.
+func (sc *s3fifoCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (sc *s3fifoCache) Remove(key string) (removedValue interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (sc *s3fifoCache) TTL(key string) (ttl time.Duration, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.ttl(sc.now()), true
+	}
+
+	return 0, false
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (sc *s3fifoCache) Touch(key string, ttl time.Duration) (found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if _, found = sc.get(key); !found {
+		return false
+	}
+
+	return sc.expire(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (sc *s3fifoCache) Expire(key string, ttl time.Duration) (found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (sc *s3fifoCache) Persist(key string) (found bool) {
+	return sc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (sc *s3fifoCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getOrSet(sc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (sc *s3fifoCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return setIfAbsent(sc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (sc *s3fifoCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return compareAndSwap(sc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (sc *s3fifoCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return update(sc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (sc *s3fifoCache) GetAndRemove(key string) (value interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getAndRemove(sc, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *s3fifoCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return incr(sc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *s3fifoCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return sc.Incr(key, -delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (sc *s3fifoCache) Size() (size int) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (sc *s3fifoCache) Cost() (cost int64) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.cost
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (sc *s3fifoCache) GC() (cleans int) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.gc()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (sc *s3fifoCache) Reset() {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	sc.reset()
+}
+
+// Close resets cache to release its entries.
+// See Cache interface.
+func (sc *s3fifoCache) Close() error {
+	sc.Reset()
+	return nil
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (sc *s3fifoCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(sc.dump(), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (sc *s3fifoCache) Keys() (keys []string) {
+	return keysOf(sc.dump())
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (sc *s3fifoCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	value, err = sc.loader.Load(key, ttl, load)
+	if err != nil {
+		return value, err
+	}
+
+	sc.Set(key, value, ttl)
+	return value, nil
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (sc *s3fifoCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, sc, sc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (sc *s3fifoCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return getMulti(keys, sc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (sc *s3fifoCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	setMulti(entries, ttl, sc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (sc *s3fifoCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return removeMulti(keys, sc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (sc *s3fifoCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(sc, sc.loader, keys, ttl, load)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestS3FIFOCache() *s3fifoCache {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newS3FIFOCache(conf).(*s3fifoCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestS3FIFOCache$
+func TestS3FIFOCache(t *testing.T) {
+	cache := newTestS3FIFOCache()
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestS3FIFOCacheEvict$
+func TestS3FIFOCacheEvict(t *testing.T) {
+	cache := newTestS3FIFOCache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue := cache.Set(data, data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && evictedValue == nil {
+			t.Fatalf("i %d >= cache.maxEntries %d && evictedValue == nil", i, cache.maxEntries)
+		}
+
+		if cache.ghostList.Len() > cache.mainEntries {
+			t.Fatalf("cache.ghostList.Len() %d > cache.mainEntries %d", cache.ghostList.Len(), cache.mainEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestS3FIFOCacheSegment$
+func TestS3FIFOCacheSegment(t *testing.T) {
+	cache := newTestS3FIFOCache()
+
+	for i := 0; i < cache.maxEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	if cache.small.Len() != cache.maxEntries {
+		t.Fatalf("cache.small.Len() %d != cache.maxEntries %d", cache.small.Len(), cache.maxEntries)
+	}
+
+	// Accessed entries will be moved to main fifo, and others will be evicted to ghost fifo.
+	cache.Get("0")
+	cache.Get("0")
+
+	evictedValue := cache.Set("new", "new", NoTTL)
+	if evictedValue.(string) != "1" {
+		t.Fatalf("evictedValue %+v != \"1\"", evictedValue)
+	}
+
+	if segment := cache.unwrap(cache.elementMap["0"]).segment; segment != s3fifoMain {
+		t.Fatalf("segment %d != s3fifoMain", segment)
+	}
+
+	if _, ok := cache.ghostMap["1"]; !ok {
+		t.Fatal("key 1 should be in ghost fifo")
+	}
+
+	// Keys in ghost fifo go to main fifo directly.
+	cache.Set("1", "1", NoTTL)
+
+	if segment := cache.unwrap(cache.elementMap["1"]).segment; segment != s3fifoMain {
+		t.Fatalf("segment %d != s3fifoMain", segment)
+	}
+
+	if _, ok := cache.ghostMap["1"]; ok {
+		t.Fatal("key 1 should be removed from ghost fifo")
+	}
+
+	item := cache.unwrap(cache.elementMap["1"])
+	for i := 0; i < 10; i++ {
+		item.increase()
+	}
+
+	if frequency := item.frequency.Load(); frequency != s3fifoMaxFrequency {
+		t.Fatalf("frequency %d != s3fifoMaxFrequency %d", frequency, s3fifoMaxFrequency)
+	}
+}
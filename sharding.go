@@ -15,7 +15,9 @@
 package cachego
 
 import (
+	"context"
 	"math/bits"
+	"sort"
 	"time"
 )
 
@@ -33,9 +35,17 @@ func newShardingCache(conf *config, newCache func(conf *config) Cache) Cache {
 		panic("cachego: shardings must be the pow of 2 (such as 64).")
 	}
 
+	// Each sharding cache gets a proportional share of the cost budget.
+	shardingConf := conf
+	if conf.maxCost > 0 {
+		shardingConf = new(config)
+		*shardingConf = *conf
+		shardingConf.maxCost = max(conf.maxCost/int64(conf.shardings), 1)
+	}
+
 	caches := make([]Cache, 0, conf.shardings)
 	for i := 0; i < conf.shardings; i++ {
-		caches = append(caches, newCache(conf))
+		caches = append(caches, newCache(shardingConf))
 	}
 
 	cache := &shardingCache{
@@ -46,11 +56,58 @@ func newShardingCache(conf *config, newCache func(conf *config) Cache) Cache {
 	return cache
 }
 
-func (sc *shardingCache) cacheOf(key string) Cache {
+func (sc *shardingCache) indexOf(key string) int {
 	hash := sc.hash(key)
 	mask := len(sc.caches) - 1
 
-	return sc.caches[hash&mask]
+	return hash & mask
+}
+
+func (sc *shardingCache) cacheOf(key string) Cache {
+	return sc.caches[sc.indexOf(key)]
+}
+
+func (sc *shardingCache) loaderOfKey(key string) *loader {
+	return loaderOfKey(sc.cacheOf(key), key)
+}
+
+// groupKeys groups keys by the index of their sharding caches.
+func (sc *shardingCache) groupKeys(keys []string) map[int][]string {
+	groups := make(map[int][]string, len(sc.caches))
+
+	for _, key := range keys {
+		index := sc.indexOf(key)
+		groups[index] = append(groups[index], key)
+	}
+
+	return groups
+}
+
+func (sc *shardingCache) dump() []DumpEntry {
+	var entries []DumpEntry
+
+	for _, cache := range sc.caches {
+		if dc, ok := cache.(dumpableCache); ok {
+			entries = append(entries, dc.dump()...)
+		}
+	}
+
+	return entries
+}
+
+func (sc *shardingCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	return Peek(sc.cacheOf(key), key)
+}
+
+func (sc *shardingCache) restore(entry *DumpEntry) {
+	cache := sc.cacheOf(entry.Key)
+
+	if rc, ok := cache.(restorableCache); ok {
+		rc.restore(entry)
+		return
+	}
+
+	cache.Set(entry.Key, entry.Value, entry.TTL)
 }
 
 // Get gets the value of key from cache and returns value if found.
@@ -70,6 +127,72 @@ func (sc *shardingCache) Remove(key string) (removedValue interface{}) {
 	return sc.cacheOf(key).Remove(key)
 }
 
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (sc *shardingCache) TTL(key string) (ttl time.Duration, found bool) {
+	return sc.cacheOf(key).TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (sc *shardingCache) Touch(key string, ttl time.Duration) (found bool) {
+	return sc.cacheOf(key).Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (sc *shardingCache) Expire(key string, ttl time.Duration) (found bool) {
+	return sc.cacheOf(key).Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (sc *shardingCache) Persist(key string) (found bool) {
+	return sc.cacheOf(key).Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (sc *shardingCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	return sc.cacheOf(key).GetOrSet(key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (sc *shardingCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	return sc.cacheOf(key).SetIfAbsent(key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (sc *shardingCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	return sc.cacheOf(key).CompareAndSwap(key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (sc *shardingCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	return sc.cacheOf(key).Update(key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (sc *shardingCache) GetAndRemove(key string) (value interface{}, found bool) {
+	return sc.cacheOf(key).GetAndRemove(key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *shardingCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	return sc.cacheOf(key).Incr(key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *shardingCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return sc.cacheOf(key).Decr(key, delta, ttl)
+}
+
 // Size returns the count of keys in cache.
 // See Cache interface.
 func (sc *shardingCache) Size() (size int) {
@@ -80,6 +203,16 @@ func (sc *shardingCache) Size() (size int) {
 	return size
 }
 
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (sc *shardingCache) Cost() (cost int64) {
+	for _, cache := range sc.caches {
+		cost += cache.Cost()
+	}
+
+	return cost
+}
+
 // GC cleans the expired keys in cache and returns the exact count cleaned.
 // See Cache interface.
 func (sc *shardingCache) GC() (cleans int) {
@@ -98,8 +231,209 @@ func (sc *shardingCache) Reset() {
 	}
 }
 
+// Close closes all sharding caches and returns the first error.
+// See Cache interface.
+func (sc *shardingCache) Close() error {
+	var err error
+	for _, cache := range sc.caches {
+		if closeErr := cache.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// Shardings are iterated one by one, so the order is only kept in each sharding.
+// See Cache interface.
+func (sc *shardingCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	for _, cache := range sc.caches {
+		next := true
+
+		cache.Range(func(key string, value interface{}, ttl time.Duration) bool {
+			next = fn(key, value, ttl)
+			return next
+		})
+
+		if !next {
+			return
+		}
+	}
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (sc *shardingCache) Keys() (keys []string) {
+	for _, cache := range sc.caches {
+		keys = append(keys, cache.Keys()...)
+	}
+
+	return keys
+}
+
 // Load loads a value by load function and sets it to cache.
 // Returns an error if load failed.
 func (sc *shardingCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
 	return sc.cacheOf(key).Load(key, ttl, load)
 }
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (sc *shardingCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return sc.cacheOf(key).LoadContext(ctx, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// Keys are grouped by sharding caches, so each sharding cache only locks once.
+// See Cache interface.
+func (sc *shardingCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	values = make(map[string]interface{}, len(keys))
+
+	for index, groupKeys := range sc.groupKeys(keys) {
+		groupValues, groupMissedKeys := sc.caches[index].GetMulti(groupKeys)
+
+		for key, value := range groupValues {
+			values[key] = value
+		}
+
+		missedKeys = append(missedKeys, groupMissedKeys...)
+	}
+
+	return values, missedKeys
+}
+
+// SetMulti sets entries to cache with ttl.
+// Entries are grouped by sharding caches, so each sharding cache only locks once.
+// See Cache interface.
+func (sc *shardingCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	groups := make(map[int]map[string]interface{}, len(sc.caches))
+
+	for key, value := range entries {
+		index := sc.indexOf(key)
+
+		if groups[index] == nil {
+			groups[index] = make(map[string]interface{})
+		}
+
+		groups[index][key] = value
+	}
+
+	for index, groupEntries := range groups {
+		sc.caches[index].SetMulti(groupEntries, ttl)
+	}
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// Keys are grouped by sharding caches, so each sharding cache only locks once.
+// See Cache interface.
+func (sc *shardingCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	removedValues = make(map[string]interface{}, len(keys))
+
+	for index, groupKeys := range sc.groupKeys(keys) {
+		for key, value := range sc.caches[index].RemoveMulti(groupKeys) {
+			removedValues[key] = value
+		}
+	}
+
+	return removedValues
+}
+
+// loadGroups loads the keys of groups in the singleflight groups of their sharding caches in the order of indexes.
+// Load function is called once in the innermost group with all keys which aren't being loaded by others.
+// Each group waits for its keys being loaded by others only after the inner groups finish,
+// so calls in the same order of indexes won't wait for each other in a cycle.
+func (sc *shardingCache) loadGroups(indexes []int, groups map[int][]string, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	values = make(map[string]interface{})
+
+	var loadKeys []string
+	var loadedValues map[string]interface{}
+	var loadErr error
+
+	var loadGroup func(i int)
+	loadGroup = func(i int) {
+		if i >= len(indexes) {
+			if len(loadKeys) > 0 {
+				loadedValues, loadErr = load(loadKeys)
+			}
+
+			return
+		}
+
+		lc, ok := sc.caches[indexes[i]].(loadableCache)
+		if !ok {
+			loadKeys = append(loadKeys, groups[indexes[i]]...)
+			loadGroup(i + 1)
+
+			for _, key := range groups[indexes[i]] {
+				if value, ok := loadedValues[key]; ok {
+					values[key] = value
+				}
+			}
+
+			if err == nil {
+				err = loadErr
+			}
+
+			return
+		}
+
+		// The inner groups must be loaded even if all keys of this group are being loaded by others.
+		called := false
+		groupValues, groupErr := lc.loaderOf().LoadMulti(groups[indexes[i]], func(missedKeys []string) (map[string]interface{}, error) {
+			called = true
+			loadKeys = append(loadKeys, missedKeys...)
+			loadGroup(i + 1)
+
+			return loadedValues, loadErr
+		})
+
+		if !called {
+			loadGroup(i + 1)
+		}
+
+		for key, value := range groupValues {
+			values[key] = value
+		}
+
+		if err == nil {
+			err = groupErr
+		}
+	}
+
+	loadGroup(0)
+	return values, err
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// Each missed key is loaded in the singleflight group of its sharding cache, so it won't be loaded by Load at the same time.
+// Load function is still called once with all missed keys which aren't being loaded by others.
+// See Cache interface.
+func (sc *shardingCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	values, missedKeys := sc.GetMulti(keys)
+	if len(missedKeys) == 0 {
+		return values, nil
+	}
+
+	if load == nil {
+		return values, errNilLoad
+	}
+
+	groups := sc.groupKeys(missedKeys)
+
+	indexes := make([]int, 0, len(groups))
+	for index := range groups {
+		indexes = append(indexes, index)
+	}
+
+	sort.Ints(indexes)
+
+	loadedValues, err := sc.loadGroups(indexes, groups, load)
+	sc.SetMulti(loadedValues, ttl)
+
+	for key, value := range loadedValues {
+		values[key] = value
+	}
+
+	return values, err
+}
@@ -16,7 +16,10 @@ package cachego
 
 import (
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 const (
@@ -55,3 +58,104 @@ func TestShardingCacheIndex(t *testing.T) {
 		}
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestShardingCacheMaxCost$
+func TestShardingCacheMaxCost(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.shardings = testShardings
+	conf.maxCost = 100
+
+	cache := newShardingCache(conf, newStandardCache).(*shardingCache)
+
+	for _, c := range cache.caches {
+		if maxCost := c.(*standardCache).maxCost; maxCost != 100/testShardings {
+			t.Fatalf("maxCost %d != %d", maxCost, 100/testShardings)
+		}
+	}
+
+	if conf.maxCost != 100 {
+		t.Fatalf("conf.maxCost %d != 100", conf.maxCost)
+	}
+
+	for i := 0; i < 1000; i++ {
+		cache.Set(strconv.Itoa(i), i, NoTTL)
+	}
+
+	if cost := cache.Cost(); cost > 100 {
+		t.Fatalf("cost %d > 100", cost)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestShardingCacheLoadMulti$
+func TestShardingCacheLoadMulti(t *testing.T) {
+	cache := newTestShardingCache()
+
+	var loads atomic.Int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		cache.Load("key", NoTTL, func() (value interface{}, err error) {
+			loads.Add(1)
+			close(started)
+			<-release
+
+			return "value", nil
+		})
+	}()
+
+	<-started
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+	}()
+
+	keys := []string{"key"}
+	for i := 0; i < 10; i++ {
+		keys = append(keys, strconv.Itoa(i))
+	}
+
+	// The key being loaded by Load shouldn't be loaded again by LoadMulti.
+	values, err := cache.LoadMulti(keys, NoTTL, func(missedKeys []string) (values map[string]interface{}, err error) {
+		values = make(map[string]interface{}, len(missedKeys))
+		for _, key := range missedKeys {
+			if key == "key" {
+				loads.Add(1)
+			}
+
+			values[key] = key
+		}
+
+		return values, nil
+	})
+
+	wg.Wait()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if loads.Load() != 1 {
+		t.Fatalf("loads %d != 1", loads.Load())
+	}
+
+	if len(values) != len(keys) || values["key"] != "value" {
+		t.Fatalf("values %+v is wrong", values)
+	}
+
+	for _, key := range keys[1:] {
+		if value, found := cache.Get(key); !found || value != key {
+			t.Fatalf("value %+v, found %+v is wrong", value, found)
+		}
+	}
+
+	if _, err = cache.LoadMulti([]string{"nil"}, NoTTL, nil); err != errNilLoad {
+		t.Fatalf("err %+v != errNilLoad", err)
+	}
+}
@@ -0,0 +1,494 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type sieveItem struct {
+	entry   *entry
+	visited atomic.Bool
+}
+
+type sieveCache struct {
+	*config
+
+	elementMap  map[string]*list.Element
+	elementList *list.List
+	cost        int64
+	lock        sync.RWMutex
+
+	// hand points to the next element to check when evicting.
+	hand *list.Element
+
+	loader *loader
+}
+
+func newSIEVECache(conf *config) Cache {
+	conf.checkEntriesBounded(sieve)
+
+	cache := &sieveCache{
+		config:      conf,
+		elementMap:  make(map[string]*list.Element, mapInitialCap),
+		elementList: list.New(),
+		loader:      newLoader(conf),
+	}
+
+	return cache
+}
+
+func (sc *sieveCache) unwrap(element *list.Element) *sieveItem {
+	item, ok := element.Value.(*sieveItem)
+	if !ok {
+		panic("cachego: failed to unwrap sieve element's value to item")
+	}
+
+	return item
+}
+
+// evict moves the hand from tail to head and evicts the first element which isn't visited.
+// Visited elements passed by the hand will be marked unvisited.
+func (sc *sieveCache) evict() (evictedValue interface{}) {
+	element := sc.hand
+	if element == nil {
+		element = sc.elementList.Back()
+	}
+
+	for element != nil {
+		item := sc.unwrap(element)
+		if !item.visited.Load() {
+			break
+		}
+
+		item.visited.Store(false)
+
+		if element = element.Prev(); element == nil {
+			element = sc.elementList.Back()
+		}
+	}
+
+	if element == nil {
+		return nil
+	}
+
+	sc.hand = element.Prev()
+	return sc.removeElement(element, RemovalCapacity)
+}
+
+func (sc *sieveCache) get(key string) (value interface{}, found bool) {
+	element, ok := sc.elementMap[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := sc.unwrap(element)
+	if item.entry.expired(0) {
+		return nil, false
+	}
+
+	// Only visited flag will be changed so it can be done in read lock.
+	if !item.visited.Load() {
+		item.visited.Store(true)
+	}
+
+	return item.entry.value, true
+}
+
+func (sc *sieveCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	element, ok := sc.elementMap[key]
+	if ok {
+		item := sc.unwrap(element)
+		sc.notifyEvicted(key, item.entry.value, replacedCause(item.entry))
+		item.entry.setup(key, value, ttl)
+		item.visited.Store(true)
+
+		cost := sc.weigh(key, value)
+		sc.cost += cost - item.entry.cost
+		item.entry.cost = cost
+
+		return nil
+	}
+
+	if sc.maxEntries > 0 && sc.elementList.Len() >= sc.maxEntries {
+		evictedValue = sc.evict()
+	}
+
+	item := &sieveItem{
+		entry: newEntry(key, value, ttl, sc.now),
+	}
+
+	item.entry.cost = sc.weigh(key, value)
+	sc.cost += item.entry.cost
+	sc.elementMap[key] = sc.elementList.PushFront(item)
+	return evictedValue
+}
+
+func (sc *sieveCache) removeElement(element *list.Element, cause RemovalCause) (removedValue interface{}) {
+	if element == sc.hand {
+		sc.hand = element.Prev()
+	}
+
+	item := sc.unwrap(element)
+
+	delete(sc.elementMap, item.entry.key)
+	sc.elementList.Remove(element)
+	sc.cost -= item.entry.cost
+
+	sc.notifyEvicted(item.entry.key, item.entry.value, cause)
+	return item.entry.value
+}
+
+func (sc *sieveCache) remove(key string) (removedValue interface{}) {
+	if element, ok := sc.elementMap[key]; ok {
+		return sc.removeElement(element, RemovalExplicit)
+	}
+
+	return nil
+}
+
+func (sc *sieveCache) size() (size int) {
+	return len(sc.elementMap)
+}
+
+func (sc *sieveCache) gc() (cleans int) {
+	now := sc.now()
+	scans := 0
+
+	for _, element := range sc.elementMap {
+		scans++
+
+		if item := sc.unwrap(element); item.entry.expired(now) {
+			sc.removeElement(element, RemovalExpired)
+			cleans++
+		}
+
+		if sc.maxScans > 0 && scans >= sc.maxScans {
+			break
+		}
+	}
+
+	return cleans
+}
+
+func (sc *sieveCache) reset() {
+	if sc.onEvicted != nil {
+		for _, element := range sc.elementMap {
+			entry := sc.unwrap(element).entry
+			sc.notifyEvicted(entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	sc.elementMap = make(map[string]*list.Element, mapInitialCap)
+	sc.elementList = list.New()
+	sc.cost = 0
+	sc.hand = nil
+
+	sc.loader.Reset()
+}
+
+func (sc *sieveCache) dump() []DumpEntry {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	now := sc.now()
+	entries := make([]DumpEntry, 0, sc.size())
+
+	// Keep the fifo order of entries.
+	for element := sc.elementList.Back(); element != nil; element = element.Prev() {
+		if dumpEntry, ok := newDumpEntry(sc.unwrap(element).entry, now); ok {
+			entries = append(entries, dumpEntry)
+		}
+	}
+
+	return entries
+}
+
+func (sc *sieveCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(sc.now()), true
+	}
+
+	return nil, 0, false
+}
+
+func (sc *sieveCache) loaderOf() *loader {
+	return sc.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (sc *sieveCache) entryOf(key string) *entry {
+	element, ok := sc.elementMap[key]
+	if !ok {
+		return nil
+	}
+
+	if item := sc.unwrap(element); !item.entry.expired(0) {
+		return item.entry
+	}
+
+	return nil
+}
+
+func (sc *sieveCache) expire(key string, ttl time.Duration) (found bool) {
+	entry := sc.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	return true
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (sc *sieveCache) Get(key string) (value interface{}, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See Cache interface.
+func (sc *sieveCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (sc *sieveCache) Remove(key string) (removedValue interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (sc *sieveCache) TTL(key string) (ttl time.Duration, found bool) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.ttl(sc.now()), true
+	}
+
+	return 0, false
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (sc *sieveCache) Touch(key string, ttl time.Duration) (found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	if _, found = sc.get(key); !found {
+		return false
+	}
+
+	return sc.expire(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (sc *sieveCache) Expire(key string, ttl time.Duration) (found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (sc *sieveCache) Persist(key string) (found bool) {
+	return sc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (sc *sieveCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getOrSet(sc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (sc *sieveCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return setIfAbsent(sc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (sc *sieveCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return compareAndSwap(sc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (sc *sieveCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return update(sc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (sc *sieveCache) GetAndRemove(key string) (value interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getAndRemove(sc, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *sieveCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return incr(sc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *sieveCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return sc.Incr(key, -delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (sc *sieveCache) Size() (size int) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (sc *sieveCache) Cost() (cost int64) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.cost
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (sc *sieveCache) GC() (cleans int) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return sc.gc()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (sc *sieveCache) Reset() {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	sc.reset()
+}
+
+// Close resets cache to release its entries.
+// See Cache interface.
+func (sc *sieveCache) Close() error {
+	sc.Reset()
+	return nil
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (sc *sieveCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(sc.dump(), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (sc *sieveCache) Keys() (keys []string) {
+	return keysOf(sc.dump())
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (sc *sieveCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	value, err = sc.loader.Load(key, ttl, load)
+	if err != nil {
+		return value, err
+	}
+
+	sc.Set(key, value, ttl)
+	return value, nil
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (sc *sieveCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, sc, sc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (sc *sieveCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return getMulti(keys, sc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (sc *sieveCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	setMulti(entries, ttl, sc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (sc *sieveCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return removeMulti(keys, sc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (sc *sieveCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(sc, sc.loader, keys, ttl, load)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestSIEVECache() *sieveCache {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newSIEVECache(conf).(*sieveCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSIEVECache$
+func TestSIEVECache(t *testing.T) {
+	cache := newTestSIEVECache()
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSIEVECacheEvict$
+func TestSIEVECacheEvict(t *testing.T) {
+	cache := newTestSIEVECache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue := cache.Set(data, data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && evictedValue == nil {
+			t.Fatalf("i %d >= cache.maxEntries %d && evictedValue == nil", i, cache.maxEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSIEVECacheVisited$
+func TestSIEVECacheVisited(t *testing.T) {
+	cache := newTestSIEVECache()
+
+	for i := 0; i < cache.maxEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	// Visited entries survive from the hand, and unvisited entries are evicted in fifo order.
+	for i := 0; i < cache.maxEntries; i += 2 {
+		cache.Get(strconv.Itoa(i))
+	}
+
+	for i := 0; i < cache.maxEntries/2; i++ {
+		data := "new" + strconv.Itoa(i)
+
+		evictedValue := cache.Set(data, data, NoTTL)
+		if expect := strconv.Itoa(i*2 + 1); evictedValue.(string) != expect {
+			t.Fatalf("evictedValue %+v != expect %s", evictedValue, expect)
+		}
+	}
+
+	for i := 0; i < cache.maxEntries; i += 2 {
+		data := strconv.Itoa(i)
+
+		element := cache.elementMap[data]
+		if element == nil {
+			t.Fatalf("key %s should be found", data)
+		}
+
+		if cache.unwrap(element).visited.Load() {
+			t.Fatalf("key %s should be unvisited after the hand passed", data)
+		}
+	}
+
+	// The hand keeps its position, so the next eviction starts from where it stops instead of the tail.
+	evictedValue := cache.Set("next", "next", NoTTL)
+	if evictedValue.(string) != "new0" {
+		t.Fatalf("evictedValue %+v != \"new0\"", evictedValue)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestSIEVECacheConcurrentGet$
+func TestSIEVECacheConcurrentGet(t *testing.T) {
+	cache := newTestSIEVECache()
+
+	for i := 0; i < cache.maxEntries; i++ {
+		data := strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				cache.Get(strconv.Itoa(j % maxTestEntries))
+			}
+		}()
+	}
+
+	for i := 0; i < 1000; i++ {
+		data := "new" + strconv.Itoa(i)
+		cache.Set(data, data, NoTTL)
+	}
+
+	wg.Wait()
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
@@ -15,88 +15,179 @@
 package cachego
 
 import (
+	"context"
 	"sync"
 	"time"
 )
 
-type standardCache struct {
+// typedStandardCache is the core of standard caches whose keys are in type K and values are in type V.
+type typedStandardCache[K comparable, V any] struct {
 	*config
 
-	entries map[string]*entry
-	lock    sync.RWMutex
+	entries     map[K]*typedEntry[K, V]
+	expirations *typedExpirationIndex[K]
+	cost        int64
+	lock        sync.RWMutex
+
+	loader *typedLoader[K, V]
+}
+
+func newTypedStandardCache[K comparable, V any](conf *config) *typedStandardCache[K, V] {
+	cache := &typedStandardCache[K, V]{
+		config:      conf,
+		entries:     make(map[K]*typedEntry[K, V], mapInitialCap),
+		expirations: newTypedExpirationIndex[K](conf),
+		loader:      newTypedLoader[K, V](conf),
+	}
 
-	loader *loader
+	return cache
+}
+
+// standardCache is the standard cache whose keys are strings and values are in any types.
+type standardCache struct {
+	*typedStandardCache[string, interface{}]
 }
 
 func newStandardCache(conf *config) Cache {
 	cache := &standardCache{
-		config:  conf,
-		entries: make(map[string]*entry, mapInitialCap),
-		loader:  newLoader(conf.singleflight),
+		typedStandardCache: newTypedStandardCache[string, interface{}](conf),
 	}
 
 	return cache
 }
 
-func (sc *standardCache) get(key string) (value interface{}, found bool) {
-	entry, ok := sc.entries[key]
-	if ok && !entry.expired(0) {
-		return entry.value, true
+func (tsc *typedStandardCache[K, V]) get(key K) (value V, found bool) {
+	entry, ok := tsc.entries[key]
+	if !ok || entry.expired(0) {
+		return value, false
 	}
 
-	return nil, false
+	// Sliding only changes the expiration atomically, so get is still safe in read lock.
+	entry.slide()
+	return entry.value, true
 }
 
-func (sc *standardCache) evict() (evictedValue interface{}) {
-	for key := range sc.entries {
-		return sc.remove(key)
+func (tsc *typedStandardCache[K, V]) evict() (evictedValue V, evicted bool) {
+	for _, entry := range tsc.entries {
+		return tsc.removeEntry(entry, RemovalCapacity), true
 	}
 
-	return nil
+	return evictedValue, false
 }
 
-func (sc *standardCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
-	entry, ok := sc.entries[key]
+// evictByCost evicts entries until cost can be added without exceeding maxCost or size reaches minSize.
+func (tsc *typedStandardCache[K, V]) evictByCost(cost int64, minSize int) (evictedValue V, evicted bool) {
+	for tsc.maxCost > 0 && tsc.size() > minSize && tsc.cost+cost > tsc.maxCost {
+		evictedValue, evicted = tsc.evict()
+	}
+
+	return evictedValue, evicted
+}
+
+func (tsc *typedStandardCache[K, V]) set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	cost := weighTyped(tsc.config, key, value)
+
+	entry, ok := tsc.entries[key]
 	if ok {
+		notifyTypedEvicted(tsc.config, key, entry.value, replacedCause(entry))
 		entry.setup(key, value, ttl)
-		return nil
+		tsc.setupSliding(entry, ttl)
+		tsc.updateExpiration(entry)
+
+		tsc.cost += cost - entry.cost
+		entry.cost = cost
+
+		if tsc.maxCost <= 0 || tsc.cost <= tsc.maxCost {
+			return evictedValue, false
+		}
+
+		// Detach entry so it won't be evicted by itself.
+		delete(tsc.entries, key)
+		evictedValue, evicted = tsc.evictByCost(0, 0)
+		tsc.entries[key] = entry
+
+		return evictedValue, evicted
 	}
 
-	if sc.maxEntries > 0 && sc.size() >= sc.maxEntries {
-		evictedValue = sc.evict()
+	if tsc.maxEntries > 0 && tsc.size() >= tsc.maxEntries {
+		evictedValue, evicted = tsc.evict()
 	}
 
-	sc.entries[key] = newEntry(key, value, ttl, sc.now)
-	return evictedValue
+	if value, ok := tsc.evictByCost(cost, 0); ok {
+		evictedValue, evicted = value, true
+	}
+
+	entry = newTypedEntry(key, value, ttl, tsc.now)
+	tsc.setupSliding(entry, ttl)
+	entry.cost = cost
+
+	tsc.entries[key] = entry
+	tsc.cost += cost
+	tsc.updateExpiration(entry)
+
+	return evictedValue, evicted
 }
 
-func (sc *standardCache) remove(key string) (removedValue interface{}) {
-	entry, ok := sc.entries[key]
-	if !ok {
-		return nil
+func (tsc *typedStandardCache[K, V]) updateExpiration(entry *typedEntry[K, V]) {
+	if tsc.expirations != nil {
+		tsc.expirations.update(entry.key, entry.expiration)
+	}
+}
+
+func (tsc *typedStandardCache[K, V]) removeEntry(entry *typedEntry[K, V], cause RemovalCause) (removedValue V) {
+	delete(tsc.entries, entry.key)
+	tsc.cost -= entry.cost
+
+	if tsc.expirations != nil {
+		tsc.expirations.remove(entry.key)
 	}
 
-	delete(sc.entries, key)
+	notifyTypedEvicted(tsc.config, entry.key, entry.value, cause)
+
 	return entry.value
 }
 
-func (sc *standardCache) size() (size int) {
-	return len(sc.entries)
+func (tsc *typedStandardCache[K, V]) remove(key K) (removedValue V, removed bool) {
+	entry, ok := tsc.entries[key]
+	if !ok {
+		return removedValue, false
+	}
+
+	return tsc.removeEntry(entry, RemovalExplicit), true
+}
+
+func (tsc *typedStandardCache[K, V]) size() (size int) {
+	return len(tsc.entries)
 }
 
-func (sc *standardCache) gc() (cleans int) {
-	now := sc.now()
+func (tsc *typedStandardCache[K, V]) gc() (cleans int) {
+	now := tsc.now()
+
+	if tsc.expirations != nil {
+		return tsc.expirations.clean(now, func(key K) bool {
+			entry := tsc.entries[key]
+			if !entry.expired(now) {
+				// The expiration of entry may slide after indexing.
+				tsc.updateExpiration(entry)
+				return false
+			}
+
+			tsc.removeEntry(entry, RemovalExpired)
+			return true
+		})
+	}
+
 	scans := 0
 
-	for _, entry := range sc.entries {
+	for _, entry := range tsc.entries {
 		scans++
 
 		if entry.expired(now) {
-			delete(sc.entries, entry.key)
+			tsc.removeEntry(entry, RemovalExpired)
 			cleans++
 		}
 
-		if sc.maxScans > 0 && scans >= sc.maxScans {
+		if tsc.maxScans > 0 && scans >= tsc.maxScans {
 			break
 		}
 	}
@@ -104,18 +195,83 @@ func (sc *standardCache) gc() (cleans int) {
 	return cleans
 }
 
-func (sc *standardCache) reset() {
-	sc.entries = make(map[string]*entry, mapInitialCap)
-	sc.loader.Reset()
+func (tsc *typedStandardCache[K, V]) reset() {
+	if tsc.onEvicted != nil {
+		for _, entry := range tsc.entries {
+			notifyTypedEvicted(tsc.config, entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	tsc.entries = make(map[K]*typedEntry[K, V], mapInitialCap)
+	tsc.cost = 0
+
+	if tsc.expirations != nil {
+		tsc.expirations.reset()
+	}
+
+	tsc.loader.Reset()
 }
 
-// Get gets the value of key from cache and returns value if found.
-// See Cache interface.
-func (sc *standardCache) Get(key string) (value interface{}, found bool) {
+func (tsc *typedStandardCache[K, V]) loaderOf() *typedLoader[K, V] {
+	return tsc.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (tsc *typedStandardCache[K, V]) entryOf(key K) *typedEntry[K, V] {
+	entry, ok := tsc.entries[key]
+	if !ok || entry.expired(0) {
+		return nil
+	}
+
+	return entry
+}
+
+func (tsc *typedStandardCache[K, V]) expire(key K, ttl time.Duration) (found bool) {
+	entry := tsc.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	tsc.updateExpiration(entry)
+	return true
+}
+
+func (sc *standardCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	evictedValue, _ = sc.typedStandardCache.set(key, value, ttl)
+	return evictedValue
+}
+
+func (sc *standardCache) remove(key string) (removedValue interface{}) {
+	removedValue, _ = sc.typedStandardCache.remove(key)
+	return removedValue
+}
+
+func (sc *standardCache) dump() []DumpEntry {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	now := sc.now()
+	entries := make([]DumpEntry, 0, sc.size())
+
+	for _, entry := range sc.entries {
+		if dumpEntry, ok := newDumpEntry(entry, now); ok {
+			entries = append(entries, dumpEntry)
+		}
+	}
+
+	return entries
+}
+
+func (sc *standardCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
 	sc.lock.RLock()
 	defer sc.lock.RUnlock()
 
-	return sc.get(key)
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(sc.now()), true
+	}
+
+	return nil, 0, false
 }
 
 // Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
@@ -136,41 +292,163 @@ func (sc *standardCache) Remove(key string) (removedValue interface{}) {
 	return sc.remove(key)
 }
 
-// Size returns the count of keys in cache.
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
 // See Cache interface.
-func (sc *standardCache) Size() (size int) {
+func (sc *standardCache) TTL(key string) (ttl time.Duration, found bool) {
 	sc.lock.RLock()
 	defer sc.lock.RUnlock()
 
-	return sc.size()
+	if entry := sc.entryOf(key); entry != nil {
+		return entry.ttl(sc.now()), true
+	}
+
+	return 0, false
 }
 
-// GC cleans the expired keys in cache and returns the exact count cleaned.
+// Touch resets the ttl of key and accesses key like Get.
 // See Cache interface.
-func (sc *standardCache) GC() (cleans int) {
+func (sc *standardCache) Touch(key string, ttl time.Duration) (found bool) {
 	sc.lock.Lock()
 	defer sc.lock.Unlock()
 
-	return sc.gc()
+	if _, found = sc.get(key); !found {
+		return false
+	}
+
+	return sc.expire(key, ttl)
 }
 
-// Reset resets cache to initial status which is like a new cache.
+// Expire resets the ttl of key without accessing key.
 // See Cache interface.
-func (sc *standardCache) Reset() {
+func (sc *standardCache) Expire(key string, ttl time.Duration) (found bool) {
 	sc.lock.Lock()
 	defer sc.lock.Unlock()
 
-	sc.reset()
+	return sc.expire(key, ttl)
 }
 
-// Load loads a value by load function and sets it to cache.
-// Returns an error if load failed.
-func (sc *standardCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
-	value, err = sc.loader.Load(key, ttl, load)
-	if err != nil {
-		return value, err
-	}
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (sc *standardCache) Persist(key string) (found bool) {
+	return sc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (sc *standardCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getOrSet(sc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (sc *standardCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return setIfAbsent(sc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (sc *standardCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return compareAndSwap(sc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (sc *standardCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return update(sc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (sc *standardCache) GetAndRemove(key string) (value interface{}, found bool) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return getAndRemove(sc, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *standardCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	return incr(sc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (sc *standardCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return sc.Incr(key, -delta, ttl)
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (sc *standardCache) Cost() (cost int64) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return sc.cost
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (sc *standardCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(sc.dump(), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (sc *standardCache) Keys() (keys []string) {
+	return keysOf(sc.dump())
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (sc *standardCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, sc, sc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (sc *standardCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	sc.lock.RLock()
+	defer sc.lock.RUnlock()
+
+	return getMulti(keys, sc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (sc *standardCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	setMulti(entries, ttl, sc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (sc *standardCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
 
-	sc.Set(key, value, ttl)
-	return value, nil
+	return removeMulti(keys, sc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (sc *standardCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(sc, sc.loader, keys, ttl, load)
 }
@@ -16,6 +16,8 @@ package cachego
 
 import (
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -33,6 +35,69 @@ func TestStandardCache(t *testing.T) {
 	testCacheImplement(t, cache)
 }
 
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStandardCacheWithExpirationIndex$
+func TestStandardCacheWithExpirationIndex(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+	conf.expirationIndex = true
+
+	cache := newStandardCache(conf).(*standardCache)
+	testCacheImplement(t, cache)
+
+	// All entries with ttl are expired, so gc should clean up the index.
+	cache.GC()
+
+	if cache.expirations.itemHeap.Size() != 0 {
+		t.Fatalf("cache.expirations.itemHeap.Size() %d != 0", cache.expirations.itemHeap.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStandardCacheSlidingExpiration$
+func TestStandardCacheSlidingExpiration(t *testing.T) {
+	for _, expirationIndex := range []bool{false, true} {
+		var clock atomic.Int64
+
+		conf := newDefaultConfig()
+		conf.maxEntries = maxTestEntries
+		conf.expirationIndex = expirationIndex
+		conf.slidingExpiration = true
+		conf.maxLifetime = 25
+		conf.now = clock.Load
+
+		cache := newStandardCache(conf)
+		testCacheSlidingExpiration(t, cache, &clock)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStandardCacheSlidingConcurrently$
+func TestStandardCacheSlidingConcurrently(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.slidingExpiration = true
+
+	cache := newStandardCache(conf)
+	cache.Set("key", "value", time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				cache.Get("key")
+				cache.TTL("key")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if ttl, found := cache.TTL("key"); !found || ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+}
+
 // go test -v -cover -count=1 -test.cpu=1 -run=^TestStandardCacheEvict$
 func TestStandardCacheEvict(t *testing.T) {
 	cache := newTestStandardCache()
@@ -50,3 +115,55 @@ func TestStandardCacheEvict(t *testing.T) {
 		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
 	}
 }
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStandardCacheMaxCost$
+func TestStandardCacheMaxCost(t *testing.T) {
+	conf := newDefaultConfig()
+	conf.maxCost = 10
+	conf.weigher = func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := newStandardCache(conf).(*standardCache)
+	cache.Set("1", "1234", NoTTL)
+	cache.Set("2", "1234", NoTTL)
+
+	if cache.Cost() != 8 {
+		t.Fatalf("cache.Cost() %d != 8", cache.Cost())
+	}
+
+	// The oldest entry should be evicted to make room for the new one.
+	evictedValue := cache.Set("3", "1234", NoTTL)
+	if evictedValue == nil || cache.Cost() != 8 || cache.Size() != 2 {
+		t.Fatalf("evictedValue %+v, cache.Cost() %d, cache.Size() %d is wrong", evictedValue, cache.Cost(), cache.Size())
+	}
+
+	// Replacing a value changes the cost, and entries are evicted if it exceeds.
+	cache.Set("3", "1", NoTTL)
+	if cache.Cost() != 5 {
+		t.Fatalf("cache.Cost() %d != 5", cache.Cost())
+	}
+
+	cache.Set("3", "12345678", NoTTL)
+	if cache.Cost() != 8 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Remove("3")
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+
+	// An entry costing more than maxCost still can be set after evicting all others.
+	cache.Set("1", "1", NoTTL)
+	cache.Set("big", "12345678901", NoTTL)
+
+	if cache.Cost() != 11 || cache.Size() != 1 {
+		t.Fatalf("cache.Cost() %d, cache.Size() %d is wrong", cache.Cost(), cache.Size())
+	}
+
+	cache.Reset()
+	if cache.Cost() != 0 {
+		t.Fatalf("cache.Cost() %d != 0", cache.Cost())
+	}
+}
@@ -0,0 +1,513 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// storeKeyLocks is the count of key locks serializing writes of keys in write-through mode.
+const storeKeyLocks = 256
+
+const (
+	// ReadThrough loads missed keys of Get and GetMulti from store.
+	ReadThrough StoreMode = 1 << iota
+
+	// WriteThrough writes keys to store after writing them to cache.
+	WriteThrough
+
+	// WriteBehind writes keys to store in background, and writes of the same key will be coalesced.
+	WriteBehind
+)
+
+// Store is the data source behind cache, such as a database.
+type Store interface {
+	// Load loads the value of key from store.
+	Load(ctx context.Context, key string) (value interface{}, err error)
+
+	// Store stores key and value to store.
+	Store(ctx context.Context, key string, value interface{}) error
+
+	// Delete deletes key from store.
+	Delete(ctx context.Context, key string) error
+}
+
+// BatchStore is a store which can write keys in batch.
+// Write-behind uses it to flush writes if a store implements it.
+type BatchStore interface {
+	Store
+
+	// StoreMulti stores entries to store.
+	StoreMulti(ctx context.Context, entries map[string]interface{}) error
+
+	// DeleteMulti deletes keys from store.
+	DeleteMulti(ctx context.Context, keys []string) error
+}
+
+// StoreMode is the mode of using store, and modes can be combined like ReadThrough | WriteThrough.
+// Notice that WriteThrough and WriteBehind can't be combined.
+type StoreMode int
+
+// storeCache reads missed keys from store and writes keys to store in the way of its mode.
+// Keys failing to be written through are removed from cache, so they will be read from store again.
+type storeCache struct {
+	*config
+
+	cache       Cache
+	writeBehind *writeBehind
+
+	// keyLocks serialize writes of the same key in write-through mode, so cache and store are written in the same order.
+	keyLocks []sync.Mutex
+}
+
+func newStoreCache(conf *config, cache Cache) Cache {
+	if conf.storeMode&WriteThrough != 0 && conf.storeMode&WriteBehind != 0 {
+		panic("cachego: can't combine write-through with write-behind")
+	}
+
+	sc := &storeCache{
+		config: conf,
+		cache:  cache,
+	}
+
+	if conf.storeMode&WriteBehind != 0 {
+		sc.writeBehind = newWriteBehind(conf)
+	}
+
+	if conf.storeMode&WriteThrough != 0 {
+		sc.keyLocks = make([]sync.Mutex, storeKeyLocks)
+	}
+
+	return sc
+}
+
+// lockKey locks key in write-through mode and returns a function to unlock it.
+func (sc *storeCache) lockKey(key string) (unlock func()) {
+	if sc.keyLocks == nil {
+		return func() {}
+	}
+
+	lock := &sc.keyLocks[uint(sc.hash(key))%storeKeyLocks]
+	lock.Lock()
+
+	return lock.Unlock
+}
+
+// load loads key from store, and pending writes of write-behind will be returned first so keys won't be stale.
+func (sc *storeCache) load(ctx context.Context, key string) (value interface{}, err error) {
+	if sc.writeBehind != nil {
+		if value, deleted, found := sc.writeBehind.lookup(key); found {
+			if deleted {
+				return nil, errPendingDelete
+			}
+
+			return value, nil
+		}
+	}
+
+	return sc.store.Load(ctx, key)
+}
+
+// readThrough loads a missed key from store to cache.
+func (sc *storeCache) readThrough(key string) (value interface{}, found bool) {
+	load := func(ctx context.Context) (value interface{}, err error) {
+		return sc.load(ctx, key)
+	}
+
+	value, err := sc.cache.LoadContext(context.Background(), key, sc.storeTTL, load)
+	if err != nil {
+		if !errors.Is(err, errPendingDelete) {
+			sc.notifyStoreError(key, err)
+		}
+
+		return nil, false
+	}
+
+	return value, true
+}
+
+// rollback removes key from cache after value failed to be written through.
+// Key is removed only if its value is still value, so a newer value written by others won't be removed.
+func (sc *storeCache) rollback(key string, value interface{}) {
+	ttl, _ := sc.cache.TTL(key)
+
+	sc.cache.Update(key, func(old interface{}, found bool) (new interface{}, newTTL time.Duration, keep bool) {
+		if found && reflect.DeepEqual(old, value) {
+			return nil, 0, false
+		}
+
+		return old, ttl, found
+	})
+}
+
+// write writes key and value to store in the way of its mode.
+// Key should be locked by lockKey in write-through mode.
+func (sc *storeCache) write(key string, value interface{}) {
+	if sc.writeBehind != nil {
+		sc.writeBehind.write(key, value, false)
+		return
+	}
+
+	if sc.storeMode&WriteThrough == 0 {
+		return
+	}
+
+	if err := sc.store.Store(context.Background(), key, value); err != nil {
+		sc.rollback(key, value)
+		sc.notifyStoreError(key, err)
+	}
+}
+
+// delete deletes key from store in the way of its mode.
+// Key should be locked by lockKey in write-through mode.
+func (sc *storeCache) delete(key string) {
+	if sc.writeBehind != nil {
+		sc.writeBehind.write(key, nil, true)
+		return
+	}
+
+	if sc.storeMode&WriteThrough == 0 {
+		return
+	}
+
+	if err := sc.store.Delete(context.Background(), key); err != nil {
+		sc.notifyStoreError(key, err)
+	}
+}
+
+func (sc *storeCache) dump() []DumpEntry {
+	if dc, ok := sc.cache.(dumpableCache); ok {
+		return dc.dump()
+	}
+
+	return nil
+}
+
+func (sc *storeCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	return Peek(sc.cache, key)
+}
+
+func (sc *storeCache) restore(entry *DumpEntry) {
+	if restorable, ok := sc.cache.(restorableCache); ok {
+		restorable.restore(entry)
+		return
+	}
+
+	sc.cache.Set(entry.Key, entry.Value, entry.TTL)
+}
+
+// Get gets the value of key from cache and returns value if found.
+// A missed key will be loaded from store in read-through mode, and load errors are treated as not found.
+// See Cache interface.
+func (sc *storeCache) Get(key string) (value interface{}, found bool) {
+	value, found = sc.cache.Get(key)
+	if found || sc.storeMode&ReadThrough == 0 {
+		return value, found
+	}
+
+	return sc.readThrough(key)
+}
+
+// Set sets key and value to cache with ttl and writes them to store.
+// See Cache interface.
+func (sc *storeCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	evictedValue = sc.cache.Set(key, value, ttl)
+	sc.write(key, value)
+
+	return evictedValue
+}
+
+// Remove removes key from cache and deletes it from store.
+// Key is deleted from store even if it doesn't exist in cache.
+// See Cache interface.
+func (sc *storeCache) Remove(key string) (removedValue interface{}) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	removedValue = sc.cache.Remove(key)
+	sc.delete(key)
+
+	return removedValue
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (sc *storeCache) TTL(key string) (ttl time.Duration, found bool) {
+	return sc.cache.TTL(key)
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (sc *storeCache) Touch(key string, ttl time.Duration) (found bool) {
+	return sc.cache.Touch(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (sc *storeCache) Expire(key string, ttl time.Duration) (found bool) {
+	return sc.cache.Expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (sc *storeCache) Persist(key string) (found bool) {
+	return sc.cache.Persist(key)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// Notice that it only reads cache, and value will be written to store if it's set.
+// See Cache interface.
+func (sc *storeCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	actual, found = sc.cache.GetOrSet(key, value, ttl)
+	if !found {
+		sc.write(key, value)
+	}
+
+	return actual, found
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// Notice that it only reads cache, and value will be written to store if it's stored.
+// See Cache interface.
+func (sc *storeCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	actual, stored = sc.cache.SetIfAbsent(key, value, ttl)
+	if stored {
+		sc.write(key, value)
+	}
+
+	return actual, stored
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// New will be written to store if it's swapped.
+// See Cache interface.
+func (sc *storeCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	swapped = sc.cache.CompareAndSwap(key, old, new, ttl, equal)
+	if swapped {
+		sc.write(key, new)
+	}
+
+	return swapped
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// The new value will be written to store if it's kept, or key will be deleted from store.
+// See Cache interface.
+func (sc *storeCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	value, kept = sc.cache.Update(key, fn)
+	if kept {
+		sc.write(key, value)
+	} else {
+		sc.delete(key)
+	}
+
+	return value, kept
+}
+
+// GetAndRemove removes key and returns its value if found.
+// Key is deleted from store even if it doesn't exist in cache.
+// See Cache interface.
+func (sc *storeCache) GetAndRemove(key string) (value interface{}, found bool) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	value, found = sc.cache.GetAndRemove(key)
+	sc.delete(key)
+
+	return value, found
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// The new value will be written to store.
+// See Cache interface.
+func (sc *storeCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	value = sc.cache.Incr(key, delta, ttl)
+	sc.write(key, value)
+
+	return value
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// The new value will be written to store.
+// See Cache interface.
+func (sc *storeCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	unlock := sc.lockKey(key)
+	defer unlock()
+
+	value = sc.cache.Decr(key, delta, ttl)
+	sc.write(key, value)
+
+	return value
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (sc *storeCache) Size() (size int) {
+	return sc.cache.Size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (sc *storeCache) Cost() (cost int64) {
+	return sc.cache.Cost()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (sc *storeCache) GC() (cleans int) {
+	return sc.cache.GC()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// Notice that pending writes of write-behind won't be discarded.
+// See Cache interface.
+func (sc *storeCache) Reset() {
+	sc.cache.Reset()
+}
+
+// Close flushes all pending writes of write-behind to store and closes cache.
+// It returns the first error of writes which are discarded after all retries fail.
+// See Cache interface.
+func (sc *storeCache) Close() error {
+	var err error
+	if sc.writeBehind != nil {
+		err = sc.writeBehind.close()
+	}
+
+	if closeErr := sc.cache.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (sc *storeCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	sc.cache.Range(fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (sc *storeCache) Keys() (keys []string) {
+	return sc.cache.Keys()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// Notice that the loaded value won't be written to store.
+// See Cache interface.
+func (sc *storeCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	return sc.cache.Load(key, ttl, load)
+}
+
+// LoadContext loads a key with ttl and ctx to cache and returns an error if failed.
+// Notice that the loaded value won't be written to store.
+// See Cache interface.
+func (sc *storeCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return sc.cache.LoadContext(ctx, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// Missed keys will be loaded from store one by one in read-through mode.
+// See Cache interface.
+func (sc *storeCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	values, missedKeys = sc.cache.GetMulti(keys)
+	if len(missedKeys) <= 0 || sc.storeMode&ReadThrough == 0 {
+		return values, missedKeys
+	}
+
+	keys, missedKeys = missedKeys, nil
+	for _, key := range keys {
+		if value, found := sc.readThrough(key); found {
+			values[key] = value
+		} else {
+			missedKeys = append(missedKeys, key)
+		}
+	}
+
+	return values, missedKeys
+}
+
+// SetMulti sets entries to cache with ttl and writes them to store.
+// See Cache interface.
+func (sc *storeCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	if sc.keyLocks == nil {
+		sc.cache.SetMulti(entries, ttl)
+
+		for key, value := range entries {
+			sc.write(key, value)
+		}
+
+		return
+	}
+
+	// Keys are locked one by one in write-through mode, so they are set one by one.
+	for key, value := range entries {
+		sc.Set(key, value, ttl)
+	}
+}
+
+// RemoveMulti removes keys from cache and deletes them from store.
+// See Cache interface.
+func (sc *storeCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	if sc.keyLocks == nil {
+		removedValues = sc.cache.RemoveMulti(keys)
+
+		for _, key := range keys {
+			sc.delete(key)
+		}
+
+		return removedValues
+	}
+
+	// Keys are locked one by one in write-through mode, so they are removed one by one.
+	removedValues = make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if value, found := sc.GetAndRemove(key); found {
+			removedValues[key] = value
+		}
+	}
+
+	return removedValues
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// Notice that the loaded values won't be written to store.
+// See Cache interface.
+func (sc *storeCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return sc.cache.LoadMulti(keys, ttl, load)
+}
@@ -0,0 +1,319 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errTestStore = errors.New("store failed")
+
+// testStore is an in-memory store which can fail the next writes.
+type testStore struct {
+	data     map[string]interface{}
+	loads    int
+	writes   int
+	failures int
+	lock     sync.Mutex
+}
+
+func newTestStore() *testStore {
+	return &testStore{data: make(map[string]interface{})}
+}
+
+func (ts *testStore) fail() bool {
+	if ts.failures > 0 {
+		ts.failures--
+		return true
+	}
+
+	return false
+}
+
+func (ts *testStore) Load(ctx context.Context, key string) (value interface{}, err error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	ts.loads++
+
+	value, ok := ts.data[key]
+	if !ok {
+		return nil, errTestNotFound
+	}
+
+	return value, nil
+}
+
+func (ts *testStore) Store(ctx context.Context, key string, value interface{}) error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	ts.writes++
+	if ts.fail() {
+		return errTestStore
+	}
+
+	ts.data[key] = value
+	return nil
+}
+
+func (ts *testStore) Delete(ctx context.Context, key string) error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	ts.writes++
+	if ts.fail() {
+		return errTestStore
+	}
+
+	delete(ts.data, key)
+	return nil
+}
+
+func (ts *testStore) get(key string) (value interface{}, found bool) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	value, found = ts.data[key]
+	return value, found
+}
+
+func (ts *testStore) counts() (loads int, writes int) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	return ts.loads, ts.writes
+}
+
+func newTestStoreCache(store Store, storeMode StoreMode) *storeCache {
+	conf := newDefaultConfig()
+	conf.store = store
+	conf.storeMode = storeMode
+	conf.writeBehindBackoff = time.Millisecond
+
+	return newStoreCache(conf, newStandardCache(conf)).(*storeCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCache$
+func TestStoreCache(t *testing.T) {
+	cache := newTestStoreCache(newTestStore(), WriteThrough)
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCacheReadThrough$
+func TestStoreCacheReadThrough(t *testing.T) {
+	store := newTestStore()
+	store.data["key"] = "value"
+	store.data["multi"] = "multi"
+
+	cache := newTestStoreCache(store, ReadThrough)
+	for i := 0; i < 3; i++ {
+		if value, found := cache.Get("key"); !found || value != "value" {
+			t.Fatalf("value %+v, found %+v is wrong", value, found)
+		}
+	}
+
+	if value, found := cache.Get("missing"); found {
+		t.Fatalf("missing key found with value %+v", value)
+	}
+
+	values, missedKeys := cache.GetMulti([]string{"key", "multi", "missing"})
+	if len(values) != 2 || values["multi"] != "multi" {
+		t.Fatalf("values %+v is wrong", values)
+	}
+
+	if len(missedKeys) != 1 || missedKeys[0] != "missing" {
+		t.Fatalf("missedKeys %+v is wrong", missedKeys)
+	}
+
+	// Key and multi are loaded once, and missing is loaded twice.
+	if loads, _ := store.counts(); loads != 4 {
+		t.Fatalf("loads %d != 4", loads)
+	}
+
+	// Writes shouldn't go to store without write modes.
+	cache.Set("new", "value", NoTTL)
+	cache.Remove("key")
+
+	if _, writes := store.counts(); writes != 0 {
+		t.Fatalf("writes %d != 0", writes)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCacheWriteThrough$
+func TestStoreCacheWriteThrough(t *testing.T) {
+	var errKeys []string
+
+	store := newTestStore()
+	cache := newTestStoreCache(store, ReadThrough|WriteThrough)
+	cache.onStoreError = func(key string, err error) {
+		if !errors.Is(err, errTestStore) {
+			t.Fatalf("err %+v isn't errTestStore", err)
+		}
+
+		errKeys = append(errKeys, key)
+	}
+
+	cache.Set("key", "value", NoTTL)
+	cache.SetMulti(map[string]interface{}{"k1": 1, "k2": 2}, NoTTL)
+	cache.Incr("counter", 1, NoTTL)
+
+	for key, want := range map[string]interface{}{"key": "value", "k1": 1, "k2": 2, "counter": int64(1)} {
+		if value, found := store.get(key); !found || value != want {
+			t.Fatalf("key %s: value %+v, found %+v is wrong", key, value, found)
+		}
+	}
+
+	cache.Remove("key")
+	cache.RemoveMulti([]string{"k1"})
+
+	if _, found := store.get("key"); found {
+		t.Fatal("removed key found in store")
+	}
+
+	if _, found := store.get("k1"); found {
+		t.Fatal("removed k1 found in store")
+	}
+
+	// A key failing to be written should be removed from cache, so it can be read from store again.
+	store.failures = 1
+	cache.Set("k2", "new", NoTTL)
+
+	if len(errKeys) != 1 || errKeys[0] != "k2" {
+		t.Fatalf("errKeys %+v is wrong", errKeys)
+	}
+
+	if value, found := cache.Get("k2"); !found || value != 2 {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCacheReadThroughError$
+func TestStoreCacheReadThroughError(t *testing.T) {
+	var errs []error
+
+	cache := newTestStoreCache(newTestStore(), ReadThrough)
+	cache.onStoreError = func(key string, err error) {
+		errs = append(errs, err)
+	}
+
+	if value, found := cache.Get("missing"); found {
+		t.Fatalf("missing key found with value %+v", value)
+	}
+
+	if len(errs) != 1 || !errors.Is(errs[0], errTestNotFound) {
+		t.Fatalf("errs %+v is wrong", errs)
+	}
+}
+
+// testHookStore is a store calling hook before storing keys.
+type testHookStore struct {
+	*testStore
+
+	hook func(key string)
+}
+
+func (hs *testHookStore) Store(ctx context.Context, key string, value interface{}) error {
+	hs.hook(key)
+	return hs.testStore.Store(ctx, key, value)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCacheWriteThroughRollback$
+func TestStoreCacheWriteThroughRollback(t *testing.T) {
+	store := &testHookStore{testStore: newTestStore()}
+	cache := newTestStoreCache(store, WriteThrough)
+
+	// A newer value set during writing shouldn't be removed by the rollback of failed write.
+	store.hook = func(key string) {
+		cache.cache.Set(key, "newer", NoTTL)
+	}
+
+	store.failures = 1
+	cache.Set("key", "value", NoTTL)
+
+	if value, found := cache.Get("key"); !found || value != "newer" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	store.hook = func(key string) {}
+	store.failures = 1
+	cache.Set("key", "value", NoTTL)
+
+	if value, found := cache.Get("key"); found {
+		t.Fatalf("value %+v is found", value)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestStoreCacheWriteThroughConcurrently$
+func TestStoreCacheWriteThroughConcurrently(t *testing.T) {
+	store := newTestStore()
+	cache := newTestStoreCache(store, WriteThrough)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				cache.Set("key", strconv.Itoa(i*100+j), NoTTL)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	// Writes of the same key are serialized, so store has the same value as cache.
+	value, _ := cache.Get("key")
+	if stored, _ := store.get("key"); stored != value {
+		t.Fatalf("stored %+v != value %+v", stored, value)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewCacheWithStore$
+func TestNewCacheWithStore(t *testing.T) {
+	store := newTestStore()
+	cache := NewCache(WithStore(store, ReadThrough|WriteBehind), WithWriteBehind(time.Hour, 100))
+
+	for i := 0; i < 10; i++ {
+		cache.Set("key", i, NoTTL)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes of the same key should be coalesced and flushed when closing.
+	if _, writes := store.counts(); writes != 1 {
+		t.Fatalf("writes %d != 1", writes)
+	}
+
+	if value, found := store.get("key"); !found || value != 9 {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("new cache with write-through and write-behind should panic")
+		}
+	}()
+
+	NewCache(WithStore(store, WriteThrough|WriteBehind))
+}
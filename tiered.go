@@ -0,0 +1,337 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errRemoteMissed is returned by the load function of tiered cache when key doesn't exist in remote tier.
+var errRemoteMissed = errors.New("cachego: key doesn't exist in remote tier")
+
+// RemoteTier is a remote cache storing bytes, such as redis or memcached.
+type RemoteTier interface {
+	// Get gets the data of key with its remaining ttl and returns false if key doesn't exist.
+	// The ttl should be NoTTL if key is never expired.
+	Get(ctx context.Context, key string) (data []byte, ttl time.Duration, found bool, err error)
+
+	// Set sets key and data with ttl, and NoTTL means key is never expired.
+	Set(ctx context.Context, key string, data []byte, ttl time.Duration) error
+
+	// Delete deletes key.
+	Delete(ctx context.Context, key string) error
+}
+
+// tieredConfig is the config of tiered caches.
+type tieredConfig struct {
+	*config
+
+	localTTL      time.Duration
+	remoteCodec   Codec
+	onRemoteError func(key string, err error)
+}
+
+func newDefaultTieredConfig() *tieredConfig {
+	return &tieredConfig{
+		config:      newDefaultConfig(),
+		remoteCodec: GobCodec{},
+	}
+}
+
+// notifyRemoteError calls onRemoteError with key and err if onRemoteError exists.
+func (c *tieredConfig) notifyRemoteError(key string, err error) {
+	if c.onRemoteError != nil {
+		c.onRemoteError(key, err)
+	}
+}
+
+// TieredCache is a two-tier cache composing a local cache as L1 and a remote tier as L2.
+// Values are encoded by codec before setting to remote tier, and keys found in remote tier are backfilled to local cache.
+// Remote lookups of the same key are called once at a time with singleflight, see WithDisableSingleflight.
+type TieredCache struct {
+	*tieredConfig
+
+	local  Cache
+	remote RemoteTier
+
+	// getter is the loader of remote lookups in Get, and loader is the loader of Load.
+	// They are separated, so Load won't share a flight with Get which may miss key.
+	getter *loader
+	loader *loader
+}
+
+// NewTieredCache creates a tiered cache of local and remote with options.
+// Use WithLocalTTL to set the max ttl of keys in local cache, which keeps them from being too stale,
+// and use WithRemoteCodec to set the codec of values in remote tier, which is GobCodec by default.
+// Use WithOnRemoteError to know the errors of remote tier which are ignored in Load.
+// Options like WithLoadTimeout and WithCancelAbandonedLoad also work in tiered cache by WithTieredOptions.
+func NewTieredCache(local Cache, remote RemoteTier, opts ...TieredOption) *TieredCache {
+	conf := newDefaultTieredConfig()
+	applyTieredOptions(conf, opts)
+
+	tc := &TieredCache{
+		tieredConfig: conf,
+		local:        local,
+		remote:       remote,
+		getter:       newLoader(conf.config),
+		loader:       newLoader(conf.config),
+	}
+
+	return tc
+}
+
+// localTTLOf returns the ttl of key in local cache, which won't exceed localTTL if localTTL > 0.
+func (tc *TieredCache) localTTLOf(ttl time.Duration) time.Duration {
+	if tc.localTTL <= 0 {
+		return ttl
+	}
+
+	if ttl <= NoTTL || ttl > tc.localTTL {
+		return tc.localTTL
+	}
+
+	return ttl
+}
+
+func (tc *TieredCache) encode(key string, value interface{}) ([]byte, error) {
+	buffer := bytes.NewBuffer(make([]byte, 0, 64))
+
+	entry := DumpEntry{Key: key, Value: value}
+	if err := tc.remoteCodec.NewEncoder(buffer).Encode(&entry); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (tc *TieredCache) decode(data []byte) (value interface{}, err error) {
+	var entry DumpEntry
+	if err = tc.remoteCodec.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		return nil, err
+	}
+
+	return entry.Value, nil
+}
+
+// getRemote gets key from remote tier and backfills it to local cache.
+// The ttl of key in local cache won't exceed its remaining ttl in remote tier, so it expires no later than remote tier.
+func (tc *TieredCache) getRemote(ctx context.Context, key string) (value interface{}, err error) {
+	data, ttl, found, err := tc.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, errRemoteMissed
+	}
+
+	if value, err = tc.decode(data); err != nil {
+		return nil, err
+	}
+
+	tc.local.Set(key, value, tc.localTTLOf(ttl))
+	return value, nil
+}
+
+// setRemote sets key and value with ttl to remote tier and local cache.
+func (tc *TieredCache) setRemote(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := tc.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	if err = tc.remote.Set(ctx, key, data, ttl); err != nil {
+		return err
+	}
+
+	tc.local.Set(key, value, tc.localTTLOf(ttl))
+	return nil
+}
+
+// Get gets the value of key from local cache, or from remote tier if missed.
+// A key found in remote tier will be backfilled to local cache with its remaining ttl capped by local ttl.
+func (tc *TieredCache) Get(ctx context.Context, key string) (value interface{}, found bool, err error) {
+	if value, found = tc.local.Get(key); found {
+		return value, true, nil
+	}
+
+	value, err = tc.getter.LoadContext(ctx, key, func(ctx context.Context) (value interface{}, err error) {
+		return tc.getRemote(ctx, key)
+	})
+
+	if errors.Is(err, errRemoteMissed) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Set sets key and value with ttl to remote tier and then local cache.
+// The ttl of key in local cache won't exceed local ttl, and key won't be set to local cache if setting remote tier failed.
+func (tc *TieredCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return tc.setRemote(ctx, key, value, ttl)
+}
+
+// Delete deletes key from local cache and remote tier.
+// Notice that other local caches in front of the same remote tier may still have key until their local ttl.
+func (tc *TieredCache) Delete(ctx context.Context, key string) error {
+	tc.local.Remove(key)
+	return tc.remote.Delete(ctx, key)
+}
+
+// Load gets key from local cache and remote tier, or loads it with ttl to both tiers if missed.
+// The lookup of remote tier and load function are called once at a time for the same key with singleflight.
+// Errors of remote tier are treated as misses, so load will be called if remote tier is unavailable,
+// and the loaded value will be set to local cache even if setting remote tier fails.
+// These errors are reported to the function set by WithOnRemoteError.
+func (tc *TieredCache) Load(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	if value, found := tc.local.Get(key); found {
+		return value, nil
+	}
+
+	if load == nil {
+		return nil, errNilLoad
+	}
+
+	loadBothTiers := func(ctx context.Context) (value interface{}, err error) {
+		value, err = tc.getRemote(ctx, key)
+		if err == nil {
+			return value, nil
+		}
+
+		if !errors.Is(err, errRemoteMissed) {
+			tc.notifyRemoteError(key, err)
+		}
+
+		if value, err = load(ctx); err != nil {
+			return nil, err
+		}
+
+		if err = tc.setRemote(ctx, key, value, ttl); err != nil {
+			tc.notifyRemoteError(key, err)
+			tc.local.Set(key, value, tc.localTTLOf(ttl))
+		}
+
+		return value, nil
+	}
+
+	return tc.loader.LoadContext(ctx, key, loadBothTiers)
+}
+
+// Local returns the local cache of tiered cache.
+func (tc *TieredCache) Local() Cache {
+	return tc.local
+}
+
+// Remote returns the remote tier of tiered cache.
+func (tc *TieredCache) Remote() RemoteTier {
+	return tc.remote
+}
+
+// memoryData is the data of a key in memory tier.
+type memoryData struct {
+	data       []byte
+	expiration int64
+}
+
+// MemoryTier is a remote tier in memory, which is useful for tests without a network.
+// It copies data in Get and Set, so it behaves like a real remote tier.
+type MemoryTier struct {
+	now  func() int64
+	data map[string]memoryData
+	lock sync.RWMutex
+}
+
+// NewMemoryTier creates a memory tier.
+func NewMemoryTier() *MemoryTier {
+	mt := &MemoryTier{
+		now:  now,
+		data: make(map[string]memoryData, mapInitialCap),
+	}
+
+	return mt
+}
+
+// Get gets the data of key with its remaining ttl and returns false if key doesn't exist or is expired.
+func (mt *MemoryTier) Get(ctx context.Context, key string) (data []byte, ttl time.Duration, found bool, err error) {
+	if err = ctx.Err(); err != nil {
+		return nil, 0, false, err
+	}
+
+	mt.lock.RLock()
+	defer mt.lock.RUnlock()
+
+	md, ok := mt.data[key]
+	if !ok {
+		return nil, 0, false, nil
+	}
+
+	ttl = NoTTL
+	if md.expiration > 0 {
+		if ttl = time.Duration(md.expiration - mt.now()); ttl <= 0 {
+			return nil, 0, false, nil
+		}
+	}
+
+	return append([]byte(nil), md.data...), ttl, true, nil
+}
+
+// Set sets key and data with ttl, and NoTTL means key is never expired.
+// Notice that expired keys are kept until they are set or deleted, because there is no gc in memory tier.
+func (mt *MemoryTier) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	md := memoryData{data: append([]byte(nil), data...)}
+	if ttl > 0 {
+		md.expiration = mt.now() + ttl.Nanoseconds()
+	}
+
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	mt.data[key] = md
+	return nil
+}
+
+// Delete deletes key.
+func (mt *MemoryTier) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	delete(mt.data, key)
+	return nil
+}
+
+// Size returns the count of keys in memory tier, including expired ones.
+func (mt *MemoryTier) Size() int {
+	mt.lock.RLock()
+	defer mt.lock.RUnlock()
+
+	return len(mt.data)
+}
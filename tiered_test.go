@@ -0,0 +1,289 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testFailedTier is a remote tier which always fails.
+type testFailedTier struct{}
+
+func (testFailedTier) Get(ctx context.Context, key string) (data []byte, ttl time.Duration, found bool, err error) {
+	return nil, 0, false, errTestStore
+}
+
+func (testFailedTier) Set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	return errTestStore
+}
+
+func (testFailedTier) Delete(ctx context.Context, key string) error {
+	return errTestStore
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTieredCache$
+func TestTieredCache(t *testing.T) {
+	ctx := context.Background()
+	remote := NewMemoryTier()
+
+	for _, codec := range []Codec{GobCodec{}, JSONCodec{}} {
+		cache := NewTieredCache(newStandardCache(newDefaultConfig()), remote, WithLocalTTL(time.Minute), WithRemoteCodec(codec))
+		other := NewTieredCache(newStandardCache(newDefaultConfig()), remote, WithLocalTTL(time.Minute), WithRemoteCodec(codec))
+
+		if value, found, err := cache.Get(ctx, "key"); err != nil || found {
+			t.Fatalf("value %+v, found %+v, err %+v is wrong", value, found, err)
+		}
+
+		if err := cache.Set(ctx, "key", "value", time.Hour); err != nil {
+			t.Fatal(err)
+		}
+
+		// The ttl of key in local cache shouldn't exceed local ttl.
+		if ttl, found := cache.Local().TTL("key"); !found || ttl <= 0 || ttl > time.Minute {
+			t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+		}
+
+		// Key should be got from remote tier and backfilled to local cache.
+		if value, found, err := other.Get(ctx, "key"); err != nil || !found || value != "value" {
+			t.Fatalf("value %+v, found %+v, err %+v is wrong", value, found, err)
+		}
+
+		if ttl, found := other.Local().TTL("key"); !found || ttl <= 0 || ttl > time.Minute {
+			t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+		}
+
+		if err := cache.Delete(ctx, "key"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, found := cache.Local().Get("key"); found {
+			t.Fatal("deleted key found in local cache")
+		}
+
+		if _, _, found, _ := remote.Get(ctx, "key"); found {
+			t.Fatal("deleted key found in remote tier")
+		}
+	}
+
+	// Keys backfilled from remote tier shouldn't live longer than they do in remote tier.
+	cache := NewTieredCache(newStandardCache(newDefaultConfig()), remote)
+	other := NewTieredCache(newStandardCache(newDefaultConfig()), remote)
+
+	if err := cache.Set(ctx, "expiring", "value", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found, err := other.Get(ctx, "expiring"); err != nil || !found {
+		t.Fatalf("found %+v, err %+v is wrong", found, err)
+	}
+
+	if ttl, found := other.Local().TTL("expiring"); !found || ttl <= 0 || ttl > 10*time.Millisecond {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, found, err := other.Get(ctx, "expiring"); err != nil || found {
+		t.Fatalf("found %+v, err %+v is wrong", found, err)
+	}
+
+	cache = NewTieredCache(newStandardCache(newDefaultConfig()), testFailedTier{})
+	if err := cache.Set(ctx, "key", "value", NoTTL); !errors.Is(err, errTestStore) {
+		t.Fatalf("err %+v isn't errTestStore", err)
+	}
+
+	if _, found := cache.Local().Get("key"); found {
+		t.Fatal("key found in local cache after setting remote tier failed")
+	}
+
+	if _, _, err := cache.Get(ctx, "key"); !errors.Is(err, errTestStore) {
+		t.Fatalf("err %+v isn't errTestStore", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTieredCacheLoad$
+func TestTieredCacheLoad(t *testing.T) {
+	ctx := context.Background()
+	remote := NewMemoryTier()
+
+	var loads atomic.Int64
+	load := func(ctx context.Context) (interface{}, error) {
+		loads.Add(1)
+		time.Sleep(10 * time.Millisecond)
+
+		return "value", nil
+	}
+
+	cache := NewTieredCache(newStandardCache(newDefaultConfig()), remote)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if value, err := cache.Load(ctx, "key", time.Minute, load); err != nil || value != "value" {
+				t.Errorf("value %+v, err %+v is wrong", value, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if loads.Load() != 1 {
+		t.Fatalf("loads %d != 1", loads.Load())
+	}
+
+	// Key in remote tier shouldn't be loaded again by other local caches.
+	other := NewTieredCache(newStandardCache(newDefaultConfig()), remote)
+	if value, err := other.Load(ctx, "key", time.Minute, load); err != nil || value != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	if loads.Load() != 1 {
+		t.Fatalf("loads %d != 1", loads.Load())
+	}
+
+	// Load should work even if remote tier is unavailable, and errors of remote tier should be reported.
+	var remoteErrors []error
+	onRemoteError := func(key string, err error) {
+		remoteErrors = append(remoteErrors, err)
+	}
+
+	failed := NewTieredCache(newStandardCache(newDefaultConfig()), testFailedTier{}, WithOnRemoteError(onRemoteError))
+	if value, err := failed.Load(ctx, "key", time.Minute, load); err != nil || value != "value" {
+		t.Fatalf("value %+v, err %+v is wrong", value, err)
+	}
+
+	if len(remoteErrors) != 2 || !errors.Is(remoteErrors[0], errTestStore) || !errors.Is(remoteErrors[1], errTestStore) {
+		t.Fatalf("remoteErrors %+v is wrong", remoteErrors)
+	}
+
+	if value, found := failed.Local().Get("key"); !found || value != "value" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if _, err := failed.Load(ctx, "nil", time.Minute, nil); err != errNilLoad {
+		t.Fatalf("err %+v != errNilLoad", err)
+	}
+}
+
+// testBlockedTier is a remote tier whose Get is blocked until unblock is closed.
+type testBlockedTier struct {
+	*MemoryTier
+
+	getting chan struct{}
+	unblock chan struct{}
+}
+
+func (bt testBlockedTier) Get(ctx context.Context, key string) (data []byte, ttl time.Duration, found bool, err error) {
+	bt.getting <- struct{}{}
+	<-bt.unblock
+
+	return bt.MemoryTier.Get(ctx, key)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTieredCacheGetAndLoad$
+func TestTieredCacheGetAndLoad(t *testing.T) {
+	ctx := context.Background()
+	remote := testBlockedTier{MemoryTier: NewMemoryTier(), getting: make(chan struct{}, 2), unblock: make(chan struct{})}
+	cache := NewTieredCache(newStandardCache(newDefaultConfig()), remote)
+
+	got := make(chan error, 1)
+	go func() {
+		_, _, err := cache.Get(ctx, "key")
+		got <- err
+	}()
+
+	<-remote.getting
+
+	// Load shouldn't share the flight of Get which misses key.
+	loaded := make(chan error, 1)
+	go func() {
+		value, err := cache.Load(ctx, "key", time.Minute, func(ctx context.Context) (interface{}, error) {
+			return "value", nil
+		})
+
+		if err == nil && value != "value" {
+			err = errors.New("value is wrong")
+		}
+
+		loaded <- err
+	}()
+
+	<-remote.getting
+	close(remote.unblock)
+
+	if err := <-got; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := <-loaded; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestMemoryTier$
+func TestMemoryTier(t *testing.T) {
+	ctx := context.Background()
+	tier := NewMemoryTier()
+
+	data := []byte("value")
+	if err := tier.Set(ctx, "key", data, NoTTL); err != nil {
+		t.Fatal(err)
+	}
+
+	// Data should be copied, so modifying it won't change the tier.
+	data[0] = 'x'
+
+	got, ttl, found, err := tier.Get(ctx, "key")
+	if err != nil || !found || string(got) != "value" || ttl != NoTTL {
+		t.Fatalf("got %s, ttl %d, found %+v, err %+v is wrong", got, ttl, found, err)
+	}
+
+	tier.Set(ctx, "ttl", data, time.Millisecond)
+
+	if _, ttl, found, _ = tier.Get(ctx, "ttl"); !found || ttl <= 0 || ttl > time.Millisecond {
+		t.Fatalf("ttl %d, found %+v is wrong", ttl, found)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	if _, _, found, _ := tier.Get(ctx, "ttl"); found {
+		t.Fatal("expired key found")
+	}
+
+	if tier.Size() != 2 {
+		t.Fatalf("tier.Size() %d != 2", tier.Size())
+	}
+
+	tier.Delete(ctx, "key")
+	if _, _, found, _ := tier.Get(ctx, "key"); found {
+		t.Fatal("deleted key found")
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	if _, _, _, err := tier.Get(canceled, "key"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err %+v isn't context.Canceled", err)
+	}
+}
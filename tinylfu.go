@@ -0,0 +1,589 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/FishGoddess/cachego/pkg/sketch"
+)
+
+const (
+	// tinyLFUWindowPercent is the percent of window lru in max entries.
+	tinyLFUWindowPercent = 1
+
+	// tinyLFUProtectedPercent is the percent of protected segment in main lru.
+	tinyLFUProtectedPercent = 80
+
+	// tinyLFUResetMultiple is the multiple of max entries which decides when to age the sketch.
+	tinyLFUResetMultiple = 10
+)
+
+type tinyLFUSegment int
+
+const (
+	tinyLFUWindow tinyLFUSegment = iota
+	tinyLFUProbation
+	tinyLFUProtected
+)
+
+type tinyLFUItem struct {
+	entry   *entry
+	segment tinyLFUSegment
+}
+
+type tinyLFUCache struct {
+	*config
+
+	elementMap map[string]*list.Element
+	window     *list.List
+	probation  *list.List
+	protected  *list.List
+	sketch     *sketch.Sketch
+	cost       int64
+	lock       sync.RWMutex
+
+	windowEntries    int
+	mainEntries      int
+	protectedEntries int
+
+	loader *loader
+}
+
+func newTinyLFUCache(conf *config) Cache {
+	conf.checkEntriesBounded(tinylfu)
+
+	windowEntries := conf.maxEntries * tinyLFUWindowPercent / 100
+	if windowEntries < 1 {
+		windowEntries = 1
+	}
+
+	mainEntries := conf.maxEntries - windowEntries
+	protectedEntries := mainEntries * tinyLFUProtectedPercent / 100
+
+	cache := &tinyLFUCache{
+		config:           conf,
+		elementMap:       make(map[string]*list.Element, mapInitialCap),
+		window:           list.New(),
+		probation:        list.New(),
+		protected:        list.New(),
+		sketch:           sketch.New(conf.maxEntries, conf.maxEntries*tinyLFUResetMultiple),
+		windowEntries:    windowEntries,
+		mainEntries:      mainEntries,
+		protectedEntries: protectedEntries,
+		loader:           newLoader(conf),
+	}
+
+	return cache
+}
+
+func (tlc *tinyLFUCache) unwrap(element *list.Element) *tinyLFUItem {
+	item, ok := element.Value.(*tinyLFUItem)
+	if !ok {
+		panic("cachego: failed to unwrap tinylfu element's value to item")
+	}
+
+	return item
+}
+
+func (tlc *tinyLFUCache) listOf(segment tinyLFUSegment) *list.List {
+	switch segment {
+	case tinyLFUProbation:
+		return tlc.probation
+	case tinyLFUProtected:
+		return tlc.protected
+	default:
+		return tlc.window
+	}
+}
+
+func (tlc *tinyLFUCache) frequency(key string) uint8 {
+	return tlc.sketch.Estimate(uint64(tlc.hash(key)))
+}
+
+func (tlc *tinyLFUCache) increment(key string) {
+	tlc.sketch.Increment(uint64(tlc.hash(key)))
+}
+
+// moveTo moves element to the front of segment and returns the new element.
+func (tlc *tinyLFUCache) moveTo(element *list.Element, segment tinyLFUSegment) *list.Element {
+	item := tlc.unwrap(element)
+	tlc.listOf(item.segment).Remove(element)
+
+	item.segment = segment
+	element = tlc.listOf(segment).PushFront(item)
+	tlc.elementMap[item.entry.key] = element
+
+	return element
+}
+
+// access adjusts the position of element after it's accessed.
+func (tlc *tinyLFUCache) access(element *list.Element) {
+	item := tlc.unwrap(element)
+
+	switch item.segment {
+	case tinyLFUWindow:
+		tlc.window.MoveToFront(element)
+	case tinyLFUProtected:
+		tlc.protected.MoveToFront(element)
+	case tinyLFUProbation:
+		tlc.moveTo(element, tinyLFUProtected)
+
+		// Demote the last protected entry to probation if protected segment is full.
+		if tlc.protected.Len() > tlc.protectedEntries {
+			tlc.moveTo(tlc.protected.Back(), tinyLFUProbation)
+		}
+	}
+}
+
+// evict moves the candidate from window to main lru and evicts the one with lower frequency if main lru is full.
+func (tlc *tinyLFUCache) evict() (evictedValue interface{}) {
+	candidate := tlc.window.Back()
+	if candidate == nil {
+		return nil
+	}
+
+	if tlc.probation.Len()+tlc.protected.Len() < tlc.mainEntries {
+		tlc.moveTo(candidate, tinyLFUProbation)
+		return nil
+	}
+
+	victim := tlc.probation.Back()
+	if victim == nil {
+		victim = tlc.protected.Back()
+	}
+
+	if victim == nil {
+		return tlc.removeElement(candidate, RemovalCapacity)
+	}
+
+	candidateKey := tlc.unwrap(candidate).entry.key
+	victimKey := tlc.unwrap(victim).entry.key
+
+	if tlc.frequency(candidateKey) > tlc.frequency(victimKey) {
+		evictedValue = tlc.removeElement(victim, RemovalCapacity)
+		tlc.moveTo(candidate, tinyLFUProbation)
+
+		return evictedValue
+	}
+
+	return tlc.removeElement(candidate, RemovalCapacity)
+}
+
+func (tlc *tinyLFUCache) get(key string) (value interface{}, found bool) {
+	tlc.increment(key)
+
+	element, ok := tlc.elementMap[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := tlc.unwrap(element)
+	if item.entry.expired(0) {
+		return nil, false
+	}
+
+	tlc.access(element)
+	return item.entry.value, true
+}
+
+func (tlc *tinyLFUCache) set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	tlc.increment(key)
+
+	element, ok := tlc.elementMap[key]
+	if ok {
+		item := tlc.unwrap(element)
+		tlc.notifyEvicted(key, item.entry.value, replacedCause(item.entry))
+		item.entry.setup(key, value, ttl)
+
+		cost := tlc.weigh(key, value)
+		tlc.cost += cost - item.entry.cost
+		item.entry.cost = cost
+
+		tlc.access(element)
+		return nil
+	}
+
+	item := &tinyLFUItem{
+		entry:   newEntry(key, value, ttl, tlc.now),
+		segment: tinyLFUWindow,
+	}
+
+	item.entry.cost = tlc.weigh(key, value)
+	tlc.cost += item.entry.cost
+	tlc.elementMap[key] = tlc.window.PushFront(item)
+
+	if tlc.window.Len() > tlc.windowEntries {
+		evictedValue = tlc.evict()
+	}
+
+	return evictedValue
+}
+
+func (tlc *tinyLFUCache) removeElement(element *list.Element, cause RemovalCause) (removedValue interface{}) {
+	item := tlc.unwrap(element)
+
+	delete(tlc.elementMap, item.entry.key)
+	tlc.listOf(item.segment).Remove(element)
+	tlc.cost -= item.entry.cost
+
+	tlc.notifyEvicted(item.entry.key, item.entry.value, cause)
+	return item.entry.value
+}
+
+func (tlc *tinyLFUCache) remove(key string) (removedValue interface{}) {
+	if element, ok := tlc.elementMap[key]; ok {
+		return tlc.removeElement(element, RemovalExplicit)
+	}
+
+	return nil
+}
+
+func (tlc *tinyLFUCache) size() (size int) {
+	return len(tlc.elementMap)
+}
+
+func (tlc *tinyLFUCache) gc() (cleans int) {
+	now := tlc.now()
+	scans := 0
+
+	for _, element := range tlc.elementMap {
+		scans++
+
+		if item := tlc.unwrap(element); item.entry.expired(now) {
+			tlc.removeElement(element, RemovalExpired)
+			cleans++
+		}
+
+		if tlc.maxScans > 0 && scans >= tlc.maxScans {
+			break
+		}
+	}
+
+	return cleans
+}
+
+func (tlc *tinyLFUCache) reset() {
+	if tlc.onEvicted != nil {
+		for _, element := range tlc.elementMap {
+			entry := tlc.unwrap(element).entry
+			tlc.notifyEvicted(entry.key, entry.value, RemovalReset)
+		}
+	}
+
+	tlc.elementMap = make(map[string]*list.Element, mapInitialCap)
+	tlc.window = list.New()
+	tlc.probation = list.New()
+	tlc.protected = list.New()
+	tlc.cost = 0
+	tlc.sketch.Reset()
+
+	tlc.loader.Reset()
+}
+
+func (tlc *tinyLFUCache) dump() []DumpEntry {
+	tlc.lock.RLock()
+	defer tlc.lock.RUnlock()
+
+	now := tlc.now()
+	entries := make([]DumpEntry, 0, tlc.size())
+
+	// Entries in probation are less valuable than entries in protected and window.
+	for _, segment := range []*list.List{tlc.probation, tlc.protected, tlc.window} {
+		for element := segment.Back(); element != nil; element = element.Prev() {
+			if dumpEntry, ok := newDumpEntry(tlc.unwrap(element).entry, now); ok {
+				entries = append(entries, dumpEntry)
+			}
+		}
+	}
+
+	return entries
+}
+
+func (tlc *tinyLFUCache) peek(key string) (value interface{}, ttl time.Duration, found bool) {
+	tlc.lock.RLock()
+	defer tlc.lock.RUnlock()
+
+	if entry := tlc.entryOf(key); entry != nil {
+		return entry.value, entry.ttl(tlc.now()), true
+	}
+
+	return nil, 0, false
+}
+
+func (tlc *tinyLFUCache) loaderOf() *loader {
+	return tlc.loader
+}
+
+// entryOf returns the unexpired entry of key or nil if not found.
+func (tlc *tinyLFUCache) entryOf(key string) *entry {
+	element, ok := tlc.elementMap[key]
+	if !ok {
+		return nil
+	}
+
+	if item := tlc.unwrap(element); !item.entry.expired(0) {
+		return item.entry
+	}
+
+	return nil
+}
+
+func (tlc *tinyLFUCache) expire(key string, ttl time.Duration) (found bool) {
+	entry := tlc.entryOf(key)
+	if entry == nil {
+		return false
+	}
+
+	entry.expire(ttl)
+	return true
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See Cache interface.
+func (tlc *tinyLFUCache) Get(key string) (value interface{}, found bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return tlc.get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See Cache interface.
+func (tlc *tinyLFUCache) Set(key string, value interface{}, ttl time.Duration) (evictedValue interface{}) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return tlc.set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See Cache interface.
+func (tlc *tinyLFUCache) Remove(key string) (removedValue interface{}) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return tlc.remove(key)
+}
+
+// TTL returns the remaining ttl of key and NoTTL means key is never expired.
+// See Cache interface.
+func (tlc *tinyLFUCache) TTL(key string) (ttl time.Duration, found bool) {
+	tlc.lock.RLock()
+	defer tlc.lock.RUnlock()
+
+	if entry := tlc.entryOf(key); entry != nil {
+		return entry.ttl(tlc.now()), true
+	}
+
+	return 0, false
+}
+
+// Touch resets the ttl of key and accesses key like Get.
+// See Cache interface.
+func (tlc *tinyLFUCache) Touch(key string, ttl time.Duration) (found bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	if _, found = tlc.get(key); !found {
+		return false
+	}
+
+	return tlc.expire(key, ttl)
+}
+
+// Expire resets the ttl of key without accessing key.
+// See Cache interface.
+func (tlc *tinyLFUCache) Expire(key string, ttl time.Duration) (found bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return tlc.expire(key, ttl)
+}
+
+// Persist removes the ttl of key so key is never expired.
+// See Cache interface.
+func (tlc *tinyLFUCache) Persist(key string) (found bool) {
+	return tlc.Expire(key, NoTTL)
+}
+
+// GetOrSet gets the value of key like Get, or sets value with ttl to cache if key is absent.
+// See Cache interface.
+func (tlc *tinyLFUCache) GetOrSet(key string, value interface{}, ttl time.Duration) (actual interface{}, found bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return getOrSet(tlc, key, value, ttl)
+}
+
+// SetIfAbsent sets value with ttl to cache only if key is absent.
+// See Cache interface.
+func (tlc *tinyLFUCache) SetIfAbsent(key string, value interface{}, ttl time.Duration) (actual interface{}, stored bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return setIfAbsent(tlc, key, value, ttl)
+}
+
+// CompareAndSwap sets new with ttl to cache only if the value of key equals old by equal.
+// See Cache interface.
+func (tlc *tinyLFUCache) CompareAndSwap(key string, old interface{}, new interface{}, ttl time.Duration, equal func(old interface{}, current interface{}) bool) (swapped bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return compareAndSwap(tlc, key, old, new, ttl, equal)
+}
+
+// Update calls fn with the value of key and sets the new value with ttl to cache if keep is true, or removes key.
+// See Cache interface.
+func (tlc *tinyLFUCache) Update(key string, fn func(old interface{}, found bool) (new interface{}, ttl time.Duration, keep bool)) (value interface{}, kept bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return update(tlc, key, fn)
+}
+
+// GetAndRemove removes key and returns its value if found.
+// See Cache interface.
+func (tlc *tinyLFUCache) GetAndRemove(key string) (value interface{}, found bool) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return getAndRemove(tlc, key)
+}
+
+// Incr adds delta to the int64 value of key and returns the new value.
+// See Cache interface.
+func (tlc *tinyLFUCache) Incr(key string, delta int64, ttl time.Duration) (value int64) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return incr(tlc, key, delta, ttl)
+}
+
+// Decr subtracts delta from the int64 value of key and returns the new value.
+// See Cache interface.
+func (tlc *tinyLFUCache) Decr(key string, delta int64, ttl time.Duration) (value int64) {
+	return tlc.Incr(key, -delta, ttl)
+}
+
+// Size returns the count of keys in cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) Size() (size int) {
+	tlc.lock.RLock()
+	defer tlc.lock.RUnlock()
+
+	return tlc.size()
+}
+
+// Cost returns the total cost of entries in cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) Cost() (cost int64) {
+	tlc.lock.RLock()
+	defer tlc.lock.RUnlock()
+
+	return tlc.cost
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See Cache interface.
+func (tlc *tinyLFUCache) GC() (cleans int) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return tlc.gc()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) Reset() {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	tlc.reset()
+}
+
+// Close resets cache to release its entries.
+// See Cache interface.
+func (tlc *tinyLFUCache) Close() error {
+	tlc.Reset()
+	return nil
+}
+
+// Range calls fn with each unexpired entry in cache until fn returns false.
+// See Cache interface.
+func (tlc *tinyLFUCache) Range(fn func(key string, value interface{}, ttl time.Duration) bool) {
+	rangeEntries(tlc.dump(), fn)
+}
+
+// Keys returns all unexpired keys in cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) Keys() (keys []string) {
+	return keysOf(tlc.dump())
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (tlc *tinyLFUCache) Load(key string, ttl time.Duration, load func() (value interface{}, err error)) (value interface{}, err error) {
+	value, err = tlc.loader.Load(key, ttl, load)
+	if err != nil {
+		return value, err
+	}
+
+	tlc.Set(key, value, ttl)
+	return value, nil
+}
+
+// LoadContext loads a value by load function with ctx and sets it to cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) LoadContext(ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (value interface{}, err error)) (value interface{}, err error) {
+	return loadContext(ctx, tlc, tlc.loader, key, ttl, load)
+}
+
+// GetMulti gets the values of keys from cache and returns the missed keys.
+// See Cache interface.
+func (tlc *tinyLFUCache) GetMulti(keys []string) (values map[string]interface{}, missedKeys []string) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return getMulti(keys, tlc.get)
+}
+
+// SetMulti sets entries to cache with ttl.
+// See Cache interface.
+func (tlc *tinyLFUCache) SetMulti(entries map[string]interface{}, ttl time.Duration) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	setMulti(entries, ttl, tlc.set)
+}
+
+// RemoveMulti removes keys and returns the removed values of keys.
+// See Cache interface.
+func (tlc *tinyLFUCache) RemoveMulti(keys []string) (removedValues map[string]interface{}) {
+	tlc.lock.Lock()
+	defer tlc.lock.Unlock()
+
+	return removeMulti(keys, tlc.remove)
+}
+
+// LoadMulti loads the missed keys by load function and sets them to cache.
+// See Cache interface.
+func (tlc *tinyLFUCache) LoadMulti(keys []string, ttl time.Duration, load func(missedKeys []string) (values map[string]interface{}, err error)) (values map[string]interface{}, err error) {
+	return loadMulti(tlc, tlc.loader, keys, ttl, load)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestTinyLFUCache() *tinyLFUCache {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newTinyLFUCache(conf).(*tinyLFUCache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTinyLFUCache$
+func TestTinyLFUCache(t *testing.T) {
+	cache := newTestTinyLFUCache()
+	testCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTinyLFUCacheEvict$
+func TestTinyLFUCacheEvict(t *testing.T) {
+	cache := newTestTinyLFUCache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue := cache.Set(data, data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && evictedValue == nil {
+			t.Fatalf("i %d >= cache.maxEntries %d && evictedValue == nil", i, cache.maxEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+
+	if cache.window.Len() != cache.windowEntries {
+		t.Fatalf("cache.window.Len() %d != cache.windowEntries %d", cache.window.Len(), cache.windowEntries)
+	}
+
+	if cache.probation.Len()+cache.protected.Len() != cache.mainEntries {
+		t.Fatalf("cache.probation.Len() %d + cache.protected.Len() %d != cache.mainEntries %d", cache.probation.Len(), cache.protected.Len(), cache.mainEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTinyLFUCacheAdmit$
+func TestTinyLFUCacheAdmit(t *testing.T) {
+	cache := newTestTinyLFUCache()
+
+	hotKeys := make([]string, 0, cache.mainEntries)
+	for i := 0; i < cache.mainEntries; i++ {
+		hotKeys = append(hotKeys, "hot"+strconv.Itoa(i))
+	}
+
+	for _, key := range hotKeys {
+		cache.Set(key, key, NoTTL)
+	}
+
+	// Let window lru push all hot keys to main lru.
+	cache.Set("push", "push", NoTTL)
+
+	for i := 0; i < 5; i++ {
+		for _, key := range hotKeys {
+			cache.Get(key)
+		}
+	}
+
+	if cache.protected.Len() != cache.protectedEntries {
+		t.Fatalf("cache.protected.Len() %d != cache.protectedEntries %d", cache.protected.Len(), cache.protectedEntries)
+	}
+
+	// Keys in a scan only appear once, so they shouldn't be admitted to main lru.
+	for i := 0; i < cache.maxEntries*5; i++ {
+		key := "scan" + strconv.Itoa(i)
+		cache.Set(key, key, NoTTL)
+	}
+
+	for _, key := range hotKeys {
+		value, ok := cache.Get(key)
+		if !ok || value.(string) != key {
+			t.Fatalf("hot key %s should be found", key)
+		}
+	}
+
+	// Keys accessed frequently enough should be admitted.
+	for i := 0; i < 20; i++ {
+		cache.Get("new")
+	}
+
+	cache.Set("new", "new", NoTTL)
+	cache.Set("push", "push", NoTTL)
+
+	if _, ok := cache.Get("new"); !ok {
+		t.Fatal("new key should be found")
+	}
+
+	element := cache.elementMap["new"]
+	if segment := cache.unwrap(element).segment; segment == tinyLFUWindow {
+		t.Fatalf("segment %d == tinyLFUWindow", segment)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"fmt"
+	"time"
+)
+
+// TypedCache is the generic version of Cache.
+// Keys are in type K and values are in type V, so there is no need to assert the type of values.
+// Keys in other types like int64 are supported, see WithHasher if you want to customize the hash of keys.
+// Typed caches share the same cores with untyped caches, and keys are passed to callbacks like weigher in their string forms.
+type TypedCache[K comparable, V any] interface {
+	// Get gets the value of key from cache and returns value if found.
+	// A zero value will be returned if key doesn't exist in cache.
+	// See Cache.Get.
+	Get(key K) (value V, found bool)
+
+	// Set sets key and value to cache with ttl and returns evicted value if exists.
+	// See NoTTL if you want your key is never expired.
+	Set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool)
+
+	// Remove removes key and returns the removed value of key.
+	// A zero value will be returned if key doesn't exist in cache.
+	Remove(key K) (removedValue V, removed bool)
+
+	// Size returns the count of keys in cache.
+	// The result may be different in different implements.
+	Size() (size int)
+
+	// GC cleans the expired keys in cache and returns the exact count cleaned.
+	// The exact cleans depend on implements, however, all implements should have a limit of scanning.
+	GC() (cleans int)
+
+	// Reset resets cache to initial status which is like a new cache.
+	Reset()
+
+	// Load loads a key with ttl to cache and returns an error if failed.
+	// See Cache.Load.
+	Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error)
+
+	// Close closes cache, stops its gc task and releases its entries.
+	// See Cache.Close.
+	Close() error
+}
+
+// typedConfig is the config of typed caches whose keys are in type K.
+type typedConfig[K comparable] struct {
+	*config
+
+	hasher func(key K) int
+}
+
+func newDefaultTypedConfig[K comparable]() *typedConfig[K] {
+	return &typedConfig[K]{
+		config: newDefaultConfig(),
+	}
+}
+
+func newTypedCacheOf[K comparable, V any](cacheType CacheType) func(conf *config) TypedCache[K, V] {
+	switch cacheType {
+	case standard:
+		return func(conf *config) TypedCache[K, V] {
+			return newTypedStandardCache[K, V](conf)
+		}
+	case lru:
+		return func(conf *config) TypedCache[K, V] {
+			return newTypedLRUCache[K, V](conf)
+		}
+	case lfu:
+		return func(conf *config) TypedCache[K, V] {
+			return newTypedLFUCache[K, V](conf)
+		}
+	default:
+		return nil
+	}
+}
+
+func newTypedCache[K comparable, V any](withReport bool, opts ...TypedOption[K]) (cache TypedCache[K, V], reporter *Reporter) {
+	conf := newDefaultTypedConfig[K]()
+	applyTypedOptions(conf, opts)
+
+	newCache := newTypedCacheOf[K, V](conf.cacheType)
+	if newCache == nil {
+		panic("cachego: cache type doesn't exist")
+	}
+
+	if conf.shardings > 0 {
+		cache = newTypedShardingCache(conf, newCache)
+	} else {
+		cache = newCache(conf.config)
+	}
+
+	if withReport {
+		cache, reporter = reportTyped(conf.config, cache)
+	}
+
+	var cancel func()
+	if conf.gcDuration > 0 {
+		cancel = runGCTask(cache.GC, conf.gcDuration)
+	}
+
+	cache = newTypedClosableCache(cache, cancel)
+	return cache, reporter
+}
+
+// NewTypedCache creates a typed cache with typed options.
+// It works like NewCache but keys and values have their own types.
+// Use WithOptions to pass options like WithLRU, and WithHasher to customize the hash of keys.
+// Notice that only standard, lru and lfu are supported in typed cache, and other types will panic.
+// See NewCache.
+func NewTypedCache[K comparable, V any](opts ...TypedOption[K]) (cache TypedCache[K, V]) {
+	cache, _ = newTypedCache[K, V](false, opts...)
+	return cache
+}
+
+// NewTypedCacheWithReport creates a typed cache and a reporter with typed options.
+// It works like NewCacheWithReport but keys and values have their own types.
+// See NewCacheWithReport.
+func NewTypedCacheWithReport[K comparable, V any](opts ...TypedOption[K]) (cache TypedCache[K, V], reporter *Reporter) {
+	return newTypedCache[K, V](true, opts...)
+}
+
+// hashOf returns the hash function of keys in type K.
+// It uses the hasher in config if it's set, or uses the default hash of K.
+func hashOf[K comparable](conf *typedConfig[K]) func(key K) int {
+	if conf.hasher != nil {
+		return conf.hasher
+	}
+
+	return func(key K) int {
+		return typedHash(conf.hash, key)
+	}
+}
+
+func mixHash(n uint64) int {
+	// See splitmix64.
+	n ^= n >> 30
+	n *= 0xbf58476d1ce4e5b9
+	n ^= n >> 27
+	n *= 0x94d049bb133111eb
+	n ^= n >> 31
+
+	return int(n >> 1)
+}
+
+func typedHash[K comparable](hash func(key string) int, key K) int {
+	switch k := any(key).(type) {
+	case string:
+		return hash(k)
+	case int:
+		return mixHash(uint64(k))
+	case int8:
+		return mixHash(uint64(k))
+	case int16:
+		return mixHash(uint64(k))
+	case int32:
+		return mixHash(uint64(k))
+	case int64:
+		return mixHash(uint64(k))
+	case uint:
+		return mixHash(uint64(k))
+	case uint8:
+		return mixHash(uint64(k))
+	case uint16:
+		return mixHash(uint64(k))
+	case uint32:
+		return mixHash(uint64(k))
+	case uint64:
+		return mixHash(k)
+	case uintptr:
+		return mixHash(uint64(k))
+	default:
+		// Other keys are rare, so we use their string forms to hash.
+		// Use WithHasher to set a better one if you want.
+		return hash(fmt.Sprint(k))
+	}
+}
+
+// keyString returns the string form of key which is used in reporting.
+func keyString[K comparable](key K) string {
+	if k, ok := any(key).(string); ok {
+		return k
+	}
+
+	return fmt.Sprint(key)
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"time"
+)
+
+// typedClosableCache is the outermost typed cache which stops the gc task and rejects calls after closing.
+type typedClosableCache[K comparable, V any] struct {
+	cache  TypedCache[K, V]
+	cancel func()
+	guard  *closeGuard
+}
+
+func newTypedClosableCache[K comparable, V any](cache TypedCache[K, V], cancel func()) TypedCache[K, V] {
+	return &typedClosableCache[K, V]{
+		cache:  cache,
+		cancel: cancel,
+		guard:  newCloseGuard(),
+	}
+}
+
+// Get gets the value of key from cache and returns value if found.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Get(key K) (value V, found bool) {
+	if !tcc.guard.enter() {
+		return value, false
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.Get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	if !tcc.guard.enter() {
+		return evictedValue, false
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.Set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Remove(key K) (removedValue V, removed bool) {
+	if !tcc.guard.enter() {
+		return removedValue, false
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.Remove(key)
+}
+
+// Size returns the count of keys in cache.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Size() (size int) {
+	if !tcc.guard.enter() {
+		return 0
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.Size()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) GC() (cleans int) {
+	if !tcc.guard.enter() {
+		return 0
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.GC()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Reset() {
+	if !tcc.guard.enter() {
+		return
+	}
+
+	defer tcc.guard.leave()
+
+	tcc.cache.Reset()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error) {
+	if !tcc.guard.enter() {
+		return value, ErrClosed
+	}
+
+	defer tcc.guard.leave()
+
+	return tcc.cache.Load(key, ttl, load)
+}
+
+// Close closes cache, stops its gc task and releases its entries.
+// It rejects new calls and waits for running calls to return, so don't close cache in functions passed to cache.
+// See TypedCache interface.
+func (tcc *typedClosableCache[K, V]) Close() error {
+	if !tcc.guard.close() {
+		return nil
+	}
+
+	if tcc.cancel != nil {
+		tcc.cancel()
+	}
+
+	return tcc.cache.Close()
+}
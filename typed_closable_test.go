@@ -0,0 +1,123 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedClosableCache$
+func TestTypedClosableCache(t *testing.T) {
+	cache := newTypedClosableCache[int64, string](newTestTypedStandardCache(), nil)
+	testTypedCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedClosableCacheClose$
+func TestTypedClosableCacheClose(t *testing.T) {
+	cancels := 0
+	standardCache := newTestTypedStandardCache()
+
+	cache := newTypedClosableCache[int64, string](standardCache, func() {
+		cancels++
+	})
+
+	cache.Set(1, "value", NoTTL)
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing a closed cache should do nothing.
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if cancels != 1 {
+		t.Fatalf("cancels %d != 1", cancels)
+	}
+
+	if standardCache.Size() != 0 {
+		t.Fatalf("standardCache.Size() %d != 0", standardCache.Size())
+	}
+
+	cache.Set(1, "value", NoTTL)
+	if _, found := cache.Get(1); found {
+		t.Fatal("key found after closing")
+	}
+
+	if cache.Size() != 0 || standardCache.Size() != 0 {
+		t.Fatalf("cache.Size() %d, standardCache.Size() %d is wrong", cache.Size(), standardCache.Size())
+	}
+
+	load := func() (string, error) {
+		return "value", nil
+	}
+
+	if _, err := cache.Load(1, NoTTL, load); !errors.Is(err, ErrClosed) {
+		t.Fatalf("err %+v != ErrClosed", err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedClosableCacheCloseConcurrently$
+func TestTypedClosableCacheCloseConcurrently(t *testing.T) {
+	standardCache := newTestTypedStandardCache()
+	cache := newTypedClosableCache[int64, string](standardCache, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				cache.Set(int64(i*1000+j), strconv.Itoa(j), NoTTL)
+			}
+		}(i)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+
+	// No calls should run after closing, so entries set concurrently are all released.
+	if standardCache.Size() != 0 {
+		t.Fatalf("standardCache.Size() %d != 0", standardCache.Size())
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedCacheCloseGC$
+func TestTypedCacheCloseGC(t *testing.T) {
+	cache, reporter := NewTypedCacheWithReport[int64, string](WithOptions[int64](WithGC(time.Millisecond)))
+
+	time.Sleep(20 * time.Millisecond)
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The gc task should be stopped after closing.
+	gcCount := reporter.CountGC()
+	time.Sleep(20 * time.Millisecond)
+
+	if reporter.CountGC() > gcCount+1 {
+		t.Fatalf("reporter.CountGC() %d > %d", reporter.CountGC(), gcCount+1)
+	}
+}
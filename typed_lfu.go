@@ -0,0 +1,92 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"time"
+)
+
+// Get gets the value of key from cache and returns value if found.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Get(key K) (value V, found bool) {
+	tfc.lock.Lock()
+	defer tfc.lock.Unlock()
+
+	return tfc.get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	tfc.lock.Lock()
+	defer tfc.lock.Unlock()
+
+	return tfc.set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Remove(key K) (removedValue V, removed bool) {
+	tfc.lock.Lock()
+	defer tfc.lock.Unlock()
+
+	return tfc.remove(key)
+}
+
+// Size returns the count of keys in cache.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Size() (size int) {
+	tfc.lock.RLock()
+	defer tfc.lock.RUnlock()
+
+	return tfc.size()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) GC() (cleans int) {
+	tfc.lock.Lock()
+	defer tfc.lock.Unlock()
+
+	return tfc.gc()
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Reset() {
+	tfc.lock.Lock()
+	defer tfc.lock.Unlock()
+
+	tfc.reset()
+}
+
+// Close resets cache to release its entries.
+// See TypedCache interface.
+func (tfc *typedLFUCache[K, V]) Close() error {
+	tfc.Reset()
+	return nil
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (tfc *typedLFUCache[K, V]) Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error) {
+	value, err = tfc.loader.Load(key, ttl, load)
+	if err != nil {
+		return value, err
+	}
+
+	tfc.Set(key, value, ttl)
+	return value, nil
+}
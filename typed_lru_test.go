@@ -0,0 +1,83 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestTypedLRUCache() *typedLRUCache[int64, string] {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newTypedLRUCache[int64, string](conf)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedLRUCache$
+func TestTypedLRUCache(t *testing.T) {
+	cache := newTestTypedLRUCache()
+	testTypedCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedLRUCacheEvict$
+func TestTypedLRUCacheEvict(t *testing.T) {
+	cache := newTestTypedLRUCache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue, evicted := cache.Set(int64(i), data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && (!evicted || evictedValue == "") {
+			t.Fatalf("i %d >= cache.maxEntries %d && !evicted", i, cache.maxEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedLRUCacheMaxCost$
+func TestTypedLRUCacheMaxCost(t *testing.T) {
+	var evictedKeys []string
+	onEvicted := func(key string, value interface{}, cause RemovalCause) {
+		evictedKeys = append(evictedKeys, key)
+	}
+
+	weigher := func(key string, value interface{}) int64 {
+		return int64(len(value.(string)))
+	}
+
+	cache := NewTypedCache[int64, string](WithOptions[int64](WithLRU(0), WithMaxCost(10), WithWeigher(weigher), WithOnEvicted(onEvicted)))
+
+	cache.Set(1, "12345", NoTTL)
+	cache.Set(2, "12345", NoTTL)
+	cache.Get(1)
+
+	// Typed caches share the cores with untyped caches, so they are bounded by cost, too.
+	if evictedValue, evicted := cache.Set(3, "12345", NoTTL); !evicted || evictedValue != "12345" {
+		t.Fatalf("evictedValue %s, evicted %+v is wrong", evictedValue, evicted)
+	}
+
+	if _, found := cache.Get(2); found {
+		t.Fatal("key 2 should be evicted")
+	}
+
+	if len(evictedKeys) != 1 || evictedKeys[0] != "2" {
+		t.Fatalf("evictedKeys %+v is wrong", evictedKeys)
+	}
+}
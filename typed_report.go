@@ -0,0 +1,137 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"time"
+)
+
+type typedReportableCache[K comparable, V any] struct {
+	*config
+	*Reporter
+
+	cache TypedCache[K, V]
+}
+
+func reportTyped[K comparable, V any](conf *config, cache TypedCache[K, V]) (TypedCache[K, V], *Reporter) {
+	reporter := &Reporter{
+		conf:        conf,
+		cache:       cache,
+		hitCount:    0,
+		missedCount: 0,
+		gcCount:     0,
+		loadCount:   0,
+	}
+
+	reportable := &typedReportableCache[K, V]{
+		config:   conf,
+		Reporter: reporter,
+		cache:    cache,
+	}
+
+	return reportable, reporter
+}
+
+// Get gets the value of key from cache and returns value if found.
+func (trc *typedReportableCache[K, V]) Get(key K) (value V, found bool) {
+	value, found = trc.cache.Get(key)
+
+	if found {
+		if trc.recordHit {
+			trc.increaseHitCount()
+		}
+
+		if trc.reportHit != nil {
+			trc.reportHit(trc.Reporter, keyString(key), value)
+		}
+	} else {
+		if trc.recordMissed {
+			trc.increaseMissedCount()
+		}
+
+		if trc.reportMissed != nil {
+			trc.reportMissed(trc.Reporter, keyString(key))
+		}
+	}
+
+	return value, found
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	return trc.cache.Set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Remove(key K) (removedValue V, removed bool) {
+	return trc.cache.Remove(key)
+}
+
+// Size returns the count of keys in cache.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Size() (size int) {
+	return trc.cache.Size()
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) GC() (cleans int) {
+	if trc.recordGC {
+		trc.increaseGCCount()
+	}
+
+	if trc.reportGC == nil {
+		return trc.cache.GC()
+	}
+
+	begin := trc.now()
+	cleans = trc.cache.GC()
+	end := trc.now()
+
+	cost := time.Duration(end - begin)
+	trc.reportGC(trc.Reporter, cost, cleans)
+
+	return cleans
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Reset() {
+	trc.cache.Reset()
+}
+
+// Close closes cache.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Close() error {
+	return trc.cache.Close()
+}
+
+// Load loads a key with ttl to cache and returns an error if failed.
+// See TypedCache interface.
+func (trc *typedReportableCache[K, V]) Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error) {
+	value, err = trc.cache.Load(key, ttl, load)
+
+	if trc.recordLoad {
+		trc.increaseLoadCount()
+	}
+
+	if trc.reportLoad != nil {
+		trc.reportLoad(trc.Reporter, keyString(key), value, ttl, err)
+	}
+
+	return value, err
+}
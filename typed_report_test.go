@@ -0,0 +1,73 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"testing"
+)
+
+func newTestTypedReportableCache() (*typedReportableCache[int64, string], *Reporter) {
+	conf := newDefaultConfig()
+	conf.cacheName = testCacheName
+	conf.maxEntries = maxTestEntries
+
+	cache, reporter := reportTyped(conf, newTypedStandardCache[int64, string](conf))
+	return cache.(*typedReportableCache[int64, string]), reporter
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedReportableCache$
+func TestTypedReportableCache(t *testing.T) {
+	cache, _ := newTestTypedReportableCache()
+	testTypedCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedReportableCacheReport$
+func TestTypedReportableCacheReport(t *testing.T) {
+	cache, reporter := newTestTypedReportableCache()
+	cache.Set(1, "value", NoTTL)
+
+	hitKey := ""
+	cache.reportHit = func(reporter *Reporter, key string, value interface{}) {
+		hitKey = key
+	}
+
+	missedKey := ""
+	cache.reportMissed = func(reporter *Reporter, key string) {
+		missedKey = key
+	}
+
+	cache.Get(1)
+	cache.Get(2)
+
+	if hitKey != "1" {
+		t.Fatalf("hitKey %s is wrong", hitKey)
+	}
+
+	if missedKey != "2" {
+		t.Fatalf("missedKey %s is wrong", missedKey)
+	}
+
+	if reporter.CountHit() != 1 {
+		t.Fatalf("CountHit %d is wrong", reporter.CountHit())
+	}
+
+	if reporter.CountMissed() != 1 {
+		t.Fatalf("CountMissed %d is wrong", reporter.CountMissed())
+	}
+
+	if reporter.CacheSize() != 1 {
+		t.Fatalf("CacheSize %d is wrong", reporter.CacheSize())
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"math/bits"
+	"time"
+)
+
+type typedShardingCache[K comparable, V any] struct {
+	*config
+	caches []TypedCache[K, V]
+	hash   func(key K) int
+}
+
+func newTypedShardingCache[K comparable, V any](conf *typedConfig[K], newCache func(conf *config) TypedCache[K, V]) TypedCache[K, V] {
+	if conf.shardings <= 0 {
+		panic("cachego: shardings must be > 0.")
+	}
+
+	if bits.OnesCount(uint(conf.shardings)) > 1 {
+		panic("cachego: shardings must be the pow of 2 (such as 64).")
+	}
+
+	// Each sharding cache gets a proportional share of the cost budget.
+	shardingConf := conf.config
+	if conf.maxCost > 0 {
+		shardingConf = new(config)
+		*shardingConf = *conf.config
+		shardingConf.maxCost = max(conf.maxCost/int64(conf.shardings), 1)
+	}
+
+	caches := make([]TypedCache[K, V], 0, conf.shardings)
+	for i := 0; i < conf.shardings; i++ {
+		caches = append(caches, newCache(shardingConf))
+	}
+
+	cache := &typedShardingCache[K, V]{
+		config: conf.config,
+		caches: caches,
+		hash:   hashOf[K](conf),
+	}
+
+	return cache
+}
+
+func (tsc *typedShardingCache[K, V]) cacheOf(key K) TypedCache[K, V] {
+	hash := tsc.hash(key)
+	mask := len(tsc.caches) - 1
+
+	return tsc.caches[hash&mask]
+}
+
+// Get gets the value of key from cache and returns value if found.
+func (tsc *typedShardingCache[K, V]) Get(key K) (value V, found bool) {
+	return tsc.cacheOf(key).Get(key)
+}
+
+// Set sets key and value to cache with ttl and returns evicted value if exists and unexpired.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) Set(key K, value V, ttl time.Duration) (evictedValue V, evicted bool) {
+	return tsc.cacheOf(key).Set(key, value, ttl)
+}
+
+// Remove removes key and returns the removed value of key.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) Remove(key K) (removedValue V, removed bool) {
+	return tsc.cacheOf(key).Remove(key)
+}
+
+// Size returns the count of keys in cache.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) Size() (size int) {
+	for _, cache := range tsc.caches {
+		size += cache.Size()
+	}
+
+	return size
+}
+
+// GC cleans the expired keys in cache and returns the exact count cleaned.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) GC() (cleans int) {
+	for _, cache := range tsc.caches {
+		cleans += cache.GC()
+	}
+
+	return cleans
+}
+
+// Reset resets cache to initial status which is like a new cache.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) Reset() {
+	for _, cache := range tsc.caches {
+		cache.Reset()
+	}
+}
+
+// Close closes all sharding caches and returns the first error.
+// See TypedCache interface.
+func (tsc *typedShardingCache[K, V]) Close() error {
+	var err error
+	for _, cache := range tsc.caches {
+		if closeErr := cache.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// Load loads a value by load function and sets it to cache.
+// Returns an error if load failed.
+func (tsc *typedShardingCache[K, V]) Load(key K, ttl time.Duration, load func() (value V, err error)) (value V, err error) {
+	return tsc.cacheOf(key).Load(key, ttl, load)
+}
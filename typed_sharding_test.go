@@ -0,0 +1,52 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"testing"
+)
+
+func newTestTypedShardingCache() *typedShardingCache[int64, string] {
+	conf := newDefaultTypedConfig[int64]()
+	conf.shardings = testShardings
+
+	newCache := newTypedCacheOf[int64, string](standard)
+	return newTypedShardingCache(conf, newCache).(*typedShardingCache[int64, string])
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedShardingCache$
+func TestTypedShardingCache(t *testing.T) {
+	cache := newTestTypedShardingCache()
+	testTypedCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedShardingCacheIndex$
+func TestTypedShardingCacheIndex(t *testing.T) {
+	cache := newTestTypedShardingCache()
+
+	if len(cache.caches) != testShardings {
+		t.Fatalf("len(cache.caches) %d is wrong", len(cache.caches))
+	}
+
+	for i := int64(0); i < 100; i++ {
+		cache.Set(i, "value", NoTTL)
+	}
+
+	for i := range cache.caches {
+		if cache.caches[i].Size() <= 0 {
+			t.Fatalf("cache.caches[i].Size() %d <= 0", cache.caches[i].Size())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestTypedStandardCache() *typedStandardCache[int64, string] {
+	conf := newDefaultConfig()
+	conf.maxEntries = maxTestEntries
+
+	return newTypedStandardCache[int64, string](conf)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedStandardCache$
+func TestTypedStandardCache(t *testing.T) {
+	cache := newTestTypedStandardCache()
+	testTypedCacheImplement(t, cache)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestTypedStandardCacheEvict$
+func TestTypedStandardCacheEvict(t *testing.T) {
+	cache := newTestTypedStandardCache()
+
+	for i := 0; i < cache.maxEntries*10; i++ {
+		data := strconv.Itoa(i)
+		evictedValue, evicted := cache.Set(int64(i), data, time.Duration(i)*time.Second)
+
+		if i >= cache.maxEntries && (!evicted || evictedValue == "") {
+			t.Fatalf("i %d >= cache.maxEntries %d && !evicted", i, cache.maxEntries)
+		}
+	}
+
+	if cache.Size() != cache.maxEntries {
+		t.Fatalf("cache.Size() %d != cache.maxEntries %d", cache.Size(), cache.maxEntries)
+	}
+}
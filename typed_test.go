@@ -0,0 +1,215 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"testing"
+	"time"
+)
+
+func testTypedCacheGet(t *testing.T, cache TypedCache[int64, string]) {
+	value, found := cache.Get(1)
+	if found {
+		t.Fatalf("get %+v should be not found", value)
+	}
+
+	cache.Set(1, "value", time.Millisecond)
+
+	value, found = cache.Get(1)
+	if !found {
+		t.Fatal("get should be found")
+	}
+
+	if value != "value" {
+		t.Fatalf("value %+v is wrong", value)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	value, found = cache.Get(1)
+	if found {
+		t.Fatalf("get %+v should be not found", value)
+	}
+}
+
+func testTypedCacheRemove(t *testing.T, cache TypedCache[int64, string]) {
+	removedValue, removed := cache.Remove(1)
+	if removed || removedValue != "" {
+		t.Fatalf("removedValue %+v is wrong", removedValue)
+	}
+
+	cache.Set(1, "value", NoTTL)
+
+	removedValue, removed = cache.Remove(1)
+	if !removed || removedValue != "value" {
+		t.Fatalf("removedValue %+v is wrong", removedValue)
+	}
+}
+
+func testTypedCacheGC(t *testing.T, cache TypedCache[int64, string]) {
+	for i := int64(0); i < maxTestEntries; i++ {
+		if i&1 == 0 {
+			cache.Set(i, "value", NoTTL)
+		} else {
+			cache.Set(i, "value", time.Millisecond)
+		}
+	}
+
+	size := cache.Size()
+	if size != maxTestEntries {
+		t.Fatalf("size %d is wrong", size)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	cache.GC()
+
+	size = cache.Size()
+	if size != maxTestEntries/2 {
+		t.Fatalf("size %d is wrong", size)
+	}
+}
+
+func testTypedCacheReset(t *testing.T, cache TypedCache[int64, string]) {
+	for i := int64(0); i < maxTestEntries; i++ {
+		cache.Set(i, "value", NoTTL)
+	}
+
+	size := cache.Size()
+	if size != maxTestEntries {
+		t.Fatalf("size %d is wrong", size)
+	}
+
+	cache.Reset()
+
+	size = cache.Size()
+	if size != 0 {
+		t.Fatalf("size %d is wrong", size)
+	}
+}
+
+func testTypedCacheLoad(t *testing.T, cache TypedCache[int64, string]) {
+	value, err := cache.Load(1, NoTTL, func() (value string, err error) {
+		return "loaded", nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "loaded" {
+		t.Fatalf("value %s is wrong", value)
+	}
+
+	value, found := cache.Get(1)
+	if !found || value != "loaded" {
+		t.Fatalf("value %s is wrong", value)
+	}
+}
+
+func testTypedCacheImplement(t *testing.T, cache TypedCache[int64, string]) {
+	testCaches := []func(t *testing.T, cache TypedCache[int64, string]){
+		testTypedCacheGet, testTypedCacheRemove, testTypedCacheGC, testTypedCacheReset, testTypedCacheLoad,
+	}
+
+	for _, testCache := range testCaches {
+		cache.Reset()
+		testCache(t, cache)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewTypedCache$
+func TestNewTypedCache(t *testing.T) {
+	cache := NewTypedCache[int64, string]()
+
+	if _, ok := cache.(*typedClosableCache[int64, string]).cache.(*typedStandardCache[int64, string]); !ok {
+		t.Fatalf("cache.(*typedStandardCache) %T not ok", cache)
+	}
+
+	cache = NewTypedCache[int64, string](WithOptions[int64](WithLRU(16)))
+
+	if _, ok := cache.(*typedClosableCache[int64, string]).cache.(*typedLRUCache[int64, string]); !ok {
+		t.Fatalf("cache.(*typedLRUCache) %T not ok", cache)
+	}
+
+	cache = NewTypedCache[int64, string](WithOptions[int64](WithLFU(16)))
+
+	if _, ok := cache.(*typedClosableCache[int64, string]).cache.(*typedLFUCache[int64, string]); !ok {
+		t.Fatalf("cache.(*typedLFUCache) %T not ok", cache)
+	}
+
+	cache = NewTypedCache[int64, string](WithOptions[int64](WithShardings(64)))
+
+	if _, ok := cache.(*typedClosableCache[int64, string]).cache.(*typedShardingCache[int64, string]); !ok {
+		t.Fatalf("cache.(*typedShardingCache) %T not ok", cache)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("new should panic")
+		}
+	}()
+
+	NewTypedCache[int64, string](WithOptions[int64](WithLRU(0)))
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewTypedCacheWithReport$
+func TestNewTypedCacheWithReport(t *testing.T) {
+	cache, reporter := NewTypedCacheWithReport[int64, string]()
+
+	if _, ok := cache.(*typedClosableCache[int64, string]).cache.(*typedReportableCache[int64, string]); !ok {
+		t.Fatalf("cache.(*typedReportableCache) %T not ok", cache)
+	}
+
+	if reporter == nil {
+		t.Fatal("reporter == nil")
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestHashOf$
+func TestHashOf(t *testing.T) {
+	stringHash := hashOf(newDefaultTypedConfig[string]())
+	if stringHash("key") != hash("key") {
+		t.Fatalf("stringHash %d != hash %d", stringHash("key"), hash("key"))
+	}
+
+	conf := newDefaultTypedConfig[int64]()
+
+	intHash := hashOf(conf)
+	if intHash(1) == intHash(2) {
+		t.Fatalf("intHash(1) %d == intHash(2) %d", intHash(1), intHash(2))
+	}
+
+	if intHash(-1) < 0 {
+		t.Fatalf("intHash(-1) %d < 0", intHash(-1))
+	}
+
+	type point struct {
+		x int
+		y int
+	}
+
+	pointHash := hashOf(newDefaultTypedConfig[point]())
+	if pointHash(point{x: 1, y: 2}) != hash("{1 2}") {
+		t.Fatalf("pointHash %d != hash %d", pointHash(point{x: 1, y: 2}), hash("{1 2}"))
+	}
+
+	WithHasher(func(key int64) int { return int(key) }).applyTo(conf)
+
+	intHash = hashOf(conf)
+	if intHash(666) != 666 {
+		t.Fatalf("intHash(666) %d != 666", intHash(666))
+	}
+}
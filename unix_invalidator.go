@@ -0,0 +1,237 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// maxInvalidationSize is the max size of an invalidation datagram.
+	maxInvalidationSize = 64 * 1024
+
+	// minReceiveBackoff and maxReceiveBackoff limit the backoff of receiving after a read error.
+	minReceiveBackoff = time.Millisecond
+	maxReceiveBackoff = time.Second
+)
+
+// subscribers stores the functions subscribing invalidations.
+type subscribers struct {
+	fns    map[uint64]func(invalidation Invalidation)
+	nextID uint64
+	lock   sync.RWMutex
+}
+
+func (s *subscribers) add(fn func(invalidation Invalidation)) (id uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.fns == nil {
+		s.fns = make(map[uint64]func(invalidation Invalidation), 4)
+	}
+
+	s.nextID++
+	s.fns[s.nextID] = fn
+
+	return s.nextID
+}
+
+func (s *subscribers) remove(id uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.fns, id)
+}
+
+func (s *subscribers) notify(invalidation Invalidation) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, fn := range s.fns {
+		fn(invalidation)
+	}
+}
+
+// UnixInvalidator is an invalidator delivering invalidations between processes on the same host by unix datagram sockets.
+// Each invalidator listens on its own socket path and publishes invalidations to the socket paths of peers.
+// Invalidations may be lost like udp, so keys should have a ttl as the last defense of staleness.
+type UnixInvalidator struct {
+	path  string
+	conn  *net.UnixConn
+	peers []*net.UnixAddr
+
+	// writeLock serializes writes, because the write deadline is shared by all writes of conn.
+	writeLock sync.Mutex
+
+	subscribers subscribers
+	closing     chan struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// removeSocket removes the socket file of path if it exists.
+// It returns an error if path isn't a socket, so a regular file won't be removed by mistake.
+func removeSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("cachego: %s isn't a socket", path)
+	}
+
+	return os.Remove(path)
+}
+
+// NewUnixInvalidator creates an unix invalidator listening on path and publishing to peers.
+// The socket file of path will be removed before listening and after closing, and it returns an error if path isn't a socket.
+func NewUnixInvalidator(path string, peers ...string) (*UnixInvalidator, error) {
+	if err := removeSocket(path); err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	ui := &UnixInvalidator{
+		path:    path,
+		conn:    conn,
+		peers:   make([]*net.UnixAddr, 0, len(peers)),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	for _, peer := range peers {
+		ui.peers = append(ui.peers, &net.UnixAddr{Name: peer, Net: "unixgram"})
+	}
+
+	go ui.receive()
+	return ui, nil
+}
+
+// receive reads invalidations from socket and notifies subscribers until the socket is closed.
+// Invalid datagrams are ignored, and it backs off after a read error so a persistent error won't spin.
+func (ui *UnixInvalidator) receive() {
+	defer close(ui.done)
+
+	buffer := make([]byte, maxInvalidationSize)
+	backoff := minReceiveBackoff
+
+	for {
+		n, _, err := ui.conn.ReadFromUnix(buffer)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+
+		if err != nil {
+			timer := time.NewTimer(backoff)
+
+			select {
+			case <-ui.closing:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+
+			if backoff *= 2; backoff > maxReceiveBackoff {
+				backoff = maxReceiveBackoff
+			}
+
+			continue
+		}
+
+		backoff = minReceiveBackoff
+
+		var invalidation Invalidation
+		if err = json.Unmarshal(buffer[:n], &invalidation); err != nil {
+			continue
+		}
+
+		ui.subscribers.notify(invalidation)
+	}
+}
+
+// Publish publishes an invalidation to all peers.
+// Peers which are unavailable are skipped, and it returns the first error of them.
+// Writing to peers will be timeout at the deadline of ctx, so a slow peer won't block it forever.
+// Concurrent publishes are serialized, so each of them writes with its own deadline.
+func (ui *UnixInvalidator) Publish(ctx context.Context, invalidation Invalidation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(invalidation)
+	if err != nil {
+		return err
+	}
+
+	ui.writeLock.Lock()
+	defer ui.writeLock.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	if err = ui.conn.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+
+	for _, peer := range ui.peers {
+		if _, writeErr := ui.conn.WriteToUnix(data, peer); writeErr != nil && err == nil {
+			err = writeErr
+		}
+	}
+
+	return err
+}
+
+// Subscribe calls fn with each invalidation received and returns a function to unsubscribe.
+// Notice that fn is called in the receiving goroutine, so a slow fn delays the following invalidations.
+func (ui *UnixInvalidator) Subscribe(fn func(invalidation Invalidation)) (unsubscribe func()) {
+	id := ui.subscribers.add(fn)
+
+	return func() {
+		ui.subscribers.remove(id)
+	}
+}
+
+// Close closes the socket and removes its file.
+func (ui *UnixInvalidator) Close() error {
+	var err error
+
+	ui.closeOnce.Do(func() {
+		close(ui.closing)
+
+		err = ui.conn.Close()
+		<-ui.done
+
+		if removeErr := removeSocket(ui.path); removeErr != nil && err == nil {
+			err = removeErr
+		}
+	})
+
+	return err
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestUnixInvalidators(t *testing.T) (*UnixInvalidator, *UnixInvalidator) {
+	dir, err := os.MkdirTemp("", "cachego")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	path := filepath.Join(dir, "a.sock")
+	otherPath := filepath.Join(dir, "b.sock")
+
+	invalidator, err := NewUnixInvalidator(path, otherPath)
+	if err != nil {
+		t.Skipf("unix datagram socket isn't supported: %+v", err)
+	}
+
+	other, err := NewUnixInvalidator(otherPath, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return invalidator, other
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestUnixInvalidator$
+func TestUnixInvalidator(t *testing.T) {
+	invalidator, other := newTestUnixInvalidators(t)
+	defer invalidator.Close()
+	defer other.Close()
+
+	received := make(chan Invalidation, 1)
+	unsubscribe := other.Subscribe(func(invalidation Invalidation) {
+		received <- invalidation
+	})
+
+	want := Invalidation{Source: "test", Key: "user:", Prefix: true}
+	if err := invalidator.Publish(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := <-received; got != want {
+		t.Fatalf("got %+v != want %+v", got, want)
+	}
+
+	unsubscribe()
+
+	// Publishing to a closed peer should return an error.
+	other.Close()
+
+	if err := invalidator.Publish(context.Background(), want); err == nil {
+		t.Fatal("publishing to a closed peer should fail")
+	}
+
+	if _, err := os.Stat(other.path); !os.IsNotExist(err) {
+		t.Fatalf("socket file %s isn't removed: %+v", other.path, err)
+	}
+
+	if err := invalidator.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := invalidator.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestUnixInvalidatorPublishConcurrently$
+func TestUnixInvalidatorPublishConcurrently(t *testing.T) {
+	invalidator, other := newTestUnixInvalidators(t)
+	defer invalidator.Close()
+	defer other.Close()
+
+	var received int64
+	other.Subscribe(func(invalidation Invalidation) {
+		atomic.AddInt64(&received, 1)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			// Each publish should write with its own deadline.
+			ctx, cancel := context.WithTimeout(context.Background(), time.Duration(i+1)*time.Second)
+			defer cancel()
+
+			if err := invalidator.Publish(ctx, Invalidation{Key: strconv.Itoa(i)}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	waitFor(t, func() bool {
+		return atomic.LoadInt64(&received) == 16
+	})
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestUnixInvalidatorCloseError$
+func TestUnixInvalidatorCloseError(t *testing.T) {
+	invalidator, other := newTestUnixInvalidators(t)
+	defer other.Close()
+
+	// The socket file is replaced by a regular file, so it shouldn't be removed.
+	if err := os.Remove(invalidator.path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(invalidator.path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := invalidator.Close(); err == nil {
+		t.Fatal("closing should return the error of removing socket")
+	}
+
+	if _, err := os.Stat(invalidator.path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestUnixInvalidatorCache$
+func TestUnixInvalidatorCache(t *testing.T) {
+	invalidator, other := newTestUnixInvalidators(t)
+	defer invalidator.Close()
+	defer other.Close()
+
+	cache := NewCache(WithInvalidator(invalidator))
+	defer cache.Close()
+
+	otherCache := NewCache(WithInvalidator(other))
+	defer otherCache.Close()
+
+	// Invalidations without source are sent to peers only, so publish them by both invalidators.
+	testCacheInvalidate(t, multiInvalidator{invalidator, other}, cache, otherCache)
+}
+
+// multiInvalidator publishes invalidations by all invalidators.
+type multiInvalidator []Invalidator
+
+func (mi multiInvalidator) Publish(ctx context.Context, invalidation Invalidation) error {
+	for _, invalidator := range mi {
+		if err := invalidator.Publish(ctx, invalidation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (mi multiInvalidator) Subscribe(fn func(invalidation Invalidation)) (unsubscribe func()) {
+	return func() {}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestNewUnixInvalidatorNotSocket$
+func TestNewUnixInvalidatorNotSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "cachego")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.txt")
+	if err = os.WriteFile(path, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = NewUnixInvalidator(path); err == nil {
+		t.Fatal("creating invalidator on a regular file should return an error")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "data" {
+		t.Fatalf("data %q != %q", data, "data")
+	}
+}
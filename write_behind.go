@@ -0,0 +1,278 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// errPendingDelete is returned when loading a key which is deleted by a pending write.
+	errPendingDelete = errors.New("cachego: key is deleted by a pending write")
+)
+
+// pendingWrite is a write of key waiting to be flushed to store.
+// Its retryAt uses the real time instead of the now function of config, so retries won't be stuck by a fake clock.
+type pendingWrite struct {
+	value   interface{}
+	deleted bool
+
+	// attempts is the count of failed flushes, and retryAt is the time when it can be flushed again.
+	attempts int
+	retryAt  int64
+}
+
+// writeBehind collects writes of keys and flushes them to store in background.
+// Writes of the same key are coalesced, so only the last one will be flushed.
+type writeBehind struct {
+	*config
+
+	pending  map[string]*pendingWrite
+	flushing map[string]*pendingWrite
+	lock     sync.Mutex
+
+	wake   chan struct{}
+	cancel func()
+	done   chan struct{}
+}
+
+func newWriteBehind(conf *config) *writeBehind {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	wb := &writeBehind{
+		config:   conf,
+		pending:  make(map[string]*pendingWrite, mapInitialCap),
+		flushing: make(map[string]*pendingWrite, mapInitialCap),
+		wake:     make(chan struct{}, 1),
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	go wb.run(ctx)
+	return wb
+}
+
+// run flushes writes every interval, or right away if there are enough writes for a batch.
+func (wb *writeBehind) run(ctx context.Context) {
+	defer close(wb.done)
+
+	ticker := time.NewTicker(wb.writeBehindInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-wb.wake:
+		}
+
+		wb.flush(now())
+	}
+}
+
+// write adds a write of key to pending writes, and replaces the pending one of key if exists.
+func (wb *writeBehind) write(key string, value interface{}, deleted bool) {
+	wb.lock.Lock()
+	wb.pending[key] = &pendingWrite{value: value, deleted: deleted}
+	full := len(wb.pending) >= wb.writeBehindBatch
+	wb.lock.Unlock()
+
+	if full {
+		select {
+		case wb.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// lookup returns the latest write of key which isn't flushed yet.
+func (wb *writeBehind) lookup(key string) (value interface{}, deleted bool, found bool) {
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+
+	w, ok := wb.pending[key]
+	if !ok {
+		w, ok = wb.flushing[key]
+	}
+
+	if !ok {
+		return nil, false, false
+	}
+
+	return w.value, w.deleted, true
+}
+
+// take takes a batch of pending writes which can be flushed at now.
+func (wb *writeBehind) take(now int64) map[string]*pendingWrite {
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+
+	batch := make(map[string]*pendingWrite, wb.writeBehindBatch)
+	for key, w := range wb.pending {
+		if len(batch) >= wb.writeBehindBatch {
+			break
+		}
+
+		if w.retryAt > now {
+			continue
+		}
+
+		delete(wb.pending, key)
+		wb.flushing[key] = w
+		batch[key] = w
+	}
+
+	return batch
+}
+
+// finish finishes a flushed write of key with err.
+// A failed write will be retried with exponential backoff unless it's replaced by a newer write or runs out of retries.
+func (wb *writeBehind) finish(key string, w *pendingWrite, now int64, err error) (discarded bool) {
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+
+	if wb.flushing[key] == w {
+		delete(wb.flushing, key)
+	}
+
+	if err == nil {
+		return false
+	}
+
+	if _, ok := wb.pending[key]; ok {
+		return false
+	}
+
+	w.attempts++
+	if w.attempts > wb.writeBehindRetries {
+		return true
+	}
+
+	w.retryAt = now + (wb.writeBehindBackoff << (w.attempts - 1)).Nanoseconds()
+	wb.pending[key] = w
+
+	return false
+}
+
+// flushBatch writes a batch to store and returns the first error of writes which are discarded.
+func (wb *writeBehind) flushBatch(batch map[string]*pendingWrite, now int64) (err error) {
+	errs := make(map[string]error, len(batch))
+	ctx := context.Background()
+
+	if bs, ok := wb.store.(BatchStore); ok {
+		entries := make(map[string]interface{}, len(batch))
+		keys := make([]string, 0, len(batch))
+
+		for key, w := range batch {
+			if w.deleted {
+				keys = append(keys, key)
+			} else {
+				entries[key] = w.value
+			}
+		}
+
+		var storeErr, deleteErr error
+		if len(entries) > 0 {
+			storeErr = bs.StoreMulti(ctx, entries)
+		}
+
+		if len(keys) > 0 {
+			deleteErr = bs.DeleteMulti(ctx, keys)
+		}
+
+		for key, w := range batch {
+			if w.deleted {
+				errs[key] = deleteErr
+			} else {
+				errs[key] = storeErr
+			}
+		}
+	} else {
+		for key, w := range batch {
+			if w.deleted {
+				errs[key] = wb.store.Delete(ctx, key)
+			} else {
+				errs[key] = wb.store.Store(ctx, key, w.value)
+			}
+		}
+	}
+
+	for key, w := range batch {
+		if !wb.finish(key, w, now, errs[key]) {
+			continue
+		}
+
+		wb.notifyStoreError(key, errs[key])
+
+		if err == nil {
+			err = errs[key]
+		}
+	}
+
+	return err
+}
+
+// flush flushes pending writes which can be flushed at now in batches.
+// It returns the first error of writes which are discarded after all retries fail.
+func (wb *writeBehind) flush(now int64) (err error) {
+	for {
+		batch := wb.take(now)
+		if len(batch) <= 0 {
+			return err
+		}
+
+		if flushErr := wb.flushBatch(batch, now); err == nil {
+			err = flushErr
+		}
+	}
+}
+
+// nextRetryAt returns the earliest time when a pending write can be flushed.
+func (wb *writeBehind) nextRetryAt() (retryAt int64, found bool) {
+	wb.lock.Lock()
+	defer wb.lock.Unlock()
+
+	for _, w := range wb.pending {
+		if !found || w.retryAt < retryAt {
+			retryAt = w.retryAt
+			found = true
+		}
+	}
+
+	return retryAt, found
+}
+
+// close stops flushing in background and flushes all pending writes until they succeed or run out of retries.
+func (wb *writeBehind) close() (err error) {
+	wb.cancel()
+	<-wb.done
+
+	for {
+		if flushErr := wb.flush(now()); err == nil {
+			err = flushErr
+		}
+
+		retryAt, found := wb.nextRetryAt()
+		if !found {
+			return err
+		}
+
+		time.Sleep(time.Duration(retryAt - now()))
+	}
+}
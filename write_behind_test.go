@@ -0,0 +1,252 @@
+// Copyright 2025 FishGoddess. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachego
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testBatchStore is a test store which counts its batches.
+type testBatchStore struct {
+	*testStore
+
+	batches int
+}
+
+func (tbs *testBatchStore) StoreMulti(ctx context.Context, entries map[string]interface{}) error {
+	tbs.lock.Lock()
+	defer tbs.lock.Unlock()
+
+	tbs.batches++
+	if tbs.fail() {
+		return errTestStore
+	}
+
+	for key, value := range entries {
+		tbs.data[key] = value
+	}
+
+	return nil
+}
+
+func (tbs *testBatchStore) DeleteMulti(ctx context.Context, keys []string) error {
+	tbs.lock.Lock()
+	defer tbs.lock.Unlock()
+
+	tbs.batches++
+	if tbs.fail() {
+		return errTestStore
+	}
+
+	for _, key := range keys {
+		delete(tbs.data, key)
+	}
+
+	return nil
+}
+
+func newTestWriteBehind(store Store, writeBehindBatch int) *writeBehind {
+	conf := newDefaultConfig()
+	conf.store = store
+	conf.writeBehindBatch = writeBehindBatch
+	conf.writeBehindInterval = time.Hour
+	conf.writeBehindBackoff = time.Millisecond
+
+	return newWriteBehind(conf)
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWriteBehind$
+func TestWriteBehind(t *testing.T) {
+	store := newTestStore()
+	store.data["deleted"] = "value"
+
+	wb := newTestWriteBehind(store, 100)
+	wb.write("key", 1, false)
+	wb.write("key", 2, false)
+	wb.write("deleted", nil, true)
+
+	if value, deleted, found := wb.lookup("key"); !found || deleted || value != 2 {
+		t.Fatalf("value %+v, deleted %+v, found %+v is wrong", value, deleted, found)
+	}
+
+	if _, deleted, found := wb.lookup("deleted"); !found || !deleted {
+		t.Fatalf("deleted %+v, found %+v is wrong", deleted, found)
+	}
+
+	if _, writes := store.counts(); writes != 0 {
+		t.Fatalf("writes %d != 0", writes)
+	}
+
+	if err := wb.flush(now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, writes := store.counts(); writes != 2 {
+		t.Fatalf("writes %d != 2", writes)
+	}
+
+	if value, found := store.get("key"); !found || value != 2 {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if _, found := store.get("deleted"); found {
+		t.Fatal("deleted key found in store")
+	}
+
+	if _, _, found := wb.lookup("key"); found {
+		t.Fatal("flushed key found in write-behind")
+	}
+
+	if err := wb.close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWriteBehindBatch$
+func TestWriteBehindBatch(t *testing.T) {
+	store := &testBatchStore{testStore: newTestStore()}
+
+	wb := newTestWriteBehind(store, 10)
+
+	for i := 0; i < 25; i++ {
+		wb.lock.Lock()
+		wb.pending[strconv.Itoa(i)] = &pendingWrite{value: i}
+		wb.lock.Unlock()
+	}
+
+	if err := wb.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if store.batches != 3 || len(store.data) != 25 {
+		t.Fatalf("store.batches %d, len(store.data) %d is wrong", store.batches, len(store.data))
+	}
+
+	// Enough writes for a batch should be flushed right away.
+	wb = newTestWriteBehind(store, 10)
+
+	for i := 0; i < 10; i++ {
+		wb.write(strconv.Itoa(i), nil, true)
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, found := store.get("0"); !found {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, found := store.get("0"); found {
+		t.Fatal("key 0 isn't deleted in time")
+	}
+
+	wb.close()
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWriteBehindRetry$
+func TestWriteBehindRetry(t *testing.T) {
+	store := newTestStore()
+	store.failures = 2
+
+	wb := newTestWriteBehind(store, 100)
+	wb.write("key", "value", false)
+
+	// The failed write should be retried after its backoff.
+	if err := wb.flush(now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _, found := wb.lookup("key"); !found || value != "value" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	if err := wb.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, writes := store.counts(); writes != 3 {
+		t.Fatalf("writes %d != 3", writes)
+	}
+
+	if value, found := store.get("key"); !found || value != "value" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	// A write should be discarded after all retries fail.
+	var errKeys []string
+
+	store.failures = 100
+
+	conf := newDefaultConfig()
+	conf.store = store
+	conf.writeBehindBackoff = time.Millisecond
+	conf.onStoreError = func(key string, err error) {
+		errKeys = append(errKeys, key)
+	}
+
+	wb = newWriteBehind(conf)
+
+	wb.write("key", "new", false)
+
+	if err := wb.close(); !errors.Is(err, errTestStore) {
+		t.Fatalf("err %+v isn't errTestStore", err)
+	}
+
+	if len(errKeys) != 1 || errKeys[0] != "key" {
+		t.Fatalf("errKeys %+v is wrong", errKeys)
+	}
+
+	if _, writes := store.counts(); writes != 3+1+wb.writeBehindRetries {
+		t.Fatalf("writes %d != %d", writes, 3+1+wb.writeBehindRetries)
+	}
+}
+
+// go test -v -cover -count=1 -test.cpu=1 -run=^TestWriteBehindReadThrough$
+func TestWriteBehindReadThrough(t *testing.T) {
+	store := newTestStore()
+	store.data["key"] = "old"
+
+	cache := newTestStoreCache(store, ReadThrough|WriteBehind)
+
+	// Pending writes should be read before they are flushed, so keys won't be stale.
+	cache.Set("key", "new", NoTTL)
+	cache.Reset()
+
+	if value, found := cache.Get("key"); !found || value != "new" {
+		t.Fatalf("value %+v, found %+v is wrong", value, found)
+	}
+
+	cache.Remove("key")
+	if value, found := cache.Get("key"); found {
+		t.Fatalf("removed key found with value %+v", value)
+	}
+
+	if loads, _ := store.counts(); loads != 0 {
+		t.Fatalf("loads %d != 0", loads)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, found := store.get("key"); found {
+		t.Fatal("removed key found in store")
+	}
+}